@@ -14,12 +14,19 @@ func (c *Client) CreateDeploymentTarget(
 	selector string,
 	preview bool,
 ) (*deployment_target.CreateDeploymentTargetResponse, error) {
-	resp := &deployment_target.CreateDeploymentTargetResponse{}
-
-	req := &deployment_target.CreateDeploymentTargetRequest{
+	return c.CreateDeploymentTargetWithOptions(ctx, projectID, clusterID, &deployment_target.CreateDeploymentTargetRequest{
 		Selector: selector,
 		Preview:  preview,
-	}
+	})
+}
+
+// CreateDeploymentTargetWithOptions creates a new deployment target from a full request, e.g. to record the pull request a preview target is created for
+func (c *Client) CreateDeploymentTargetWithOptions(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *deployment_target.CreateDeploymentTargetRequest,
+) (*deployment_target.CreateDeploymentTargetResponse, error) {
+	resp := &deployment_target.CreateDeploymentTargetResponse{}
 
 	err := c.postRequest(
 		fmt.Sprintf(
@@ -0,0 +1,131 @@
+package authz
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// ImpersonateProjectHeader is the header an admin sets to make a request as a member of another project
+	ImpersonateProjectHeader = "X-Impersonate-Project"
+	// ImpersonateClusterHeader is the header an admin sets, along with ImpersonateProjectHeader, to make a request against a cluster of the impersonated project
+	ImpersonateClusterHeader = "X-Impersonate-Cluster"
+)
+
+// impersonation is the project and cluster an admin is impersonating a member of
+type impersonation struct {
+	projectID uint
+	// clusterID is 0 if only the project is impersonated
+	clusterID uint
+}
+
+// impersonationFromHeaders returns the impersonation requested through the impersonation headers, or nil if neither header is set
+func impersonationFromHeaders(r *http.Request) (*impersonation, error) {
+	projectHeader := r.Header.Get(ImpersonateProjectHeader)
+	clusterHeader := r.Header.Get(ImpersonateClusterHeader)
+
+	if projectHeader == "" && clusterHeader == "" {
+		return nil, nil
+	}
+	if projectHeader == "" {
+		return nil, fmt.Errorf("%s requires %s to be set", ImpersonateClusterHeader, ImpersonateProjectHeader)
+	}
+
+	projectID, err := strconv.ParseUint(projectHeader, 10, 64)
+	if err != nil || projectID == 0 {
+		return nil, fmt.Errorf("%s must be a project id", ImpersonateProjectHeader)
+	}
+
+	imp := &impersonation{projectID: uint(projectID)}
+
+	if clusterHeader != "" {
+		clusterID, err := strconv.ParseUint(clusterHeader, 10, 64)
+		if err != nil || clusterID == 0 {
+			return nil, fmt.Errorf("%s must be a cluster id", ImpersonateClusterHeader)
+		}
+		imp.clusterID = uint(clusterID)
+	}
+
+	return imp, nil
+}
+
+// impersonate replaces the project and cluster of the request scopes with the impersonated ones, so that the project and cluster
+// scoped middleware resolve the scopes as they would for a member of the impersonated project. Only users listed in the server's
+// impersonation admins may impersonate, and only with read-only requests. Every impersonated request is recorded in the audit log
+// of the impersonated project; if it cannot be recorded, the request is rejected.
+func (h *PolicyHandler) impersonate(
+	ctx context.Context,
+	r *http.Request,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+	imp *impersonation,
+) apierrors.RequestError {
+	ctx, span := telemetry.NewSpan(ctx, "impersonate")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "impersonated-project-id", Value: int(imp.projectID)},
+		telemetry.AttributeKV{Key: "impersonated-cluster-id", Value: int(imp.clusterID)},
+	)
+
+	if r.Context().Value("api_token") != nil {
+		err := telemetry.Error(ctx, span, nil, "impersonation is not allowed with api tokens")
+		return apierrors.NewErrPassThroughToClient(err, http.StatusForbidden)
+	}
+
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	if user == nil || !h.isImpersonationAdmin(user.ID) {
+		err := telemetry.Error(ctx, span, nil, "user is not allowed to impersonate")
+		return apierrors.NewErrPassThroughToClient(err, http.StatusForbidden)
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "user-id", Value: int(user.ID)})
+
+	if h.endpointMeta.Verb != types.APIVerbGet && h.endpointMeta.Verb != types.APIVerbList {
+		err := telemetry.Error(ctx, span, nil, "impersonated requests must be read-only")
+		return apierrors.NewErrPassThroughToClient(err, http.StatusForbidden)
+	}
+
+	projectScope, ok := reqScopes[types.ProjectScope]
+	if !ok {
+		err := telemetry.Error(ctx, span, nil, "impersonation is only supported on project-scoped endpoints")
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+	projectScope.Resource.UInt = imp.projectID
+
+	if clusterScope, ok := reqScopes[types.ClusterScope]; ok {
+		if imp.clusterID == 0 {
+			err := telemetry.Error(ctx, span, nil, fmt.Sprintf("%s is required on cluster-scoped endpoints", ImpersonateClusterHeader))
+			return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+		}
+		clusterScope.Resource.UInt = imp.clusterID
+	}
+
+	_, err := h.config.Repo.AuditLog().Insert(ctx, &models.AuditLogEntry{
+		ProjectID: imp.projectID,
+		ClusterID: imp.clusterID,
+		UserID:    user.ID,
+		Action:    string(types.AuditLogAction_Impersonate),
+		Detail:    fmt.Sprintf("%s %s", r.Method, r.URL.Path),
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error recording impersonation in audit log")
+		return apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError)
+	}
+
+	return nil
+}
+
+func (h *PolicyHandler) isImpersonationAdmin(userID uint) bool {
+	for _, adminID := range h.config.ServerConf.ImpersonationAdminUsers {
+		if adminID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -51,6 +51,25 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	imp, err := impersonationFromHeaders(r)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid impersonation headers")
+		apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), true)
+		return
+	}
+	if imp != nil {
+		if reqErr := h.impersonate(ctx, r, reqScopes, imp); reqErr != nil {
+			apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, reqErr, true)
+			return
+		}
+
+		// impersonation admins are not members of the impersonated project, so its policies are not checked
+		ctx = NewRequestScopeCtx(ctx, reqScopes)
+		r = r.Clone(ctx)
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
 	policyLoaderOpts := &policy.PolicyLoaderOpts{}
 
 	// first check if an api token exists in context
@@ -42,6 +42,33 @@ var hasScopeAccessTests = []testHasScopeAccess{
 		},
 		expRes: false,
 	},
+	{
+		description: "viewer access can read cluster resources",
+		policy:      types.ViewerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbGet,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		// e.g. viewer api tokens used by CI cannot roll back apps
+		description: "viewer access cannot perform update operation",
+		policy:      types.ViewerPolicy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+		},
+		expRes: false,
+	},
 	{
 		description: "developer access cannot write settings",
 		policy:      types.DeveloperPolicy,
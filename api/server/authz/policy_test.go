@@ -1,6 +1,7 @@
 package authz_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -228,6 +230,103 @@ func TestPolicyMiddlewareFailBadParam(t *testing.T) {
 	})
 }
 
+func TestPolicyMiddlewareImpersonation(t *testing.T) {
+	config, handler, next := loadHandlers(t, types.APIRequestMetadata{
+		Verb:   types.APIVerbGet,
+		Method: types.HTTPVerbGet,
+		Scopes: []types.PermissionScope{
+			types.ProjectScope,
+			types.ClusterScope,
+		},
+	}, false, false)
+
+	user := apitest.CreateTestUser(t, config, true)
+	config.ServerConf.ImpersonationAdminUsers = []uint{user.ID}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1/clusters/1", nil)
+
+	req = apitest.WithURLParams(t, req, map[string]string{
+		"project_id": "1",
+		"cluster_id": "1",
+	})
+	req.Header.Set(authz.ImpersonateProjectHeader, "2")
+	req.Header.Set(authz.ImpersonateClusterHeader, "3")
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	handler.ServeHTTP(rr, req)
+
+	// the user is not a member of the impersonated project, but its scopes are resolved anyway
+	assertNextHandlerCalled(t, next, rr, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb: types.APIVerbGet,
+			Resource: types.NameOrUInt{
+				UInt: 2,
+			},
+		},
+		types.ClusterScope: {
+			Verb: types.APIVerbGet,
+			Resource: types.NameOrUInt{
+				UInt: 3,
+			},
+		},
+	})
+
+	entries, err := config.Repo.AuditLog().ListByProjectID(context.Background(), 2, repository.AuditLogFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, entries, 1, "impersonation should be audited")
+	assert.Equal(t, string(types.AuditLogAction_Impersonate), entries[0].Action)
+	assert.Equal(t, user.ID, entries[0].UserID)
+}
+
+func TestPolicyMiddlewareImpersonationRejected(t *testing.T) {
+	tests := []struct {
+		description string
+		verb        types.APIVerb
+		admin       bool
+	}{
+		{description: "non-admin users cannot impersonate", verb: types.APIVerbGet, admin: false},
+		{description: "impersonated requests must be read-only", verb: types.APIVerbUpdate, admin: true},
+	}
+
+	for _, test := range tests {
+		config, handler, next := loadHandlers(t, types.APIRequestMetadata{
+			Verb:   test.verb,
+			Method: types.HTTPVerbGet,
+			Scopes: []types.PermissionScope{
+				types.ProjectScope,
+			},
+		}, false, false)
+
+		user := apitest.CreateTestUser(t, config, true)
+		_, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+			Name: "test-project",
+		}, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.admin {
+			config.ServerConf.ImpersonationAdminUsers = []uint{user.ID}
+		}
+
+		req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1", nil)
+
+		req = apitest.WithURLParams(t, req, map[string]string{
+			"project_id": "1",
+		})
+		req.Header.Set(authz.ImpersonateProjectHeader, "2")
+
+		req = apitest.WithAuthenticatedUser(t, req, user)
+
+		handler.ServeHTTP(rr, req)
+
+		assert.False(t, next.WasCalled, test.description)
+		apitest.AssertForbiddenError(t, rr)
+	}
+}
+
 func loadHandlers(
 	t *testing.T,
 	endpointMeta types.APIRequestMetadata,
@@ -0,0 +1,113 @@
+package audit_log
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// defaultAuditLogLimit is the number of entries returned if no limit is requested
+const defaultAuditLogLimit = 100
+
+// ListAuditLogHandler is the handler for GET /api/projects/{project_id}/audit-log
+type ListAuditLogHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListAuditLogHandler returns a new ListAuditLogHandler
+func NewListAuditLogHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListAuditLogHandler {
+	return &ListAuditLogHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP lists the deploys, rollbacks and cancels taken on apps in the project, most recent first
+func (c *ListAuditLogHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-audit-log")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditLogRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	filter := repository.AuditLogFilter{
+		Limit: request.Limit,
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultAuditLogLimit
+	}
+
+	var err error
+	if request.Since != "" {
+		filter.Since, err = time.Parse(time.RFC3339, request.Since)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "since must be an RFC3339 timestamp")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	}
+	if request.Until != "" {
+		filter.Until, err = time.Parse(time.RFC3339, request.Until)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "until must be an RFC3339 timestamp")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+	}
+
+	for _, action := range strings.Split(request.Action, ",") {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		if !types.AuditLogAction(action).IsValid() {
+			err := telemetry.Error(ctx, span, fmt.Errorf("unknown action %q", action), "invalid action filter")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		filter.Actions = append(filter.Actions, action)
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "since", Value: request.Since},
+		telemetry.AttributeKV{Key: "until", Value: request.Until},
+		telemetry.AttributeKV{Key: "action", Value: request.Action},
+		telemetry.AttributeKV{Key: "limit", Value: filter.Limit},
+	)
+
+	entries, err := c.Repo().AuditLog().ListByProjectID(ctx, project.ID, filter)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing audit log entries")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "entry-count", Value: len(entries)})
+
+	res := types.ListAuditLogResponse{
+		Entries: make([]types.AuditLogEntry, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		res.Entries = append(res.Entries, entry.ToAuditLogEntryType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,91 @@
+package deployment_target
+
+import (
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CountDeploymentTargetAppsHandler is the handler for the /deployment-targets/{deployment_target_id}/apps/count endpoint
+type CountDeploymentTargetAppsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCountDeploymentTargetAppsHandler handles GET requests to the endpoint /deployment-targets/{deployment_target_id}/apps/count
+func NewCountDeploymentTargetAppsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CountDeploymentTargetAppsHandler {
+	return &CountDeploymentTargetAppsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// CountDeploymentTargetAppsResponse is the response object for the /deployment-targets/{deployment_target_id}/apps/count GET endpoint
+type CountDeploymentTargetAppsResponse struct {
+	// Count is the number of apps with a revision in the deployment target
+	Count int `json:"count"`
+}
+
+// ServeHTTP returns the number of apps with a revision in a deployment target. Unlike listing the latest revisions, revisions are not encoded
+// and apps are not read from the database, so this is cheap enough to poll
+func (c *CountDeploymentTargetAppsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-count-deployment-target-apps")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	deploymentTargetIDStr, reqErr := requestutils.GetURLParamString(r, types.URLParamDeploymentTargetID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(deploymentTargetIDStr)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	latestAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.LatestAppRevisions(ctx, connect.NewRequest(&porterv1.LatestAppRevisionsRequest{
+		ProjectId:          int64(project.ID),
+		DeploymentTargetId: deploymentTargetID.String(),
+	}))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting latest app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if latestAppRevisionsResp == nil || latestAppRevisionsResp.Msg == nil {
+		err := telemetry.Error(ctx, span, nil, "latest app revisions response is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the control plane returns the latest revision of each app, so each revision is a distinct app
+	count := len(latestAppRevisionsResp.Msg.AppRevisions)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-count", Value: count})
+
+	c.WriteResult(w, r, &CountDeploymentTargetAppsResponse{
+		Count: count,
+	})
+}
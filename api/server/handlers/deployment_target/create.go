@@ -1,9 +1,11 @@
 package deployment_target
 
 import (
+	"context"
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -34,6 +36,10 @@ func NewCreateDeploymentTargetHandler(
 type CreateDeploymentTargetRequest struct {
 	Selector string `json:"selector"`
 	Preview  bool   `json:"preview"`
+	// PullRequestNumber is the number of the pull request a preview target is created for
+	PullRequestNumber int `json:"pull_request_number,omitempty"`
+	// Repo is the source repository of the pull request a preview target is created for, e.g. porter-dev/porter
+	Repo string `json:"repo,omitempty"`
 }
 
 // CreateDeploymentTargetResponse is the response object for the /deployment-targets POST endpoint
@@ -92,9 +98,51 @@ func (c *CreateDeploymentTargetHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
+	if request.Preview {
+		// failing to record the pull request only affects how the preview is displayed, so the target is still returned
+		_ = c.savePreviewMetadata(ctx, project.ID, ccpResp.Msg.DeploymentTargetId, models.DeploymentTargetPreviewMetadata{
+			PullRequestNumber: request.PullRequestNumber,
+			Branch:            request.Selector,
+			Repo:              request.Repo,
+		})
+	}
+
 	res := &CreateDeploymentTargetResponse{
 		DeploymentTargetID: ccpResp.Msg.DeploymentTargetId,
 	}
 
 	c.WriteResult(w, r, res)
 }
+
+// savePreviewMetadata records the pull request a preview target was created for, which the cluster control plane does not store
+func (c *CreateDeploymentTargetHandler) savePreviewMetadata(ctx context.Context, projectID uint, deploymentTargetID string, metadata models.DeploymentTargetPreviewMetadata) error {
+	ctx, span := telemetry.NewSpan(ctx, "save-preview-metadata")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID},
+		telemetry.AttributeKV{Key: "pull-request-number", Value: metadata.PullRequestNumber},
+	)
+
+	deploymentTargetUUID, err := uuid.Parse(deploymentTargetID)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error parsing deployment target id")
+	}
+
+	deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(projectID, deploymentTargetUUID)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error reading deployment target")
+	}
+	if deploymentTarget.ID == uuid.Nil {
+		return telemetry.Error(ctx, span, nil, "deployment target not found")
+	}
+
+	deploymentTarget.SetPreviewMetadata(metadata)
+
+	_, err = c.Repo().DeploymentTarget().UpdateDeploymentTargetMetadata(deploymentTarget)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error updating deployment target metadata")
+	}
+
+	return nil
+}
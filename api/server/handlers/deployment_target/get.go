@@ -1,6 +1,7 @@
 package deployment_target
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -66,12 +67,18 @@ func (c *GetDeploymentTargetHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 	}
 
 	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
-		ProjectID:          int64(project.ID),
-		ClusterID:          int64(cluster.ID),
-		DeploymentTargetID: deploymentTargetID,
-		CCPClient:          c.Config().ClusterControlPlaneClient,
+		ProjectID:                  int64(project.ID),
+		ClusterID:                  int64(cluster.ID),
+		DeploymentTargetID:         deploymentTargetID,
+		CCPClient:                  c.Config().ClusterControlPlaneClient,
+		DeploymentTargetRepository: c.Repo().DeploymentTarget(),
 	})
 	if err != nil {
+		if errors.Is(err, deployment_target.ErrDeploymentTargetNotFound) {
+			err := telemetry.Error(ctx, span, err, "deployment target not found")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
 		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
@@ -0,0 +1,236 @@
+package deployment_target
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app/notifications"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// defaultNotificationsPageSize is the number of notifications returned per page when page_size is not set
+	defaultNotificationsPageSize = 50
+	// maxNotificationsPageSize is the maximum number of notifications that can be requested per page
+	maxNotificationsPageSize = 500
+	// defaultNotificationsWindow is how far back notifications are read when since is not set
+	defaultNotificationsWindow = 7 * 24 * time.Hour
+	// maxNotificationEvents is the maximum number of the most recent notification events read per request. Events are converted, collapsed and
+	// filtered after they are read, so the window is bounded rather than paginated in the database
+	maxNotificationEvents = 5000
+)
+
+// ListDeploymentTargetNotificationsHandler is the handler for the /deployment-targets/{deployment_target_id}/notifications endpoint
+type ListDeploymentTargetNotificationsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListDeploymentTargetNotificationsHandler handles GET requests to the endpoint /deployment-targets/{deployment_target_id}/notifications
+func NewListDeploymentTargetNotificationsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListDeploymentTargetNotificationsHandler {
+	return &ListDeploymentTargetNotificationsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ListDeploymentTargetNotificationsRequest is the request object for the /deployment-targets/{deployment_target_id}/notifications GET endpoint
+type ListDeploymentTargetNotificationsRequest struct {
+	// MinSeverity filters out notifications less severe than the given severity. If empty, notifications of all severities are returned
+	MinSeverity notifications.Severity `schema:"min_severity"`
+	// ServiceName filters notifications to those for the given service. Application and revision scoped notifications, which are not
+	// associated with a service, are excluded when this is set. If empty, notifications for all services are returned
+	ServiceName string `schema:"service_name"`
+	// Since is an optional RFC3339 timestamp. If set, only notifications created after this time are returned. Defaults to seven days ago
+	Since string `schema:"since"`
+	// IncludeResolved also returns notifications whose condition has since cleared. By default only active notifications are returned
+	IncludeResolved bool `schema:"include_resolved"`
+	// Page is the 1-indexed page of notifications to return. Defaults to the first page, which holds the most recent notifications
+	Page int `schema:"page" form:"gte=0"`
+	// PageSize is the number of notifications to return per page. Defaults to 50, and may not exceed 500
+	PageSize int `schema:"page_size" form:"gte=0,lte=500"`
+}
+
+// ListDeploymentTargetNotificationsPagination contains pagination details for the /deployment-targets/{deployment_target_id}/notifications endpoint
+type ListDeploymentTargetNotificationsPagination struct {
+	// TotalCount is the total number of notifications matching the filters across all pages
+	TotalCount int `json:"total_count"`
+	// CurrentPage is the page returned in the response
+	CurrentPage int `json:"current_page"`
+	// PageSize is the number of notifications per page
+	PageSize int `json:"page_size"`
+	// HasNextPage is true if there are older notifications after the current page
+	HasNextPage bool `json:"has_next_page"`
+}
+
+// ListDeploymentTargetNotificationsResponse is the response object for the /deployment-targets/{deployment_target_id}/notifications GET endpoint
+type ListDeploymentTargetNotificationsResponse struct {
+	// Notifications are the notifications of all apps in the deployment target, most recent first
+	Notifications []notifications.Notification                `json:"notifications"`
+	Pagination    ListDeploymentTargetNotificationsPagination `json:"pagination"`
+}
+
+// ServeHTTP returns the notifications of every app in a deployment target, most recent first. As with the notifications of a single app,
+// only notifications of the latest revision of each app are returned, and notifications in the old format are skipped. Only the most recent
+// notification events since the requested time, up to maxNotificationEvents, are considered.
+func (c *ListDeploymentTargetNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-deployment-target-notifications")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	deploymentTargetIDStr, reqErr := requestutils.GetURLParamString(r, types.URLParamDeploymentTargetID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(deploymentTargetIDStr)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	request := &ListDeploymentTargetNotificationsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.MinSeverity != "" && !request.MinSeverity.IsValid() {
+		err := telemetry.Error(ctx, span, nil, "invalid min severity")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+
+	since := time.Now().Add(-defaultNotificationsWindow)
+	if request.Since != "" {
+		since, err = time.Parse(time.RFC3339, request.Since)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "since must be an RFC3339 timestamp")
+			c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+			return
+		}
+	}
+
+	page := request.Page
+	if page == 0 {
+		page = 1
+	}
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = defaultNotificationsPageSize
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "since", Value: since.Format(time.RFC3339)},
+		telemetry.AttributeKV{Key: "min-severity", Value: string(request.MinSeverity)},
+		telemetry.AttributeKV{Key: "service-name-filter", Value: request.ServiceName},
+		telemetry.AttributeKV{Key: "include-resolved", Value: request.IncludeResolved},
+		telemetry.AttributeKV{Key: "page", Value: page},
+		telemetry.AttributeKV{Key: "page-size", Value: pageSize},
+	)
+
+	if pageSize > maxNotificationsPageSize {
+		err := telemetry.Error(ctx, span, nil, "page_size must be between 1 and 500")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	latestAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.LatestAppRevisions(ctx, connect.NewRequest(&porterv1.LatestAppRevisionsRequest{
+		ProjectId:          int64(project.ID),
+		DeploymentTargetId: deploymentTargetID.String(),
+	}))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting latest app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if latestAppRevisionsResp == nil || latestAppRevisionsResp.Msg == nil {
+		err := telemetry.Error(ctx, span, nil, "latest app revisions response is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevisionIDs := make([]string, 0, len(latestAppRevisionsResp.Msg.AppRevisions))
+	for _, revision := range latestAppRevisionsResp.Msg.AppRevisions {
+		if revision.GetId() != "" {
+			appRevisionIDs = append(appRevisionIDs, revision.GetId())
+		}
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-count", Value: len(appRevisionIDs)})
+
+	// the most recent events are read, so any event which clears a returned notification is also read, since it is more recent than the notification
+	notificationEvents, err := c.Repo().PorterAppEvent().ListNotificationsByDeploymentTargetID(ctx, deploymentTargetID, appRevisionIDs, since, maxNotificationEvents)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing notifications")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-events-truncated", Value: len(notificationEvents) == maxNotificationEvents})
+
+	// notifications are filtered after conversion, since severity and resolution are derived from the events rather than stored
+	filtered := make([]notifications.Notification, 0)
+	for _, notification := range notifications.NotificationsFromPorterAppEvents(ctx, notificationEvents) {
+		if !request.IncludeResolved && notification.Resolved {
+			continue
+		}
+		if request.MinSeverity != "" && !notification.Severity.AtLeast(request.MinSeverity) {
+			continue
+		}
+		if request.ServiceName != "" && notification.Metadata.ServiceName != request.ServiceName {
+			continue
+		}
+		filtered = append(filtered, notification)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Timestamp.After(filtered[j].Timestamp)
+	})
+
+	totalCount := len(filtered)
+	offset := (page - 1) * pageSize
+	pageNotifications := notifications.PaginateNotifications(filtered, pageSize, offset)
+	hasNextPage := offset+len(pageNotifications) < totalCount
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "notification-event-count", Value: len(notificationEvents)},
+		telemetry.AttributeKV{Key: "notification-count", Value: totalCount},
+	)
+
+	requestutils.SetPaginationHeaders(w, r, "page", requestutils.PaginationHeaders{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+	})
+
+	c.WriteResult(w, r, &ListDeploymentTargetNotificationsResponse{
+		Notifications: pageNotifications,
+		Pagination: ListDeploymentTargetNotificationsPagination{
+			TotalCount:  totalCount,
+			CurrentPage: page,
+			PageSize:    pageSize,
+			HasNextPage: hasNextPage,
+		},
+	})
+}
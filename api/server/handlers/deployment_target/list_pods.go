@@ -0,0 +1,133 @@
+package deployment_target
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListDeploymentTargetPodsHandler is the handler for the /deployment-targets/{deployment_target_id}/pods endpoint
+type ListDeploymentTargetPodsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewListDeploymentTargetPodsHandler handles GET requests to the endpoint /deployment-targets/{deployment_target_id}/pods
+func NewListDeploymentTargetPodsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListDeploymentTargetPodsHandler {
+	return &ListDeploymentTargetPodsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ListDeploymentTargetPodsResponse is the response object for the /deployment-targets/{deployment_target_id}/pods GET endpoint
+type ListDeploymentTargetPodsResponse struct {
+	// PodsByApp are the status of the pods in the deployment target, keyed by app name
+	PodsByApp map[string][]porter_app.PodStatus `json:"pods_by_app"`
+}
+
+// ServeHTTP returns the status of all Porter-managed pods in a deployment target, across apps, grouped by app name
+func (c *ListDeploymentTargetPodsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-deployment-target-pods")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	deploymentTargetIDStr, reqErr := requestutils.GetURLParamString(r, types.URLParamDeploymentTargetID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	// the id is used in the label selector, so it must be validated to not alter the rest of the selector
+	deploymentTargetID, err := uuid.Parse(deploymentTargetIDStr)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if errors.Is(err, deployment_target.ErrDeploymentTargetNotFound) {
+			err := telemetry.Error(ctx, span, err, "deployment target not found")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	// listing pods with an empty namespace would search all namespaces, returning pods from other targets
+	if namespace == "" {
+		err := telemetry.Error(ctx, span, nil, "deployment target namespace is empty; the deployment target is not ready yet")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusConflict), types.APIErrorCode_TargetNotReady))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// only pods labeled with an app name are managed by porter
+	selector := fmt.Sprintf("porter.run/deployment-target-id=%s,porter.run/app-name", deploymentTargetID.String())
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "label-selector", Value: selector})
+
+	podsList, err := agent.GetPodsByLabel(selector, namespace)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to get pods by label")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	podsByApp := make(map[string][]porter_app.PodStatus)
+	for _, pod := range podsList.Items {
+		appName := pod.Labels["porter.run/app-name"]
+		podsByApp[appName] = append(podsByApp[appName], porter_app.PodStatusFromPod(pod, "porter.run/app-revision-id"))
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "pod-count", Value: len(podsList.Items)},
+		telemetry.AttributeKV{Key: "app-count", Value: len(podsByApp)},
+	)
+
+	c.WriteResult(w, r, &ListDeploymentTargetPodsResponse{
+		PodsByApp: podsByApp,
+	})
+}
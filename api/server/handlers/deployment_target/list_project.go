@@ -0,0 +1,88 @@
+package deployment_target
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListProjectDeploymentTargetsHandler is the handler for the project-scoped /deployment-targets endpoint
+type ListProjectDeploymentTargetsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListProjectDeploymentTargetsHandler handles GET requests to the project-scoped endpoint /deployment-targets
+func NewListProjectDeploymentTargetsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListProjectDeploymentTargetsHandler {
+	return &ListProjectDeploymentTargetsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ListProjectDeploymentTargetsRequest is the request object for the project-scoped /deployment-targets GET endpoint
+type ListProjectDeploymentTargetsRequest struct {
+	// ClusterID restricts the targets to a single cluster. If 0, targets in all clusters in the project are returned
+	ClusterID uint `schema:"cluster_id"`
+	// PreviewOnly restricts the targets to preview environments
+	PreviewOnly bool `schema:"preview_only"`
+}
+
+// ListProjectDeploymentTargetsResponse is the response object for the project-scoped /deployment-targets GET endpoint
+type ListProjectDeploymentTargetsResponse struct {
+	DeploymentTargets []types.DeploymentTarget `json:"deployment_targets"`
+}
+
+// ServeHTTP lists the deployment targets in a project, so that a target can be selected before querying app state
+func (c *ListProjectDeploymentTargetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-project-deployment-targets")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
+		err := telemetry.Error(ctx, span, nil, "project does not have validate apply v2 enabled")
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	request := &ListProjectDeploymentTargetsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "requested-cluster-id", Value: request.ClusterID},
+		telemetry.AttributeKV{Key: "preview-only", Value: request.PreviewOnly},
+	)
+
+	deploymentTargets, err := c.Repo().DeploymentTarget().ListForProject(project.ID, request.ClusterID, request.PreviewOnly)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error retrieving deployment targets")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	response := ListProjectDeploymentTargetsResponse{
+		DeploymentTargets: make([]types.DeploymentTarget, 0),
+	}
+
+	for _, dt := range deploymentTargets {
+		if dt == nil {
+			continue
+		}
+
+		response.DeploymentTargets = append(response.DeploymentTargets, *dt.ToDeploymentTargetType())
+	}
+
+	c.WriteResult(w, r, response)
+}
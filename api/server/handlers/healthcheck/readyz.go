@@ -1,14 +1,42 @@
 package healthcheck
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"time"
 
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
-	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 )
 
+// readyzDependencyTimeout is the maximum time spent checking a single dependency
+const readyzDependencyTimeout = 3 * time.Second
+
+// DependencyStatus is the status of a single dependency checked by the readiness probe
+type DependencyStatus string
+
+const (
+	// DependencyStatus_OK means the dependency is reachable
+	DependencyStatus_OK DependencyStatus = "ok"
+	// DependencyStatus_Unavailable means the dependency could not be reached
+	DependencyStatus_Unavailable DependencyStatus = "unavailable"
+	// DependencyStatus_Disabled means the dependency is not configured for this server and was not checked
+	DependencyStatus_Disabled DependencyStatus = "disabled"
+)
+
+// ReadyzResponse is the response object for the /readyz endpoint
+type ReadyzResponse struct {
+	// Dependencies maps dependency name to its status
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+	// Errors maps dependency name to the error encountered checking it
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
 type ReadyzHandler struct {
 	handlers.PorterHandlerWriter
 }
@@ -22,19 +50,73 @@ func NewReadyzHandler(
 	}
 }
 
+// ServeHTTP checks that the database and the cluster control plane are reachable. If any dependency is unavailable,
+// a 503 is returned with the status of each dependency.
 func (v *ReadyzHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	res := &ReadyzResponse{
+		Dependencies: make(map[string]DependencyStatus),
+		Errors:       make(map[string]string),
+	}
+
+	if err := v.checkDatabase(r.Context()); err != nil {
+		res.Dependencies["database"] = DependencyStatus_Unavailable
+		res.Errors["database"] = err.Error()
+	} else {
+		res.Dependencies["database"] = DependencyStatus_OK
+	}
+
+	if v.Config().ClusterControlPlaneClient == nil {
+		res.Dependencies["cluster_control_plane"] = DependencyStatus_Disabled
+	} else if err := v.checkClusterControlPlane(r.Context()); err != nil {
+		res.Dependencies["cluster_control_plane"] = DependencyStatus_Unavailable
+		res.Errors["cluster_control_plane"] = err.Error()
+	} else {
+		res.Dependencies["cluster_control_plane"] = DependencyStatus_OK
+	}
+
+	status := http.StatusOK
+	if len(res.Errors) > 0 {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(res) //nolint:errcheck
+}
+
+func (v *ReadyzHandler) checkDatabase(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readyzDependencyTimeout)
+	defer cancel()
+
 	db, err := v.Config().DB.DB()
 	if err != nil {
-		v.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		return err
+	}
+
+	return db.PingContext(ctx)
+}
+
+// checkClusterControlPlane makes a lightweight request to the cluster control plane. Since there is no dedicated health rpc,
+// any response from the server (including a validation error for the empty request) is treated as reachable.
+func (v *ReadyzHandler) checkClusterControlPlane(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, readyzDependencyTimeout)
+	defer cancel()
+
+	_, err := v.Config().ClusterControlPlaneClient.DeploymentTargets(ctx, connect.NewRequest(&porterv1.DeploymentTargetsRequest{}))
+	if err == nil {
+		return nil
+	}
+
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable, connect.CodeDeadlineExceeded:
+		return err
 	}
 
-	if err := db.Ping(); err != nil {
-		v.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+	if errors.Is(err, context.DeadlineExceeded) {
+		return err
 	}
 
-	writeHealthy(w)
+	return nil
 }
 
 func writeHealthy(w http.ResponseWriter) {
@@ -0,0 +1,114 @@
+package porter_app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// AckAllNotificationsHandler handles requests to the /apps/{porter_app_name}/notifications/ack-all endpoint
+type AckAllNotificationsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewAckAllNotificationsHandler returns a new AckAllNotificationsHandler
+func NewAckAllNotificationsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *AckAllNotificationsHandler {
+	return &AckAllNotificationsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// AckAllNotificationsRequest is the request object for the /apps/{porter_app_name}/notifications/ack-all endpoint
+type AckAllNotificationsRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// Before is an optional RFC3339 timestamp. If set, only notifications created at or before this time are acknowledged
+	Before *time.Time `json:"before,omitempty"`
+}
+
+// AckAllNotificationsResponse is the response object for the /apps/{porter_app_name}/notifications/ack-all endpoint
+type AckAllNotificationsResponse struct {
+	// AcknowledgedCount is the number of notifications which were acknowledged by this request, excluding those which were already acknowledged
+	AcknowledgedCount int64 `json:"acknowledged_count"`
+}
+
+// ServeHTTP marks all notifications of an app in a deployment target as acknowledged, e.g. after resolving an incident.
+// This is idempotent: repeating the request succeeds and acknowledges no further notifications.
+func (c *AckAllNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-ack-all-notifications")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &AckAllNotificationsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	var before time.Time
+	if request.Before != nil {
+		before = *request.Before
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "before", Value: before.Format(time.RFC3339)})
+	}
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: app.ID})
+
+	acknowledgedCount, err := c.Repo().PorterAppEvent().AcknowledgeNotifications(ctx, app.ID, deploymentTargetID, before)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error acknowledging notifications")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "acknowledged-count", Value: int(acknowledgedCount)})
+
+	c.WriteResult(w, r, &AckAllNotificationsResponse{
+		AcknowledgedCount: acknowledgedCount,
+	})
+}
@@ -0,0 +1,103 @@
+package porter_app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// AckNotificationHandler handles requests to the /apps/{porter_app_name}/notifications/{notification_id}/ack endpoint
+type AckNotificationHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewAckNotificationHandler returns a new AckNotificationHandler
+func NewAckNotificationHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *AckNotificationHandler {
+	return &AckNotificationHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP marks a notification as acknowledged so that it can be collapsed in the dashboard.
+// Acknowledging an already acknowledged notification succeeds without changes.
+func (c *AckNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-ack-notification")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	notificationIDString, reqErr := requestutils.GetURLParamString(r, types.URLParamNotificationID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing notification id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	notificationID, err := uuid.Parse(notificationIDString)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing notification id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-id", Value: notificationID.String()})
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	event, err := c.Repo().PorterAppEvent().ReadEvent(ctx, notificationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "notification not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error reading notification")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if event.PorterAppID != app.ID || event.Type != string(types.PorterAppEventType_Notification) {
+		err := telemetry.Error(ctx, span, nil, "notification not found for app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	err = c.Repo().PorterAppEvent().AcknowledgeNotification(ctx, notificationID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error acknowledging notification")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, nil)
+}
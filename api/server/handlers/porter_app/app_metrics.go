@@ -59,6 +59,8 @@ type MetricsRequest struct {
 func (c *AppMetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-app-metrics")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	r = r.Clone(ctx)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
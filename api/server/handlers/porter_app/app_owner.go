@@ -0,0 +1,59 @@
+package porter_app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// requestUserID returns the id of the user making the request, or 0 if the request is not authenticated as a user
+func requestUserID(ctx context.Context) uint {
+	if user, ok := ctx.Value(types.UserScope).(*models.User); ok && user != nil {
+		return user.ID
+	}
+	return 0
+}
+
+// porterAppOwners returns the owner of each app which recorded its creator, keyed by user id.
+// Users who have since been deleted are returned as tombstones rather than omitted, so that the apps still show who created them.
+func porterAppOwners(userRepo repository.UserRepository, apps []*types.PorterApp) (map[uint]*types.PorterAppOwner, error) {
+	owners := make(map[uint]*types.PorterAppOwner)
+
+	var userIDs []uint
+	for _, app := range apps {
+		if app.CreatedByUserID == 0 {
+			continue
+		}
+		if _, ok := owners[app.CreatedByUserID]; ok {
+			continue
+		}
+
+		owners[app.CreatedByUserID] = &types.PorterAppOwner{
+			UserID:  app.CreatedByUserID,
+			Email:   fmt.Sprintf("deleted-user-%d", app.CreatedByUserID),
+			Deleted: true,
+		}
+		userIDs = append(userIDs, app.CreatedByUserID)
+	}
+
+	if len(userIDs) == 0 {
+		return owners, nil
+	}
+
+	users, err := userRepo.ListUsersByIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if owner, ok := owners[user.ID]; ok {
+			owner.Email = user.Email
+			owner.Deleted = false
+		}
+	}
+
+	return owners, nil
+}
@@ -0,0 +1,191 @@
+package porter_app
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// AppRevisionsByTargetHandler handles requests to the /apps/{porter_app_name}/revisions/by-target endpoint
+type AppRevisionsByTargetHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewAppRevisionsByTargetHandler returns a new AppRevisionsByTargetHandler
+func NewAppRevisionsByTargetHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *AppRevisionsByTargetHandler {
+	return &AppRevisionsByTargetHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// AppRevisionsByTargetResponse is the response object for the /apps/{porter_app_name}/revisions/by-target endpoint
+type AppRevisionsByTargetResponse struct {
+	// AppRevisions maps deployment target id to the current revision of the app in the deployment target. Targets which the app is not deployed to are omitted
+	AppRevisions map[string]porter_app.Revision `json:"app_revisions"`
+}
+
+// ServeHTTP returns the current revision of the app in each deployment target of the cluster, including preview targets
+func (c *AppRevisionsByTargetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-app-revisions-by-target")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app == nil || app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	deploymentTargets, err := c.Repo().DeploymentTarget().ListForProject(project.ID, cluster.ID, false)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing deployment targets")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	concurrency := c.Config().ServerConf.CCPBatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchLatestAppRevisionsConcurrency
+	}
+	if concurrency > len(deploymentTargets) {
+		concurrency = len(deploymentTargets)
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-count", Value: len(deploymentTargets)},
+		telemetry.AttributeKV{Key: "concurrency", Value: concurrency},
+	)
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "app-revisions-by-target", c.Config().ServerConf.CCPBulkRequestTimeout))
+	defer cancel()
+
+	res := &AppRevisionsByTargetResponse{
+		AppRevisions: make(map[string]porter_app.Revision),
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		jobs     = make(chan string)
+	)
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for deploymentTargetID := range jobs {
+				revision, deployed, err := c.currentAppRevision(ccpCtx, project.ID, app.ID, deploymentTargetID)
+
+				mu.Lock()
+				switch {
+				case err != nil:
+					if firstErr == nil {
+						firstErr = err
+						// the request fails on the first error, so outstanding calls are abandoned
+						cancel()
+					}
+				case deployed:
+					res.AppRevisions[deploymentTargetID] = revision
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+dispatch:
+	for _, deploymentTarget := range deploymentTargets {
+		select {
+		case jobs <- deploymentTarget.ID.String():
+		case <-ccpCtx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+
+	wg.Wait()
+
+	if firstErr == nil && ccpCtx.Err() != nil {
+		firstErr = ccpCtx.Err()
+	}
+	if firstErr != nil {
+		if isCCPTimeout(firstErr) {
+			err := telemetry.Error(ctx, span, firstErr, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, firstErr, "error getting current app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployed-target-count", Value: len(res.AppRevisions)})
+
+	c.WriteResult(w, r, res)
+}
+
+// currentAppRevision returns the current revision of an app in a deployment target. deployed is false if the app has not been deployed to the target
+func (c *AppRevisionsByTargetHandler) currentAppRevision(ctx context.Context, projectID uint, appID uint, deploymentTargetID string) (porter_app.Revision, bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "app-revision-by-target")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID})
+
+	var revision porter_app.Revision
+
+	currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(projectID),
+		AppId:              int64(appID),
+		DeploymentTargetId: deploymentTargetID,
+	}))
+	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return revision, false, nil
+		}
+		return revision, false, telemetry.Error(ctx, span, err, "error getting current app revision")
+	}
+	if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil || currentAppRevisionResp.Msg.AppRevision == nil {
+		return revision, false, nil
+	}
+
+	revision, err = porter_app.EncodedRevisionFromProto(ctx, currentAppRevisionResp.Msg.AppRevision)
+	if err != nil {
+		return revision, false, telemetry.Error(ctx, span, err, "error encoding revision from proto")
+	}
+	revision.IsCurrent = true
+
+	return revision, true, nil
+}
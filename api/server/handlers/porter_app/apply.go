@@ -68,14 +68,11 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-apply-porter-app")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
-	telemetry.WithAttributes(span,
-		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
-		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
-	)
-
 	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
 		err := telemetry.Error(ctx, span, nil, "project does not have validate apply v2 enabled")
 		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
@@ -211,6 +208,17 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "cli-action", Value: ccpResp.Msg.CliAction.String()})
 
+	// the app proto is only sent on the first apply of a deploy. Subsequent applies of the same deploy reference the revision it created
+	auditLogInput := auditLogEntryInput{
+		Action:             types.AuditLogAction_Deploy,
+		DeploymentTargetID: deploymentTargetID,
+		AppRevisionID:      ccpResp.Msg.PorterAppRevisionId,
+	}
+	if appProto != nil {
+		auditLogInput.AppName = appProto.Name
+	}
+	recordAuditLogEntry(ctx, c.Config(), auditLogInput)
+
 	response := &ApplyPorterAppResponse{
 		AppRevisionId: ccpResp.Msg.PorterAppRevisionId,
 		CLIAction:     ccpResp.Msg.CliAction,
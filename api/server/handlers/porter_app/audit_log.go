@@ -0,0 +1,79 @@
+package porter_app
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// auditLogEntryInput describes a mutating action taken on an app, to be recorded in the project's audit log
+type auditLogEntryInput struct {
+	Action             types.AuditLogAction
+	PorterAppID        uint
+	AppName            string
+	DeploymentTargetID string
+	// AppRevisionID is the id of the revision resulting from the action, if any
+	AppRevisionID string
+	// RevisionNumber is the number of the revision resulting from the action, if known
+	RevisionNumber uint64
+	// Detail describes actions which do not result in a revision, e.g. the name of a restarted pod
+	Detail string
+}
+
+// recordAuditLogEntry records an action in the audit log of the project in the request context, attributed to the api token or user in the request context.
+// The action has already been taken by the time it is recorded, so a failure to record it does not fail the request, but is sent to the alerter
+// since the audit log is expected to be complete
+func recordAuditLogEntry(ctx context.Context, conf *config.Config, input auditLogEntryInput) {
+	ctx, span := telemetry.NewSpan(ctx, "record-audit-log-entry")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "action", Value: string(input.Action)},
+		telemetry.AttributeKV{Key: "app-name", Value: input.AppName},
+		telemetry.AttributeKV{Key: "app-revision-id", Value: input.AppRevisionID},
+	)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	if project == nil {
+		_ = telemetry.Error(ctx, span, nil, "project not found in context")
+		return
+	}
+
+	entry := &models.AuditLogEntry{
+		ProjectID:          project.ID,
+		Action:             string(input.Action),
+		PorterAppID:        input.PorterAppID,
+		AppName:            input.AppName,
+		DeploymentTargetID: input.DeploymentTargetID,
+		AppRevisionID:      input.AppRevisionID,
+		RevisionNumber:     input.RevisionNumber,
+		Detail:             input.Detail,
+	}
+	if cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster); cluster != nil {
+		entry.ClusterID = cluster.ID
+	}
+	// api token requests carry a placeholder user with id 0, so they are attributed to the token and the user who created it
+	if apiToken, _ := ctx.Value("api_token").(*models.APIToken); apiToken != nil {
+		entry.APITokenID = apiToken.UniqueID
+		entry.UserID = apiToken.CreatedByUserID
+	} else if user, _ := ctx.Value(types.UserScope).(*models.User); user != nil {
+		entry.UserID = user.ID
+	}
+
+	if _, err := conf.Repo.AuditLog().Insert(ctx, entry); err != nil {
+		err = telemetry.Error(ctx, span, err, "error recording audit log entry")
+		if conf.Alerter != nil {
+			conf.Alerter.SendAlert(ctx, err, map[string]interface{}{
+				"project_id":      entry.ProjectID,
+				"action":          entry.Action,
+				"app_name":        entry.AppName,
+				"app_revision_id": entry.AppRevisionID,
+				"user_id":         entry.UserID,
+				"api_token_id":    entry.APITokenID,
+			})
+		}
+	}
+}
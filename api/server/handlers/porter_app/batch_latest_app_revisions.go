@@ -0,0 +1,334 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// maxBatchLatestAppRevisionsApps is the maximum number of apps that can be requested in a single batch
+	maxBatchLatestAppRevisionsApps = 100
+	// defaultBatchLatestAppRevisionsConcurrency is the maximum number of concurrent requests made to the cluster control plane per batch
+	// if the concurrency is not configured
+	defaultBatchLatestAppRevisionsConcurrency = 8
+	// ndjsonMediaType is the media type requested through the Accept header to stream the response as newline-delimited json
+	ndjsonMediaType = "application/x-ndjson"
+)
+
+// BatchLatestAppRevisionsHandler handles requests to the /apps/revisions/batch endpoint
+type BatchLatestAppRevisionsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewBatchLatestAppRevisionsHandler returns a new BatchLatestAppRevisionsHandler
+func NewBatchLatestAppRevisionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *BatchLatestAppRevisionsHandler {
+	return &BatchLatestAppRevisionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// BatchLatestAppRevisionsRequest is the request object for the /apps/revisions/batch endpoint
+type BatchLatestAppRevisionsRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// AppNames are the names of the apps to get the latest revisions for
+	AppNames []string `json:"app_names"`
+}
+
+// BatchLatestAppRevisionsResponse is the response object for the /apps/revisions/batch endpoint
+type BatchLatestAppRevisionsResponse struct {
+	// AppRevisions maps app name to the latest revision for the app
+	AppRevisions map[string]porter_app.Revision `json:"app_revisions"`
+	// Errors maps app name to the error encountered getting the latest revision for the app
+	Errors map[string]string `json:"errors"`
+}
+
+// BatchLatestAppRevisionsStreamLine is a single line of the ndjson variant of the /apps/revisions/batch response, requested with Accept: application/x-ndjson.
+// A line is written for each app as soon as its result is available, in no particular order, followed by a final line with only the summary set
+type BatchLatestAppRevisionsStreamLine struct {
+	AppName string `json:"app_name,omitempty"`
+	// AppRevision is the latest revision for the app, if it was retrieved successfully
+	AppRevision *porter_app.Revision `json:"app_revision,omitempty"`
+	// Error is the error encountered getting the latest revision for the app
+	Error string `json:"error,omitempty"`
+	// Summary is only set on the final line
+	Summary *BatchLatestAppRevisionsStreamSummary `json:"summary,omitempty"`
+}
+
+// BatchLatestAppRevisionsStreamSummary aggregates the results of a streamed /apps/revisions/batch response
+type BatchLatestAppRevisionsStreamSummary struct {
+	AppCount      int `json:"app_count"`
+	RevisionCount int `json:"revision_count"`
+	ErrorCount    int `json:"error_count"`
+	// Errors maps app name to the error encountered getting the latest revision for the app
+	Errors map[string]string `json:"errors"`
+}
+
+// ServeHTTP gets the latest revision for each requested app. Apps which fail individually are reported in the errors map rather than failing the request.
+// If the client accepts application/x-ndjson, each app's result is streamed as a line as soon as it is available instead of being buffered into a single object
+func (c *BatchLatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-batch-latest-app-revisions")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &BatchLatestAppRevisionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "app-count", Value: len(request.AppNames)},
+	)
+
+	if len(request.AppNames) == 0 {
+		err := telemetry.Error(ctx, span, nil, "must provide at least one app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if len(request.AppNames) > maxBatchLatestAppRevisionsApps {
+		err := telemetry.Error(ctx, span, nil, "too many app names provided")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	res := &BatchLatestAppRevisionsResponse{
+		AppRevisions: make(map[string]porter_app.Revision),
+		Errors:       make(map[string]string),
+	}
+
+	appNames := make([]string, 0, len(request.AppNames))
+	seen := make(map[string]bool)
+	for _, appName := range request.AppNames {
+		if seen[appName] {
+			continue
+		}
+		seen[appName] = true
+		appNames = append(appNames, appName)
+	}
+
+	concurrency := c.Config().ServerConf.CCPBatchConcurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchLatestAppRevisionsConcurrency
+	}
+	if concurrency > len(appNames) {
+		concurrency = len(appNames)
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "concurrency", Value: concurrency})
+
+	var (
+		wg      sync.WaitGroup
+		jobs    = make(chan string)
+		results = make(chan batchLatestAppRevisionResult)
+	)
+
+	// a fixed pool of workers bounds the number of in-flight calls to the cluster control plane, regardless of the batch size
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for appName := range jobs {
+				revision, err := c.latestAppRevision(ctx, project.ID, cluster.ID, deploymentTargetID, appName)
+				results <- batchLatestAppRevisionResult{appName: appName, revision: revision, err: err}
+			}
+		}()
+	}
+
+	// the request context is canceled if the client disconnects or the request times out, in which case no further apps are dispatched.
+	// Calls already in flight are canceled through the same context
+	go func() {
+		defer close(jobs)
+
+		for _, appName := range appNames {
+			select {
+			case jobs <- appName:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var stream *batchLatestAppRevisionsStream
+	if requestutils.AcceptsMediaType(r, ndjsonMediaType) {
+		stream = newBatchLatestAppRevisionsStream(w)
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "stream", Value: stream != nil})
+
+	for result := range results {
+		line := BatchLatestAppRevisionsStreamLine{AppName: result.appName}
+		if result.err != nil {
+			res.Errors[result.appName] = result.err.Error()
+			line.Error = result.err.Error()
+		} else {
+			res.AppRevisions[result.appName] = result.revision
+			line.AppRevision = &result.revision
+		}
+
+		if stream != nil {
+			stream.writeLine(line)
+		}
+	}
+
+	if ctx.Err() != nil {
+		for _, appName := range appNames {
+			_, hasRevision := res.AppRevisions[appName]
+			_, hasError := res.Errors[appName]
+			if !hasRevision && !hasError {
+				res.Errors[appName] = fmt.Sprintf("request ended before the app was processed: %s", ctx.Err())
+				if stream != nil {
+					stream.writeLine(BatchLatestAppRevisionsStreamLine{AppName: appName, Error: res.Errors[appName]})
+				}
+			}
+		}
+
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// the client has disconnected, so there is no one to write the result to
+			_ = telemetry.Error(ctx, span, ctx.Err(), "request canceled before all apps were processed")
+			return
+		}
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "error-count", Value: len(res.Errors)})
+
+	if stream != nil {
+		stream.writeLine(BatchLatestAppRevisionsStreamLine{
+			Summary: &BatchLatestAppRevisionsStreamSummary{
+				AppCount:      len(appNames),
+				RevisionCount: len(res.AppRevisions),
+				ErrorCount:    len(res.Errors),
+				Errors:        res.Errors,
+			},
+		})
+		if stream.err != nil {
+			_ = telemetry.Error(ctx, span, stream.err, "error writing streamed response")
+		}
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+type batchLatestAppRevisionResult struct {
+	appName  string
+	revision porter_app.Revision
+	err      error
+}
+
+// batchLatestAppRevisionsStream writes the ndjson variant of the /apps/revisions/batch response, flushing each line as it is written
+type batchLatestAppRevisionsStream struct {
+	encoder    *json.Encoder
+	controller *http.ResponseController
+	// err is the first error writing to the client. Once set, further lines are dropped, since the client has most likely disconnected
+	err error
+}
+
+func newBatchLatestAppRevisionsStream(w http.ResponseWriter) *batchLatestAppRevisionsStream {
+	w.Header().Set("Content-Type", ndjsonMediaType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+
+	return &batchLatestAppRevisionsStream{
+		encoder:    json.NewEncoder(w),
+		controller: http.NewResponseController(w),
+	}
+}
+
+func (s *batchLatestAppRevisionsStream) writeLine(line BatchLatestAppRevisionsStreamLine) {
+	if s.err != nil {
+		return
+	}
+
+	// the encoder terminates each value with a newline
+	if err := s.encoder.Encode(line); err != nil {
+		s.err = err
+		return
+	}
+	if err := s.controller.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		s.err = err
+	}
+}
+
+func (c *BatchLatestAppRevisionsHandler) latestAppRevision(ctx context.Context, projectID uint, clusterID uint, deploymentTargetID uuid.UUID, appName string) (porter_app.Revision, error) {
+	ctx, span := telemetry.NewSpan(ctx, "batch-latest-app-revision")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
+
+	var revision porter_app.Revision
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(clusterID, appName)
+	if err != nil {
+		return revision, telemetry.Error(ctx, span, err, "error reading porter app by name")
+	}
+	if app == nil || app.ID == 0 {
+		return revision, telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+	}
+
+	currentAppRevisionReq := connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(projectID),
+		AppId:              int64(app.ID),
+		DeploymentTargetId: deploymentTargetID.String(),
+	})
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "batch-latest-app-revisions", c.Config().ServerConf.CCPBulkRequestTimeout))
+	defer cancel()
+
+	currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, currentAppRevisionReq)
+	if err != nil {
+		return revision, telemetry.Error(ctx, span, err, "error getting current app revision from cluster control plane client")
+	}
+	if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil {
+		return revision, telemetry.Error(ctx, span, nil, "current app revision resp is nil")
+	}
+
+	revision, err = porter_app.EncodedRevisionFromProto(ctx, currentAppRevisionResp.Msg.AppRevision)
+	if err != nil {
+		return revision, telemetry.Error(ctx, span, err, "error encoding revision from proto")
+	}
+
+	return revision, nil
+}
@@ -0,0 +1,239 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultBuildLogLimit is the number of build log lines returned if no limit is requested
+	defaultBuildLogLimit = 1000
+	// maxBuildLogLimit is the maximum number of build log lines that can be requested at once
+	maxBuildLogLimit = 5000
+)
+
+// BuildLogsHandler handles requests to the /apps/{porter_app_name}/revisions/{app_revision_number}/build-logs endpoint
+type BuildLogsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewBuildLogsHandler returns a new BuildLogsHandler
+func NewBuildLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *BuildLogsHandler {
+	return &BuildLogsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// BuildLogsRequest is the request object for the /apps/{porter_app_name}/revisions/{app_revision_number}/build-logs endpoint
+type BuildLogsRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+	// Limit is the maximum number of log lines to return. Defaults to 1000
+	Limit int `schema:"limit"`
+	// Offset is the number of log lines to skip
+	Offset int `schema:"offset"`
+}
+
+// BuildLogsResponse is the response object for the /apps/{porter_app_name}/revisions/{app_revision_number}/build-logs endpoint
+type BuildLogsResponse struct {
+	// Built is false if the revision deployed a prebuilt image, in which case there is no build and no logs
+	Built bool `json:"built"`
+	// Status is the status of the build, e.g. PROGRESSING, SUCCESS or FAILED. This is empty if the revision was not built
+	Status string `json:"status,omitempty"`
+	// Lines are the build log lines, oldest first, starting at offset. Logs are only kept for failed builds
+	Lines []string `json:"lines"`
+	// TotalLines is the total number of build log lines, before limit and offset are applied
+	TotalLines int `json:"total_lines"`
+}
+
+// ServeHTTP returns the build logs of a revision, so that builds which failed before any pods started can be debugged.
+// Build logs are reported by the CLI on the revision's build event when a build fails
+func (c *BuildLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-build-logs")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if revisionNumber == 0 {
+		err := telemetry.Error(ctx, span, nil, "app revision number must be a positive integer")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &BuildLogsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultBuildLogLimit
+	}
+	if limit < 0 || limit > maxBuildLogLimit || request.Offset < 0 {
+		err := telemetry.Error(ctx, span, nil, "limit must be between 1 and 5000 and offset must not be negative")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "limit", Value: limit},
+		telemetry.AttributeKV{Key: "offset", Value: request.Offset},
+	)
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "build-logs", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              porterApp.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			err := telemetry.Error(ctx, span, err, "app revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting app revision by number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, appRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error encoding revision from proto")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-revision-id", Value: encodedRevision.ID},
+		telemetry.AttributeKV{Key: "commit-sha", Value: encodedRevision.CommitSHA},
+	)
+
+	res := &BuildLogsResponse{
+		Lines: make([]string, 0),
+	}
+
+	// revisions which deploy a prebuilt image have no build settings, and so no commit to build from
+	if appRevision.GetApp().GetBuild() == nil {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "built", Value: false})
+		c.WriteResult(w, r, res)
+		return
+	}
+	res.Built = true
+
+	buildEvent, err := c.Repo().PorterAppEvent().ReadBuildEventByAppRevision(ctx, porterApp.ID, deploymentTargetID, encodedRevision.ID, encodedRevision.CommitSHA)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "no build found for app revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting build event from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	res.Status = buildEvent.Status
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "build-event-id", Value: buildEvent.ID.String()},
+		telemetry.AttributeKV{Key: "build-status", Value: buildEvent.Status},
+	)
+
+	lines, err := porter_app.BuildLogLinesFromEventMetadata(buildEvent.Metadata)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading build logs from build event")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	res.TotalLines = len(lines)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "total-lines", Value: res.TotalLines})
+
+	if request.Offset < len(lines) {
+		end := request.Offset + limit
+		if end > len(lines) {
+			end = len(lines)
+		}
+		res.Lines = lines[request.Offset:end]
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,19 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"net"
+
+	"connectrpc.com/connect"
+)
+
+// isCCPTimeout returns true if an error from the cluster control plane client was caused by a timeout rather than an error response
+func isCCPTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) || connect.CodeOf(err) == connect.CodeDeadlineExceeded {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,50 @@
+package porter_app
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// ccpRetryMaxAttempts is the maximum number of attempts made for a cluster control plane call, including the first
+	ccpRetryMaxAttempts = 3
+	// ccpRetryInitialBackoff is the delay before the first retry. The delay doubles before each subsequent retry
+	ccpRetryInitialBackoff = 100 * time.Millisecond
+)
+
+// withCCPRetry calls the cluster control plane with call, retrying with exponential backoff if the call fails with a transient error.
+// Each attempt is given its own timeout, as with withCCPTimeout. Only idempotent reads should be retried. The number of attempts made is recorded on the span.
+func withCCPRetry(ctx context.Context, span trace.Span, timeout time.Duration, call func(ctx context.Context) error) error {
+	backoff := ccpRetryInitialBackoff
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		ccpCtx, cancel := withCCPTimeout(ctx, span, timeout)
+		err = call(ccpCtx)
+		cancel()
+
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "ccp-attempts", Value: attempt})
+
+		if err == nil || !isTransientCCPError(err) || attempt >= ccpRetryMaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			// the request was cancelled, so the error from the last attempt is returned rather than retrying
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isTransientCCPError returns true if a cluster control plane call failed in a way that may succeed if retried, i.e. the control plane
+// was unavailable or did not respond in time. Errors returned by the control plane for the request itself are not transient.
+func isTransientCCPError(err error) bool {
+	return connect.CodeOf(err) == connect.CodeUnavailable || isCCPTimeout(err)
+}
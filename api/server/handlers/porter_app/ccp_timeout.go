@@ -0,0 +1,31 @@
+package porter_app
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withCCPTimeout returns a context for a cluster control plane call which is cancelled after the given timeout, so that a slow control plane
+// cannot tie up the request for longer than the handler's budget. A timeout of 0 leaves the context unchanged. The effective timeout is recorded on the span.
+func withCCPTimeout(ctx context.Context, span trace.Span, timeout time.Duration) (context.Context, context.CancelFunc) {
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "ccp-timeout", Value: timeout.String()})
+
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// ccpTimeout returns the budget for the cluster control plane calls of a handler: the handler's override in CCPHandlerTimeouts if one is set,
+// and defaultTimeout otherwise, e.g. CCPRequestTimeout for interactive endpoints and CCPBulkRequestTimeout for those which operate on many apps
+func ccpTimeout(conf *config.Config, handlerName string, defaultTimeout time.Duration) time.Duration {
+	if timeout, ok := conf.ServerConf.CCPHandlerTimeouts[handlerName]; ok {
+		return timeout
+	}
+	return defaultTimeout
+}
@@ -0,0 +1,224 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CompareDeploymentTargetsHandler handles requests to the /apps/{porter_app_name}/deployment-targets/compare endpoint
+type CompareDeploymentTargetsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewCompareDeploymentTargetsHandler returns a new CompareDeploymentTargetsHandler
+func NewCompareDeploymentTargetsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CompareDeploymentTargetsHandler {
+	return &CompareDeploymentTargetsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// CompareDeploymentTargetsRequest is the request object for the /apps/{porter_app_name}/deployment-targets/compare endpoint
+type CompareDeploymentTargetsRequest struct {
+	// FromDeploymentTargetID is the deployment target to diff from, e.g. staging
+	FromDeploymentTargetID string `schema:"from_deployment_target_id" form:"required,uuid"`
+	// ToDeploymentTargetID is the deployment target to diff to, e.g. production
+	ToDeploymentTargetID string `schema:"to_deployment_target_id" form:"required,uuid"`
+}
+
+// CompareDeploymentTargetsResponse is the response object for the /apps/{porter_app_name}/deployment-targets/compare endpoint
+type CompareDeploymentTargetsResponse struct {
+	// FromRevisionNumber is the number of the current revision in the from deployment target
+	FromRevisionNumber uint64 `json:"from_revision_number"`
+	// ToRevisionNumber is the number of the current revision in the to deployment target
+	ToRevisionNumber uint64 `json:"to_revision_number"`
+	// Diff are the differences going from the current revision of the from deployment target to that of the to deployment target
+	Diff porter_app.RevisionDiff `json:"diff"`
+	// InSync is true if the deployment targets run the same config. Differences in only the image tag are allowed, since targets commonly
+	// deploy environment-specific tags of the same image
+	InSync bool `json:"in_sync"`
+	// ImageTagDiffers is true if the targets deploy different image tags, so that the difference can be highlighted even when InSync is true
+	ImageTagDiffers bool `json:"image_tag_differs"`
+}
+
+// ServeHTTP returns a structured diff of env, services, and images between the current revisions of an app in two deployment targets
+// of the cluster, e.g. to check that production runs the same config as staging
+func (c *CompareDeploymentTargetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-compare-deployment-targets")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &CompareDeploymentTargetsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	fromDeploymentTargetID, err := uuid.Parse(request.FromDeploymentTargetID)
+	if err != nil || fromDeploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, err, "invalid from deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	toDeploymentTargetID, err := uuid.Parse(request.ToDeploymentTargetID)
+	if err != nil || toDeploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, err, "invalid to deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "from-deployment-target-id", Value: fromDeploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "to-deployment-target-id", Value: toDeploymentTargetID.String()},
+	)
+
+	if fromDeploymentTargetID == toDeploymentTargetID {
+		err := telemetry.Error(ctx, span, nil, "deployment targets to compare must be different")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "compare-deployment-targets", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	// the current revision of each target is encoded with its env attached, so that env differences are included in the diff
+	revisions := make([]porter_app.Revision, 0, 2)
+	for _, deploymentTargetID := range []uuid.UUID{fromDeploymentTargetID, toDeploymentTargetID} {
+		currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+			ProjectId:          int64(project.ID),
+			AppId:              int64(app.ID),
+			DeploymentTargetId: deploymentTargetID.String(),
+		}))
+		if err != nil {
+			if isCCPTimeout(err) {
+				err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+				return
+			}
+			err := telemetry.Error(ctx, span, err, "error getting current app revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil || currentAppRevisionResp.Msg.AppRevision == nil {
+			err := telemetry.Error(ctx, span, nil, "app has no current revision in deployment target")
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "missing-revision-deployment-target-id", Value: deploymentTargetID.String()})
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		deploymentTarget, err := deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		if err != nil {
+			if isCCPTimeout(err) {
+				err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+				return
+			}
+			err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		revision, err := encodedRevisionWithEnv(ctx, encodedRevisionWithEnvInput{
+			ProjectID:           project.ID,
+			ClusterID:           cluster.ID,
+			DeploymentTarget:    deploymentTarget,
+			Agent:               agent,
+			PorterAppRepository: c.Repo().PorterApp(),
+		}, currentAppRevisionResp.Msg.AppRevision)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting current revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	fromRevision, toRevision := revisions[0], revisions[1]
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "from-revision-number", Value: int(fromRevision.RevisionNumber)},
+		telemetry.AttributeKV{Key: "to-revision-number", Value: int(toRevision.RevisionNumber)},
+	)
+
+	diff, err := porter_app.DiffRevisions(ctx, fromRevision, toRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error diffing revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	imageTagDiffers := false
+	for _, imageDiff := range diff.Image {
+		if imageDiff.Key == "tag" {
+			imageTagDiffers = true
+		}
+	}
+
+	res := &CompareDeploymentTargetsResponse{
+		FromRevisionNumber: fromRevision.RevisionNumber,
+		ToRevisionNumber:   toRevision.RevisionNumber,
+		Diff:               diff,
+		InSync:             diff.IsEmpty() || diff.OnlyImageTagDiffers(),
+		ImageTagDiffers:    imageTagDiffers,
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "in-sync", Value: res.InSync},
+		telemetry.AttributeKV{Key: "image-tag-differs", Value: res.ImageTagDiffers},
+	)
+
+	c.WriteResult(w, r, res)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/porter_app/notifications"
+	"github.com/porter-dev/porter/internal/porter_app/webhooks"
 	"github.com/porter-dev/porter/internal/telemetry"
 )
 
@@ -47,6 +48,8 @@ func (p *CreateUpdatePorterAppEventHandler) ServeHTTP(w http.ResponseWriter, r *
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-post-porter-app-event")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 	user, _ := ctx.Value(types.UserScope).(*models.User)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
@@ -106,6 +109,17 @@ func (p *CreateUpdatePorterAppEventHandler) ServeHTTP(w http.ResponseWriter, r *
 		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest))
 		return
 	}
+
+	// the CLI cancels a deploy by marking its build event as canceled
+	if event.Type == types.PorterAppEventType_Build && request.Status == types.PorterAppEventStatus_Canceled {
+		recordAuditLogEntry(ctx, p.Config(), auditLogEntryInput{
+			Action:             types.AuditLogAction_Cancel,
+			PorterAppID:        event.PorterAppID,
+			AppName:            appName,
+			DeploymentTargetID: event.DeploymentTargetID,
+		})
+	}
+
 	p.WriteResult(w, r, event)
 }
 
@@ -283,6 +297,8 @@ func (p *CreateUpdatePorterAppEventHandler) createNewAppEvent(ctx context.Contex
 		return types.PorterAppEvent{}, telemetry.Error(ctx, span, nil, "porter app event not found")
 	}
 
+	sendAppEventWebhooks(ctx, p.Config(), "", event)
+
 	return event.ToPorterAppEvent(), nil
 }
 
@@ -309,6 +325,7 @@ func (p *CreateUpdatePorterAppEventHandler) updateExistingAppEvent(ctx context.C
 		return types.PorterAppEvent{}, telemetry.Error(ctx, span, err, "error retrieving porter app event by id")
 	}
 
+	previousStatus := existingAppEvent.Status
 	if submittedEvent.Status != "" {
 		existingAppEvent.Status = string(submittedEvent.Status)
 	}
@@ -324,6 +341,8 @@ func (p *CreateUpdatePorterAppEventHandler) updateExistingAppEvent(ctx context.C
 		return types.PorterAppEvent{}, telemetry.Error(ctx, span, err, "error updating porter app event")
 	}
 
+	sendAppEventWebhooks(ctx, p.Config(), previousStatus, existingAppEvent)
+
 	return existingAppEvent.ToPorterAppEvent(), nil
 }
 
@@ -501,6 +520,7 @@ func (p *CreateUpdatePorterAppEventHandler) updateDeployEvent(ctx context.Contex
 				anyServicesFailed = true
 			}
 		}
+		previousStatus := matchEvent.Status
 		if allServicesDone {
 			matchEvent.Metadata["end_time"] = time.Now().UTC()
 			if anyServicesFailed {
@@ -516,6 +536,9 @@ func (p *CreateUpdatePorterAppEventHandler) updateDeployEvent(ctx context.Contex
 			return matchEvent.ToPorterAppEvent()
 		}
 		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "updating-deployment-event", Value: true})
+
+		sendAppEventWebhooks(ctx, p.Config(), previousStatus, matchEvent)
+
 		return matchEvent.ToPorterAppEvent()
 	}
 
@@ -660,5 +683,20 @@ func (p *CreateUpdatePorterAppEventHandler) handleNotification(ctx context.Conte
 		return telemetry.Error(ctx, span, err, "error creating notification")
 	}
 
+	sendProjectWebhooks(ctx, p.Config(), webhooks.Event{
+		Event:              webhooks.EventType_NotificationCreated,
+		ProjectID:          projectId,
+		ClusterID:          clusterId,
+		AppName:            agentEventMetadata.AppName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		AppRevisionID:      agentEventMetadata.AppRevisionID,
+		Notification: &webhooks.Notification{
+			ServiceName: agentEventMetadata.ServiceName,
+			Summary:     agentEventMetadata.Summary,
+			Detail:      agentEventMetadata.Detail,
+		},
+		Timestamp: time.Now().UTC(),
+	})
+
 	return nil
 }
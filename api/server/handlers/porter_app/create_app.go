@@ -90,6 +90,8 @@ func (c *CreateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-app")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
@@ -123,8 +125,10 @@ func (c *CreateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		GitRepoID:           request.GitRepoID,
 		PorterYamlPath:      request.PorterYamlPath,
 		Image:               request.Image,
+		CreatedByUserID:     requestUserID(ctx),
 		PorterAppRepository: c.Repo().PorterApp(),
 	})
+	c.Config().PorterAppNameCache.Invalidate(project.ID, request.Name)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error creating porter app")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
@@ -1,7 +1,14 @@
 package porter_app
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
@@ -12,6 +19,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/porter-dev/porter/internal/deployment_target"
 	"github.com/porter-dev/porter/internal/porter_app"
 	"github.com/porter-dev/porter/internal/porter_app/notifications"
 	"github.com/porter-dev/porter/internal/telemetry"
@@ -44,73 +52,139 @@ func NewLatestAppRevisionHandler(
 
 // LatestAppRevisionRequest is the request object for the /apps/{porter_app_name}/latest endpoint
 type LatestAppRevisionRequest struct {
-	DeploymentTargetID string `schema:"deployment_target_id"`
+	DeploymentTargetID string `schema:"deployment_target_id" form:"omitempty,uuid"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+	// MinSeverity filters out notifications less severe than the given severity. If empty, all notifications are returned
+	MinSeverity notifications.Severity `schema:"min_severity"`
+	// NotificationLimit is the maximum number of notifications to return, most recent first. Defaults to 50
+	NotificationLimit int `schema:"notification_limit" form:"gte=0,lte=500"`
+	// NotificationOffset is the number of most recent notifications to skip
+	NotificationOffset int `schema:"notification_offset" form:"gte=0"`
+	// IncludeServiceStatus attaches the desired and ready replica counts of each service to the response
+	IncludeServiceStatus bool `schema:"include_service_status"`
+	// ServiceName filters notifications to those for the given service. Application and revision scoped notifications, which are not
+	// associated with a service, are excluded when this is set. If empty, notifications for all services are returned
+	ServiceName string `schema:"service_name"`
+	// IncludeChangeSummary attaches a summary of what changed from the previous revision to the response
+	IncludeChangeSummary bool `schema:"include_change_summary"`
+	// Since is an optional RFC3339 timestamp. If set, only notifications created after this time are returned, e.g. those which arrived since
+	// the client last polled
+	Since string `schema:"since"`
 }
 
+const (
+	// defaultNotificationLimit is the number of notifications returned if no limit is requested
+	defaultNotificationLimit = 50
+	// maxNotificationLimit is the maximum number of notifications that can be requested at once
+	maxNotificationLimit = 500
+	// maxRevisionNotificationEvents is the maximum number of the most recent notification events of a revision read per request. Events are
+	// converted, collapsed and filtered after they are read, so the window is bounded rather than paginated in the database
+	maxRevisionNotificationEvents = 5000
+)
+
 // LatestAppRevisionResponse is the response object for the /apps/{porter_app_name}/latest endpoint
 type LatestAppRevisionResponse struct {
 	// AppRevision is the latest revision for the app
 	AppRevision porter_app.Revision `json:"app_revision"`
 	// Notifications are the notifications associated with the app revision
 	Notifications []notifications.Notification `json:"notifications"`
+	// NotificationsTotalCount is the total number of notifications associated with the app revision since the requested time which match min_severity
+	// and service_name, after repeated notifications are collapsed and before notification_limit and notification_offset are applied. Only the most
+	// recent notification events of the revision are considered, up to maxRevisionNotificationEvents
+	NotificationsTotalCount int64 `json:"notifications_total_count"`
+	// ActiveNotificationsCount is the number of returned notifications which are not resolved
+	ActiveNotificationsCount int `json:"active_notifications_count"`
+	// ServiceStatus maps service name to the desired and ready replica counts for the service. Only set when requested.
+	// Services whose status could not be determined are omitted.
+	ServiceStatus map[string]porter_app.ServiceReplicaStatus `json:"service_status,omitempty"`
+	// ChangeSummary summarizes what changed from the previous revision. Only set when requested, and omitted if the previous revision could not be read
+	ChangeSummary *porter_app.RevisionChangeSummary `json:"change_summary,omitempty"`
 }
 
 // ServeHTTP translates the request into a CurrentAppRevision grpc request, forwards to the cluster control plane, and returns the response.
-// Multi-cluster projects are not supported, as they may have multiple porter-apps with the same name in the same project.
+// Multi-cluster projects may have multiple porter-apps with the same name in the same project, in which case cluster_id must be provided to select one.
+// Unambiguous app name lookups are briefly cached, since this endpoint is polled frequently by the dashboard. For the same reason, an ETag is set on the
+// response and a 304 is returned if it matches If-None-Match.
 func (c *LatestAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-latest-app-revision")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
-	telemetry.WithAttributes(span,
-		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
-		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
-	)
-
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	if reqErr != nil {
 		e := telemetry.Error(ctx, span, reqErr, "error parsing stack name from url")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(e, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
 
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
-
 	request := &LatestAppRevisionRequest{}
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
 		err := telemetry.Error(ctx, span, nil, "error decoding request")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
 
 	_, err := uuid.Parse(request.DeploymentTargetID)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidDeploymentTarget))
 		return
 	}
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID})
 
-	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
-	if err != nil {
-		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+	if request.MinSeverity != "" && !request.MinSeverity.IsValid() {
+		err := telemetry.Error(ctx, span, nil, "invalid min severity")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
-	if len(porterApps) == 0 {
-		err := telemetry.Error(ctx, span, err, "no porter apps returned")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
-		return
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "min-severity", Value: string(request.MinSeverity)},
+		telemetry.AttributeKV{Key: "service-name-filter", Value: request.ServiceName},
+	)
+
+	var since time.Time
+	if request.Since != "" {
+		since, err = time.Parse(time.RFC3339, request.Since)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "since must be an RFC3339 timestamp")
+			c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+			return
+		}
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "since", Value: since.Format(time.RFC3339)})
 	}
-	if len(porterApps) > 1 {
-		err := telemetry.Error(ctx, span, err, "multiple porter apps returned; unable to determine which one to use")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+
+	var porterApps []*models.PorterApp
+	cachedApp, cacheHit := c.Config().PorterAppNameCache.Get(project.ID, appName)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "porter-app-name-cache-hit", Value: cacheHit})
+	if cacheHit {
+		porterApps = []*models.PorterApp{cachedApp}
+	} else {
+		porterApps, err = c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		c.Config().PorterAppNameCache.Set(project.ID, appName, porterApps)
+	}
+	if request.ClusterID != 0 {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "requested-cluster-id", Value: request.ClusterID})
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		code := selectPorterAppErrorCode(err)
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), code))
 		return
 	}
 
-	appId := porterApps[0].ID
+	appId := porterApp.ID
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: appId})
 
 	if appId == 0 {
@@ -125,8 +199,18 @@ func (c *LatestAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		DeploymentTargetId: request.DeploymentTargetID,
 	})
 
-	currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ctx, currentAppRevisionReq)
+	var currentAppRevisionResp *connect.Response[porterv1.CurrentAppRevisionResponse]
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "latest-app-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		currentAppRevisionResp, err = c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, currentAppRevisionReq)
+		return err
+	})
 	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
 		err := telemetry.Error(ctx, span, err, "error getting current app revision from cluster control plane client")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
@@ -146,41 +230,266 @@ func (c *LatestAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	encodedRevision.IsCurrent = true
+
 	appRevisionId := encodedRevision.ID
 	appInstanceId := encodedRevision.AppInstanceID
 	telemetry.WithAttributes(span,
 		telemetry.AttributeKV{Key: "app-revision-id", Value: appRevisionId},
 		telemetry.AttributeKV{Key: "app-instance-id", Value: appInstanceId},
 	)
-	notificationEvents, err := c.Repo().PorterAppEvent().ReadNotificationsByAppRevisionID(ctx, appInstanceId, appRevisionId)
-	if err != nil {
-		err := telemetry.Error(ctx, span, err, "error getting notifications from repo")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+
+	notificationLimit := request.NotificationLimit
+	if notificationLimit == 0 {
+		notificationLimit = defaultNotificationLimit
+	}
+	if notificationLimit < 0 || notificationLimit > maxNotificationLimit || request.NotificationOffset < 0 {
+		err := telemetry.Error(ctx, span, nil, "notification limit must be between 1 and 500 and notification offset must not be negative")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
-	latestNotifications := make([]notifications.Notification, 0)
-	for _, event := range notificationEvents {
-		notification, err := notifications.NotificationFromPorterAppEvent(event)
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "notification-limit", Value: notificationLimit},
+		telemetry.AttributeKV{Key: "notification-offset", Value: request.NotificationOffset},
+	)
+
+	var notificationEvents []*models.PorterAppEvent
+	// notifications are keyed by app instance, so a revision without one (e.g. right after the first deploy of an app) cannot have any
+	if appInstanceId == uuid.Nil {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notifications-skipped", Value: "app instance id is empty"})
+	} else {
+		// the most recent notifications since the requested time are read, since repeated notifications must be collapsed before they are paginated
+		notificationEvents, err = c.Repo().PorterAppEvent().ReadNotificationsByAppRevisionID(ctx, appInstanceId, appRevisionId, since, maxRevisionNotificationEvents)
 		if err != nil {
-			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: err.Error()})
-			continue
+			err := telemetry.Error(ctx, span, err, "error getting notifications from repo")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
 		}
-		if notification == nil {
-			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: "notification is nil"})
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-events-truncated", Value: len(notificationEvents) == maxRevisionNotificationEvents})
+	}
+	matchingNotifications := make([]notifications.Notification, 0)
+	for _, notification := range notifications.NotificationsFromPorterAppEvents(ctx, notificationEvents) {
+		if request.MinSeverity != "" && !notification.Severity.AtLeast(request.MinSeverity) {
 			continue
 		}
-		// TODO: remove this check once this attribute is not found in the span for >30 days
-		if notification.Scope == "" {
-			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: "old-notification-format"})
+		if request.ServiceName != "" && notification.Metadata.ServiceName != request.ServiceName {
 			continue
 		}
-		latestNotifications = append(latestNotifications, *notification)
+		matchingNotifications = append(matchingNotifications, notification)
+	}
+	notificationsTotalCount := int64(len(matchingNotifications))
+
+	latestNotifications := notifications.PaginateNotifications(matchingNotifications, notificationLimit, request.NotificationOffset)
+	activeNotificationsCount := 0
+	for _, notification := range latestNotifications {
+		if !notification.Resolved {
+			activeNotificationsCount++
+		}
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "notification-event-count", Value: len(notificationEvents)},
+		telemetry.AttributeKV{Key: "notifications-total-count", Value: len(matchingNotifications)},
+	)
+
+	var serviceStatus map[string]porter_app.ServiceReplicaStatus
+	if request.IncludeServiceStatus {
+		serviceStatus = c.serviceStatus(ctx, r, cluster, appRevision.App, appName, request.DeploymentTargetID)
+	}
+
+	var changeSummary *porter_app.RevisionChangeSummary
+	if request.IncludeChangeSummary {
+		changeSummary = c.changeSummary(ctx, project, porterApp, request.DeploymentTargetID, encodedRevision)
 	}
 
 	response := LatestAppRevisionResponse{
-		AppRevision:   encodedRevision,
-		Notifications: latestNotifications,
+		AppRevision:              encodedRevision,
+		Notifications:            latestNotifications,
+		NotificationsTotalCount:  notificationsTotalCount,
+		ActiveNotificationsCount: activeNotificationsCount,
+		ServiceStatus:            serviceStatus,
+		ChangeSummary:            changeSummary,
+	}
+
+	etag, err := latestAppRevisionETag(response)
+	if err != nil {
+		// the response can still be served, it just cannot be cached by the client
+		_ = telemetry.Error(ctx, span, err, "error computing etag")
+		c.WriteResult(w, r, response)
+		return
+	}
+	w.Header().Set("ETag", etag)
+
+	notModified := etagMatches(r, etag)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "not-modified", Value: notModified})
+	if notModified {
+		w.WriteHeader(http.StatusNotModified)
+		return
 	}
 
 	c.WriteResult(w, r, response)
 }
+
+// latestAppRevisionETag computes an entity tag for the response. The revision is identified by its id and update time rather than
+// by hashing the encoded app proto, while everything else in the response is hashed so that e.g. a new or acknowledged notification changes the tag.
+func latestAppRevisionETag(response LatestAppRevisionResponse) (string, error) {
+	hash := sha256.New()
+
+	fmt.Fprintf(hash, "%s\n%d\n%s\n%d\n",
+		response.AppRevision.ID,
+		response.AppRevision.UpdatedAt.UnixNano(),
+		response.AppRevision.Status,
+		response.NotificationsTotalCount,
+	)
+
+	// encoding/json sorts map keys, so the encoding is deterministic
+	err := json.NewEncoder(hash).Encode(struct {
+		Notifications []notifications.Notification               `json:"notifications"`
+		ServiceStatus map[string]porter_app.ServiceReplicaStatus `json:"service_status"`
+		ChangeSummary *porter_app.RevisionChangeSummary          `json:"change_summary"`
+	}{
+		Notifications: response.Notifications,
+		ServiceStatus: response.ServiceStatus,
+		ChangeSummary: response.ChangeSummary,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error encoding response for etag: %w", err)
+	}
+
+	return fmt.Sprintf("\"%s\"", hex.EncodeToString(hash.Sum(nil))), nil
+}
+
+// serviceStatus returns the replica status of each long-running service in the app. This is best-effort: if the status of
+// a service cannot be determined it is omitted, and if the deployment target or cluster cannot be reached an empty map is returned.
+func (c *LatestAppRevisionHandler) serviceStatus(ctx context.Context, r *http.Request, cluster *models.Cluster, app *porterv1.PorterApp, appName string, deploymentTargetID string) map[string]porter_app.ServiceReplicaStatus {
+	ctx, span := telemetry.NewSpan(ctx, "latest-app-revision-service-status")
+	defer span.End()
+
+	statuses := make(map[string]porter_app.ServiceReplicaStatus)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: deploymentTargetID,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error getting deployment target details")
+		return statuses
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error getting kubernetes agent")
+		return statuses
+	}
+
+	statuses, errs := serviceReplicaStatuses(ctx, agent, deploymentTarget.Namespace, c.Config().ServerConf.PodLabelPrefix, deploymentTargetID, appName, "", porter_app.LongRunningServiceNames(app))
+	for serviceName, err := range errs {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: telemetry.AttributeKey(fmt.Sprintf("service-status-error-%s", serviceName)), Value: err.Error()})
+	}
+
+	return statuses
+}
+
+// changeSummary compares the latest revision to the revision before it. Env is compared as defined on the app, without attached env groups.
+// This is best-effort: nil is returned if the previous revision cannot be read.
+func (c *LatestAppRevisionHandler) changeSummary(ctx context.Context, project *models.Project, porterApp *models.PorterApp, deploymentTargetID string, latestRevision porter_app.Revision) *porter_app.RevisionChangeSummary {
+	ctx, span := telemetry.NewSpan(ctx, "latest-app-revision-change-summary")
+	defer span.End()
+
+	if latestRevision.RevisionNumber <= 1 {
+		return &porter_app.RevisionChangeSummary{HasPreviousRevision: false}
+	}
+	previousRevisionNumber := latestRevision.RevisionNumber - 1
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "previous-revision-number", Value: int(previousRevisionNumber)})
+
+	appRevisions, err := porter_app.ListAppRevisions(ctx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              porterApp.ID,
+		DeploymentTargetID: deploymentTargetID,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error listing app revisions")
+		return nil
+	}
+
+	previousRevisionProto, err := porter_app.RevisionByNumber(appRevisions, previousRevisionNumber)
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			return &porter_app.RevisionChangeSummary{HasPreviousRevision: false}
+		}
+		_ = telemetry.Error(ctx, span, err, "error getting previous revision")
+		return nil
+	}
+
+	previousRevision, err := porter_app.EncodedRevisionFromProto(ctx, previousRevisionProto)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error encoding previous revision from proto")
+		return nil
+	}
+
+	diff, err := porter_app.DiffRevisions(ctx, previousRevision, latestRevision)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error diffing revisions")
+		return nil
+	}
+
+	summary := diff.Summary(previousRevisionNumber)
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "images-changed", Value: summary.ImagesChanged},
+		telemetry.AttributeKV{Key: "env-changed", Value: summary.EnvChanged},
+		telemetry.AttributeKV{Key: "services-changed", Value: summary.ServicesChanged},
+	)
+
+	return &summary
+}
+
+var (
+	// errPorterAppNotFound is returned by selectPorterAppByCluster when no app matches
+	errPorterAppNotFound = errors.New("no porter app with name found")
+	// errPorterAppAmbiguous is returned by selectPorterAppByCluster when multiple apps match the name and cluster
+	errPorterAppAmbiguous = errors.New("multiple porter apps returned")
+)
+
+// selectPorterAppByCluster selects a single app from apps in the same project sharing a name.
+// If clusterID is set, the app in that cluster is returned; otherwise an error is returned if the name is ambiguous.
+func selectPorterAppByCluster(porterApps []*models.PorterApp, clusterID uint) (*models.PorterApp, error) {
+	if len(porterApps) == 0 {
+		return nil, fmt.Errorf("no porter apps returned: %w", errPorterAppNotFound)
+	}
+
+	if clusterID == 0 {
+		if len(porterApps) > 1 {
+			return nil, fmt.Errorf("%w; cluster_id must be provided to determine which one to use", errPorterAppAmbiguous)
+		}
+		return porterApps[0], nil
+	}
+
+	var match *models.PorterApp
+	for _, app := range porterApps {
+		if app != nil && app.ClusterID == clusterID {
+			// names are matched case-insensitively, so apps in the same cluster whose names differ only by case are ambiguous
+			if match != nil {
+				return nil, fmt.Errorf("%w; the requested cluster has apps whose names differ only by case, so the exact name must be used", errPorterAppAmbiguous)
+			}
+			match = app
+		}
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("requested cluster has no app with name: %w", errPorterAppNotFound)
+	}
+
+	return match, nil
+}
+
+// selectPorterAppErrorCode returns the error code for an error returned by selectPorterAppByCluster
+func selectPorterAppErrorCode(err error) types.APIErrorCode {
+	if errors.Is(err, errPorterAppAmbiguous) {
+		return types.APIErrorCode_AppAmbiguous
+	}
+	return types.APIErrorCode_AppNotFound
+}
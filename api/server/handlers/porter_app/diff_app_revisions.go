@@ -0,0 +1,253 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DiffAppRevisionsHandler handles requests to the /apps/{porter_app_name}/revisions/diff endpoint
+type DiffAppRevisionsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewDiffAppRevisionsHandler returns a new DiffAppRevisionsHandler
+func NewDiffAppRevisionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffAppRevisionsHandler {
+	return &DiffAppRevisionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// DiffAppRevisionsRequest is the request object for the /apps/{porter_app_name}/revisions/diff endpoint
+type DiffAppRevisionsRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// FromRevisionNumber is the revision to diff from. Defaults to the revision before ToRevisionNumber
+	FromRevisionNumber uint64 `schema:"from_revision_number"`
+	// ToRevisionNumber is the revision to diff to. Defaults to the latest revision
+	ToRevisionNumber uint64 `schema:"to_revision_number"`
+}
+
+// DiffAppRevisionsResponse is the response object for the /apps/{porter_app_name}/revisions/diff endpoint
+type DiffAppRevisionsResponse struct {
+	FromRevisionNumber uint64                  `json:"from_revision_number"`
+	ToRevisionNumber   uint64                  `json:"to_revision_number"`
+	Diff               porter_app.RevisionDiff `json:"diff"`
+}
+
+// ServeHTTP returns a structured diff of env, services, and images between two revisions of an app
+func (c *DiffAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-diff-app-revisions")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &DiffAppRevisionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "diff-app-revisions", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              app.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	toRevisionNumber := request.ToRevisionNumber
+	if toRevisionNumber == 0 {
+		toRevisionNumber = porter_app.LatestRevisionNumber(appRevisions)
+	}
+	fromRevisionNumber := request.FromRevisionNumber
+	if fromRevisionNumber == 0 && toRevisionNumber > 1 {
+		fromRevisionNumber = toRevisionNumber - 1
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "from-revision-number", Value: int64(fromRevisionNumber)},
+		telemetry.AttributeKV{Key: "to-revision-number", Value: int64(toRevisionNumber)},
+	)
+
+	if fromRevisionNumber == 0 {
+		err := telemetry.Error(ctx, span, nil, "app does not have a previous revision to diff against")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	fromRevisionProto, err := porter_app.RevisionByNumber(appRevisions, fromRevisionNumber)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "from revision not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	toRevisionProto, err := porter_app.RevisionByNumber(appRevisions, toRevisionNumber)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "to revision not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	var deploymentTarget deployment_target.DeploymentTarget
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "diff-app-revisions", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	revisionInput := encodedRevisionWithEnvInput{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		DeploymentTarget:    deploymentTarget,
+		Agent:               agent,
+		PorterAppRepository: c.Repo().PorterApp(),
+	}
+
+	fromRevision, err := encodedRevisionWithEnv(ctx, revisionInput, fromRevisionProto)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting from revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	toRevision, err := encodedRevisionWithEnv(ctx, revisionInput, toRevisionProto)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting to revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	diff, err := porter_app.DiffRevisions(ctx, fromRevision, toRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error diffing revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, &DiffAppRevisionsResponse{
+		FromRevisionNumber: fromRevisionNumber,
+		ToRevisionNumber:   toRevisionNumber,
+		Diff:               diff,
+	})
+}
+
+type encodedRevisionWithEnvInput struct {
+	ProjectID           uint
+	ClusterID           uint
+	DeploymentTarget    deployment_target.DeploymentTarget
+	Agent               *kubernetes.Agent
+	PorterAppRepository repository.PorterAppRepository
+}
+
+// encodedRevisionWithEnv encodes a revision proto and attaches the app env so that env changes can be included in a diff
+func encodedRevisionWithEnv(ctx context.Context, inp encodedRevisionWithEnvInput, revisionProto *porterv1.AppRevision) (porter_app.Revision, error) {
+	if revisionProto == nil {
+		return porter_app.Revision{}, errors.New("revision is nil")
+	}
+
+	encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, revisionProto)
+	if err != nil {
+		return encodedRevision, err
+	}
+
+	return porter_app.AttachEnvToRevision(ctx, porter_app.AttachEnvToRevisionInput{
+		ProjectID:           inp.ProjectID,
+		ClusterID:           int(inp.ClusterID),
+		Revision:            encodedRevision,
+		DeploymentTarget:    inp.DeploymentTarget,
+		K8SAgent:            inp.Agent,
+		PorterAppRepository: inp.PorterAppRepository,
+	})
+}
@@ -0,0 +1,24 @@
+package porter_app
+
+import (
+	"net/http"
+	"strings"
+)
+
+// etagMatches returns true if the request's If-None-Match header matches the given entity tag, in which case a 304 should be returned.
+// Weak comparison is used, as is required for If-None-Match.
+func etagMatches(r *http.Request, etag string) bool {
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+
+	return false
+}
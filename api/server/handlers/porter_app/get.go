@@ -35,6 +35,8 @@ func (c *GetPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	ctx, span := telemetry.NewSpan(ctx, "serve-get-porter-app")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 
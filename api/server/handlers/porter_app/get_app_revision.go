@@ -46,6 +46,8 @@ func (c *GetAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-app-revision")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
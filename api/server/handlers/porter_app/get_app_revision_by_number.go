@@ -0,0 +1,146 @@
+package porter_app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetAppRevisionByNumberHandler handles requests to the /apps/{porter_app_name}/revisions/number/{app_revision_number} endpoint
+type GetAppRevisionByNumberHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewGetAppRevisionByNumberHandler returns a new GetAppRevisionByNumberHandler
+func NewGetAppRevisionByNumberHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetAppRevisionByNumberHandler {
+	return &GetAppRevisionByNumberHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// GetAppRevisionByNumberRequest is the request object for the /apps/{porter_app_name}/revisions/number/{app_revision_number} endpoint
+type GetAppRevisionByNumberRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+}
+
+// GetAppRevisionByNumberResponse is the response object for the /apps/{porter_app_name}/revisions/number/{app_revision_number} endpoint
+type GetAppRevisionByNumberResponse struct {
+	AppRevision porter_app.Revision `json:"app_revision"`
+}
+
+// ServeHTTP returns the revision of an app in a deployment target with the requested revision number.
+// The path is distinct from /revisions/{app_revision_id} so that revision ids and numbers cannot be confused.
+func (c *GetAppRevisionByNumberHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-app-revision-by-number")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if revisionNumber == 0 {
+		err := telemetry.Error(ctx, span, nil, "app revision number must be a positive integer")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &GetAppRevisionByNumberRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	appRevisions, err := porter_app.ListAppRevisions(ctx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              porterApp.ID,
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			err := telemetry.Error(ctx, span, err, "app revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting app revision by number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, appRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error encoding revision from proto")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, &GetAppRevisionByNumberResponse{
+		AppRevision: encodedRevision,
+	})
+}
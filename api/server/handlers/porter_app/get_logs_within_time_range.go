@@ -36,6 +36,8 @@ func NewGetLogsWithinTimeRangeHandler(
 func (c *GetLogsWithinTimeRangeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-logs-within-time-range")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	r = r.Clone(ctx)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
@@ -37,13 +37,14 @@ func (c *PorterAppHelmReleaseGetHandler) ServeHTTP(w http.ResponseWriter, r *htt
 	ctx, span := telemetry.NewSpan(ctx, "serve-get-porter-app-helm-release")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	if reqErr != nil {
 		err := telemetry.Error(ctx, span, reqErr, "error getting stack name from url")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
 	version, reqErr := requestutils.GetURLParamUint(r, types.URLParamReleaseVersion)
 	if reqErr != nil {
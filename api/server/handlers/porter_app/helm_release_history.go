@@ -37,6 +37,8 @@ func (c *PorterAppHelmReleaseHistoryGetHandler) ServeHTTP(w http.ResponseWriter,
 	ctx, span := telemetry.NewSpan(ctx, "serve-get-porter-app-helm-release-history")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	if reqErr != nil {
 		err := telemetry.Error(ctx, span, reqErr, "error getting stack name from url")
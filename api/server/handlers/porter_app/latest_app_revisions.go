@@ -1,7 +1,11 @@
 package porter_app
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"sort"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
@@ -10,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/porter_app"
@@ -32,26 +37,76 @@ func NewLatestAppRevisionsHandler(
 	}
 }
 
+const (
+	// latestAppRevisionsSortBy_UpdatedAt sorts revisions by the time they were last updated
+	latestAppRevisionsSortBy_UpdatedAt = "updated_at"
+	// latestAppRevisionsSortBy_Name sorts revisions by app name
+	latestAppRevisionsSortBy_Name = "name"
+	// latestAppRevisionsSortOrder_Asc sorts revisions in ascending order
+	latestAppRevisionsSortOrder_Asc = "asc"
+	// latestAppRevisionsSortOrder_Desc sorts revisions in descending order
+	latestAppRevisionsSortOrder_Desc = "desc"
+
+	// defaultLatestAppRevisionsPageSize is the number of revisions returned per page when page_size is not set
+	defaultLatestAppRevisionsPageSize = 20
+	// maxLatestAppRevisionsPageSize is the maximum number of revisions that can be requested per page
+	maxLatestAppRevisionsPageSize = 100
+)
+
 // LatestAppRevisionsRequest represents the request for the /apps/revisions endpoint
 type LatestAppRevisionsRequest struct {
-	DeploymentTargetID string `schema:"deployment_target_id"`
+	DeploymentTargetID string `schema:"deployment_target_id" form:"omitempty,uuid"`
+	// Page is the 1-indexed page of revisions to return. Defaults to the first page
+	Page int `schema:"page" form:"gte=0"`
+	// PageSize is the number of revisions to return per page. Defaults to 20, and may not exceed 100
+	PageSize int `schema:"page_size" form:"gte=0,lte=100"`
+	// SortBy is the field to sort revisions by, either "updated_at" or "name". Defaults to "updated_at"
+	SortBy string `schema:"sort_by" form:"omitempty,oneof=updated_at name"`
+	// SortOrder is the direction to sort revisions in, either "asc" or "desc". Defaults to "desc"
+	SortOrder string `schema:"sort_order" form:"omitempty,oneof=asc desc"`
+	// Status is an optional comma-separated list of revision statuses, e.g. "DEPLOY_FAILED,DEPLOYING". Only revisions in one of
+	// these statuses are returned, and pagination applies to the filtered revisions. If empty, revisions in all statuses are returned
+	Status string `schema:"status"`
+	// NamePrefix is an optional case-insensitive prefix of app names. Only revisions of apps whose name starts with the prefix are returned,
+	// and pagination applies to the filtered revisions
+	NamePrefix string `schema:"name_prefix"`
+	// Labels is an optional comma-separated list of key=value pairs, e.g. "team=payments,tier=backend". Only revisions of apps which
+	// have all of the given labels are returned, and pagination applies to the filtered revisions. If empty, revisions of all apps are returned
+	Labels string `schema:"labels"`
+}
+
+// LatestAppRevisionsPagination contains pagination details for the /apps/revisions endpoint
+type LatestAppRevisionsPagination struct {
+	// TotalCount is the total number of revisions across all pages
+	TotalCount int `json:"total_count"`
+	// CurrentPage is the page returned in the response
+	CurrentPage int `json:"current_page"`
+	// PageSize is the number of revisions per page
+	PageSize int `json:"page_size"`
+	// HasNextPage is true if there are more revisions after the current page
+	HasNextPage bool `json:"has_next_page"`
 }
 
 // LatestRevisionWithSource is an app revision and its source porter app
 type LatestRevisionWithSource struct {
 	AppRevision porter_app.Revision `json:"app_revision"`
-	Source      types.PorterApp     `json:"source"`
+	// Source is the porter app of the revision, including when it was created and by whom. The owner is omitted for apps created
+	// before the creator was recorded, and is a tombstone if the creator has since been deleted
+	Source types.PorterApp `json:"source"`
 }
 
 // LatestAppRevisionsResponse represents the response from the /apps/revisions endpoint
 type LatestAppRevisionsResponse struct {
-	AppRevisions []LatestRevisionWithSource `json:"app_revisions"`
+	AppRevisions []LatestRevisionWithSource   `json:"app_revisions"`
+	Pagination   LatestAppRevisionsPagination `json:"pagination"`
 }
 
 func (c *LatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-app-revisions")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 
@@ -62,6 +117,54 @@ func (c *LatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	page := request.Page
+	if page == 0 {
+		page = 1
+	}
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = defaultLatestAppRevisionsPageSize
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "page", Value: page},
+		telemetry.AttributeKV{Key: "page-size", Value: pageSize},
+	)
+
+	if page < 0 {
+		err := telemetry.Error(ctx, span, nil, "page must be a positive integer")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if pageSize < 0 || pageSize > maxLatestAppRevisionsPageSize {
+		err := telemetry.Error(ctx, span, nil, "page_size must be between 1 and 100")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	sortBy := request.SortBy
+	if sortBy == "" {
+		sortBy = latestAppRevisionsSortBy_UpdatedAt
+	}
+	sortOrder := request.SortOrder
+	if sortOrder == "" {
+		sortOrder = latestAppRevisionsSortOrder_Desc
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "sort-by", Value: sortBy},
+		telemetry.AttributeKV{Key: "sort-order", Value: sortOrder},
+	)
+
+	if sortBy != latestAppRevisionsSortBy_UpdatedAt && sortBy != latestAppRevisionsSortBy_Name {
+		err := telemetry.Error(ctx, span, nil, "sort_by must be one of updated_at or name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if sortOrder != latestAppRevisionsSortOrder_Asc && sortOrder != latestAppRevisionsSortOrder_Desc {
+		err := telemetry.Error(ctx, span, nil, "sort_order must be one of asc or desc")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
 	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
@@ -74,13 +177,41 @@ func (c *LatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	statusFilter, err := parseRevisionStatusFilter(request.Status)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid status filter")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	labelFilter, err := parseLabelFilter(request.Labels)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid labels filter")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "status-filter", Value: request.Status},
+		telemetry.AttributeKV{Key: "name-prefix", Value: request.NamePrefix},
+		telemetry.AttributeKV{Key: "labels-filter", Value: request.Labels},
+	)
+
 	listAppRevisionsReq := connect.NewRequest(&porterv1.LatestAppRevisionsRequest{
 		ProjectId:          int64(project.ID),
 		DeploymentTargetId: deploymentTargetID.String(),
 	})
 
-	latestAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.LatestAppRevisions(ctx, listAppRevisionsReq)
+	var latestAppRevisionsResp *connect.Response[porterv1.LatestAppRevisionsResponse]
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "latest-app-revisions", c.Config().ServerConf.CCPBulkRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		latestAppRevisionsResp, err = c.Config().ClusterControlPlaneClient.LatestAppRevisions(ccpCtx, listAppRevisionsReq)
+		return err
+	})
 	if err != nil {
+		if isCCPTimeout(err) {
+			err = telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
 		err = telemetry.Error(ctx, span, err, "error getting latest app revisions")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
@@ -97,11 +228,72 @@ func (c *LatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		appRevisions = []*porterv1.AppRevision{}
 	}
 
+	if len(statusFilter) > 0 {
+		filtered := make([]*porterv1.AppRevision, 0, len(appRevisions))
+		for _, revision := range appRevisions {
+			if _, ok := statusFilter[porter_app.RevisionStatusFromProto(revision)]; ok {
+				filtered = append(filtered, revision)
+			}
+		}
+		appRevisions = filtered
+	}
+
+	if request.NamePrefix != "" {
+		namePrefix := strings.ToLower(request.NamePrefix)
+		filtered := make([]*porterv1.AppRevision, 0, len(appRevisions))
+		for _, revision := range appRevisions {
+			if strings.HasPrefix(strings.ToLower(revision.GetApp().GetName()), namePrefix) {
+				filtered = append(filtered, revision)
+			}
+		}
+		appRevisions = filtered
+	}
+
+	if len(labelFilter) > 0 {
+		porterApps, err := c.Repo().PorterApp().ListPorterAppByClusterID(cluster.ID)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error listing porter apps")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		labelsByAppName := make(map[string]map[string]string, len(porterApps))
+		for _, porterApp := range porterApps {
+			labelsByAppName[porterApp.Name] = porterApp.LabelsMap()
+		}
+
+		filtered := make([]*porterv1.AppRevision, 0, len(appRevisions))
+		for _, revision := range appRevisions {
+			if labelsMatch(labelsByAppName[revision.GetApp().GetName()], labelFilter) {
+				filtered = append(filtered, revision)
+			}
+		}
+		appRevisions = filtered
+	}
+
+	sortLatestAppRevisions(appRevisions, sortBy, sortOrder)
+
+	totalCount := len(appRevisions)
+	start := (page - 1) * pageSize
+	if start > totalCount {
+		start = totalCount
+	}
+	end := start + pageSize
+	if end > totalCount {
+		end = totalCount
+	}
+
 	res := &LatestAppRevisionsResponse{
 		AppRevisions: make([]LatestRevisionWithSource, 0),
+		Pagination: LatestAppRevisionsPagination{
+			TotalCount:  totalCount,
+			CurrentPage: page,
+			PageSize:    pageSize,
+			HasNextPage: end < totalCount,
+		},
 	}
 
-	for _, revision := range appRevisions {
+	for _, revision := range appRevisions[start:end] {
 		encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, revision)
 		if err != nil {
 			err := telemetry.Error(ctx, span, err, "error getting encoded revision from proto")
@@ -121,11 +313,106 @@ func (c *LatestAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 			return
 		}
 
+		// the latest revisions are those currently deployed to the deployment target
+		encodedRevision.IsCurrent = true
+
 		res.AppRevisions = append(res.AppRevisions, LatestRevisionWithSource{
 			AppRevision: encodedRevision,
 			Source:      *porterApp.ToPorterAppType(),
 		})
 	}
 
+	sources := make([]*types.PorterApp, 0, len(res.AppRevisions))
+	for i := range res.AppRevisions {
+		sources = append(sources, &res.AppRevisions[i].Source)
+	}
+	owners, err := porterAppOwners(c.Repo().User(), sources)
+	if err != nil {
+		// owners are informational, so the revisions are still returned without them
+		_ = telemetry.Error(ctx, span, err, "error reading porter app owners")
+	}
+	for _, source := range sources {
+		source.Owner = owners[source.CreatedByUserID]
+	}
+
+	// pagination is also reported in headers for clients which read the total count and next page from the response headers
+	requestutils.SetPaginationHeaders(w, r, "page", requestutils.PaginationHeaders{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+	})
+
 	c.WriteResult(w, r, res)
 }
+
+// sortLatestAppRevisions sorts revisions in place. Ties are broken on app name in ascending order so that pages are stable across requests
+func sortLatestAppRevisions(appRevisions []*porterv1.AppRevision, sortBy string, sortOrder string) {
+	sort.SliceStable(appRevisions, func(i, j int) bool {
+		nameI := appRevisions[i].GetApp().GetName()
+		nameJ := appRevisions[j].GetApp().GetName()
+
+		if sortBy == latestAppRevisionsSortBy_UpdatedAt {
+			updatedAtI := appRevisions[i].GetUpdatedAt().AsTime()
+			updatedAtJ := appRevisions[j].GetUpdatedAt().AsTime()
+			if !updatedAtI.Equal(updatedAtJ) {
+				if sortOrder == latestAppRevisionsSortOrder_Asc {
+					return updatedAtI.Before(updatedAtJ)
+				}
+				return updatedAtI.After(updatedAtJ)
+			}
+			return nameI < nameJ
+		}
+
+		if sortOrder == latestAppRevisionsSortOrder_Desc {
+			return nameI > nameJ
+		}
+		return nameI < nameJ
+	})
+}
+
+// parseRevisionStatusFilter parses a comma-separated list of revision statuses into a set, returning an error if any status is unknown
+func parseRevisionStatusFilter(statuses string) (map[models.AppRevisionStatus]struct{}, error) {
+	filter := make(map[models.AppRevisionStatus]struct{})
+	if statuses == "" {
+		return filter, nil
+	}
+
+	for _, s := range strings.Split(statuses, ",") {
+		status, err := porter_app.ParseAppRevisionStatus(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("unknown revision status %q", s)
+		}
+		filter[status] = struct{}{}
+	}
+
+	return filter, nil
+}
+
+// parseLabelFilter parses a comma-separated list of key=value pairs into a map, returning an error if any pair is malformed
+func parseLabelFilter(labels string) (map[string]string, error) {
+	filter := make(map[string]string)
+	if labels == "" {
+		return filter, nil
+	}
+
+	for _, pair := range strings.Split(labels, ",") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("label %q must be of the form key=value", pair)
+		}
+		filter[key] = strings.TrimSpace(value)
+	}
+
+	return filter, nil
+}
+
+// labelsMatch returns true if labels contains every key-value pair in filter
+func labelsMatch(labels map[string]string, filter map[string]string) bool {
+	for key, value := range filter {
+		if v, ok := labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
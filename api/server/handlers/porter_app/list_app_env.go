@@ -0,0 +1,210 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListAppEnvHandler handles requests to the /apps/{porter_app_name}/env endpoint
+type ListAppEnvHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewListAppEnvHandler returns a new ListAppEnvHandler
+func NewListAppEnvHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListAppEnvHandler {
+	return &ListAppEnvHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ListAppEnvRequest is the request object for the /apps/{porter_app_name}/env endpoint
+type ListAppEnvRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+}
+
+// AppEnvGroupVariables are the variables of an env group synced with an app
+type AppEnvGroupVariables struct {
+	// Name is the name of the env group
+	Name string `json:"name"`
+	// Version is the version of the env group used by the revision
+	Version int `json:"version"`
+	// Variables are the variables of the env group, sorted by key
+	Variables []porter_app.EnvVariable `json:"variables"`
+}
+
+// ListAppEnvResponse is the response object for the /apps/{porter_app_name}/env endpoint.
+// Services do not define their own env in the app config, so every service of the app receives both the app env and the env groups
+type ListAppEnvResponse struct {
+	// RevisionNumber is the number of the latest revision, which the env was read from
+	RevisionNumber uint64 `json:"revision_number"`
+	// AppEnv are the variables set on the app itself, sorted by key
+	AppEnv []porter_app.EnvVariable `json:"app_env"`
+	// EnvGroups are the shared env groups synced with the app
+	EnvGroups []AppEnvGroupVariables `json:"env_groups"`
+}
+
+// ServeHTTP returns the env variables of the latest revision of an app, with the values of secrets masked, e.g. for an env editor
+func (c *ListAppEnvHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-app-env")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &ListAppEnvRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "list-app-env", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	appRevisions, err := porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              porterApp.ID,
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	latestRevision, err := porter_app.RevisionByNumber(appRevisions, porter_app.LatestRevisionNumber(appRevisions))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "app has no revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "latest-revision-number", Value: int(latestRevision.RevisionNumber)})
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// secrets are read so that their keys can be listed, but only masked values are returned. Values must never be added to the span
+	revisionWithEnv, err := encodedRevisionWithEnv(ctx, encodedRevisionWithEnvInput{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		DeploymentTarget:    deploymentTarget,
+		Agent:               agent,
+		PorterAppRepository: c.Repo().PorterApp(),
+	}, latestRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting app env")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	envGroups, err := porter_app.AppEnvironmentFromProto(ctx, porter_app.AppEnvironmentFromProtoInput{
+		ProjectID:        project.ID,
+		ClusterID:        int(cluster.ID),
+		DeploymentTarget: deploymentTarget,
+		App:              latestRevision.App,
+		K8SAgent:         agent,
+	}, porter_app.WithSecrets(), porter_app.WithoutDefaultAppEnvGroups())
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting env groups")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := &ListAppEnvResponse{
+		RevisionNumber: latestRevision.RevisionNumber,
+		AppEnv:         porter_app.MaskedEnvVariables(revisionWithEnv.Env),
+		EnvGroups:      make([]AppEnvGroupVariables, 0, len(envGroups)),
+	}
+	for _, envGroup := range envGroups {
+		res.EnvGroups = append(res.EnvGroups, AppEnvGroupVariables{
+			Name:      envGroup.Name,
+			Version:   envGroup.Version,
+			Variables: porter_app.MaskedEnvVariables(envGroup),
+		})
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-env-count", Value: len(res.AppEnv)},
+		telemetry.AttributeKV{Key: "env-group-count", Value: len(res.EnvGroups)},
+	)
+
+	c.WriteResult(w, r, res)
+}
@@ -1,7 +1,9 @@
 package porter_app
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"connectrpc.com/connect"
 	"github.com/google/uuid"
@@ -39,7 +41,11 @@ func NewListAppRevisionsHandler(
 // ListAppRevisionsRequest represents the response from the /apps/{porter_app_name}/revisions endpoint
 type ListAppRevisionsRequest struct {
 	// The deployment target ID for the revisions
-	DeploymentTargetID string `schema:"deployment_target_id"`
+	DeploymentTargetID string `schema:"deployment_target_id" form:"required,uuid"`
+	// DeployedAfter is an RFC3339 timestamp. If set, only revisions created at or after this time are returned
+	DeployedAfter string `schema:"deployed_after"`
+	// DeployedBefore is an RFC3339 timestamp. If set, only revisions created at or before this time are returned
+	DeployedBefore string `schema:"deployed_before"`
 }
 
 // ListAppRevisionsResponse represents the response from the /apps/{porter_app_name}/revisions endpoint
@@ -51,6 +57,8 @@ func (c *ListAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-app-revisions")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
@@ -94,14 +102,44 @@ func (c *ListAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	}
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
 
+	deployedAfter, err := parseTimeRangeParam("deployed_after", request.DeployedAfter)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid deployed_after")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	deployedBefore, err := parseTimeRangeParam("deployed_before", request.DeployedBefore)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid deployed_before")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if !deployedAfter.IsZero() && !deployedBefore.IsZero() && deployedAfter.After(deployedBefore) {
+		err = telemetry.Error(ctx, span, nil, "deployed_after must not be later than deployed_before")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployed-after", Value: request.DeployedAfter},
+		telemetry.AttributeKV{Key: "deployed-before", Value: request.DeployedBefore},
+	)
+
 	listAppRevisionsReq := connect.NewRequest(&porterv1.ListAppRevisionsRequest{
 		ProjectId:          int64(project.ID),
 		AppId:              int64(app.ID),
 		DeploymentTargetId: request.DeploymentTargetID,
 	})
 
-	listAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.ListAppRevisions(r.Context(), listAppRevisionsReq)
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "list-app-revisions", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	listAppRevisionsResp, err := c.Config().ClusterControlPlaneClient.ListAppRevisions(ccpCtx, listAppRevisionsReq)
 	if err != nil {
+		if isCCPTimeout(err) {
+			err = telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
 		err = telemetry.Error(ctx, span, err, "error listing app revisions")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
@@ -117,6 +155,29 @@ func (c *ListAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	if appRevisions == nil {
 		appRevisions = []*porterv1.AppRevision{}
 	}
+	appRevisions = porter_app.RevisionsCreatedBetween(appRevisions, deployedAfter, deployedBefore)
+
+	// the current revision is compared by id rather than assumed to be the latest, since they differ while a newer revision is rolling out
+	currentAppRevisionResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(project.ID),
+		AppId:              int64(app.ID),
+		DeploymentTargetId: request.DeploymentTargetID,
+	}))
+	if err != nil {
+		if isCCPTimeout(err) {
+			err = telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "error getting current app revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	var currentAppRevisionID string
+	if currentAppRevisionResp != nil && currentAppRevisionResp.Msg != nil {
+		currentAppRevisionID = currentAppRevisionResp.Msg.GetAppRevision().GetId()
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "current-app-revision-id", Value: currentAppRevisionID})
 
 	res := &ListAppRevisionsResponse{
 		AppRevisions: make([]porter_app.Revision, 0),
@@ -130,8 +191,25 @@ func (c *ListAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 			return
 		}
 
+		encodedRevision.IsCurrent = currentAppRevisionID != "" && encodedRevision.ID == currentAppRevisionID
+
 		res.AppRevisions = append(res.AppRevisions, encodedRevision)
 	}
 
 	c.WriteResult(w, r, res)
 }
+
+// parseTimeRangeParam parses an optional RFC3339 query parameter, returning the zero time if it is empty.
+// The error names the parameter so that clients can tell which bound was malformed.
+func parseTimeRangeParam(name string, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be an RFC3339 timestamp, got %q", name, value)
+	}
+
+	return t, nil
+}
@@ -41,6 +41,8 @@ func (p *PorterAppEventListHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-porter-app-events")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 	user, _ := ctx.Value(types.UserScope).(*models.User)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
@@ -131,6 +133,8 @@ func (p *PorterAppEventListHandler) updateExistingAppEvent(
 		telemetry.AttributeKV{Key: "porter-app-event-status", Value: event.Status},
 	)
 
+	previousStatus := event.Status
+
 	// TODO: get rid of this block and related methods if still here after 08-04-2023
 	if appEvent.Type == string(types.PorterAppEventType_Build) && appEvent.TypeExternalSource == "GITHUB" {
 		validateApplyV2 := project.GetFeatureFlag(models.ValidateApplyV2, p.Config().LaunchDarklyClient)
@@ -151,6 +155,8 @@ func (p *PorterAppEventListHandler) updateExistingAppEvent(
 		return models.PorterAppEvent{}, telemetry.Error(ctx, span, nil, "porter app event not found")
 	}
 
+	sendAppEventWebhooks(ctx, p.Config(), previousStatus, event)
+
 	return event, nil
 }
 
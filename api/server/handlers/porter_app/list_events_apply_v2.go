@@ -43,6 +43,8 @@ func (p *PorterAppV2EventListHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-porter-app-v2-events")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
@@ -62,6 +62,8 @@ const (
 func (c *AppLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-app-logs")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	r = r.Clone(ctx)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
@@ -79,7 +81,6 @@ func (c *AppLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
 	if request.AppID == 0 {
 		err := telemetry.Error(ctx, span, nil, "must provide app id")
@@ -0,0 +1,80 @@
+package porter_app
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/openapi"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+// clusterScopedAppsPath is the path prefix of cluster-scoped porter app routes
+var clusterScopedAppsPath = fmt.Sprintf("/api/projects/{%s}/clusters/{%s}/apps", types.URLParamProjectID, types.URLParamClusterID)
+
+// openAPIOperations are the porter app endpoints described in the generated OpenAPI document
+var openAPIOperations = []openapi.Operation{
+	{
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf("%s/{%s}/latest", clusterScopedAppsPath, types.URLParamPorterAppName),
+		OperationID: "getLatestAppRevision",
+		Summary:     "Get the latest revision of an app in a deployment target, with its notifications",
+		Tags:        []string{"apps"},
+		Request:     LatestAppRevisionRequest{},
+		Response:    LatestAppRevisionResponse{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf("%s/revisions", clusterScopedAppsPath),
+		OperationID: "listLatestAppRevisions",
+		Summary:     "List the latest revision of each app in a deployment target",
+		Tags:        []string{"apps"},
+		Request:     LatestAppRevisionsRequest{},
+		Response:    LatestAppRevisionsResponse{},
+	},
+	{
+		Method:      http.MethodGet,
+		Path:        fmt.Sprintf("%s/{%s}/pods", clusterScopedAppsPath, types.URLParamPorterAppName),
+		OperationID: "getAppPodStatus",
+		Summary:     "Get the status of an app's pods in a deployment target",
+		Tags:        []string{"apps"},
+		Request:     PodStatusRequest{},
+		Response:    []porter_app.PodStatus{},
+	},
+}
+
+// OpenAPIHandler serves the OpenAPI document for the porter app endpoints
+type OpenAPIHandler struct {
+	handlers.PorterHandlerWriter
+
+	document *openapi.Document
+	err      error
+}
+
+// NewOpenAPIHandler returns a new OpenAPIHandler. The document is generated once, since it only depends on the handler types
+func NewOpenAPIHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *OpenAPIHandler {
+	document, err := openapi.Generate("Porter API", "v2", openAPIOperations)
+
+	return &OpenAPIHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+		document:            document,
+		err:                 err,
+	}
+}
+
+// ServeHTTP writes the OpenAPI document, which is derived from the request and response structs of the porter app handlers
+func (c *OpenAPIHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if c.err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error generating openapi document: %w", c.err)))
+		return
+	}
+
+	c.WriteResult(w, r, c.document)
+}
@@ -0,0 +1,238 @@
+package porter_app
+
+import (
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// PatchAppHandler handles PATCH requests to the /apps/{porter_app_name} endpoint
+type PatchAppHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewPatchAppHandler returns a new PatchAppHandler
+func NewPatchAppHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PatchAppHandler {
+	return &PatchAppHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// PatchAppRequest is the request object for PATCH /apps/{porter_app_name}
+type PatchAppRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `json:"cluster_id"`
+	// Operations are JSON Patch operations on the latest revision's app config, as returned in b64_app_proto, with the app's env as /env,
+	// e.g. {"op": "replace", "path": "/env/LOG_LEVEL", "value": "debug"}
+	Operations []porter_app.AppConfigPatchOperation `json:"operations"`
+}
+
+// ServeHTTP applies JSON Patch operations to the config and env of the latest revision of an app, and submits the result as a new revision.
+// Operations on immutable fields such as the app name are rejected with a 422.
+func (c *PatchAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-patch-app")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
+		err := telemetry.Error(ctx, span, nil, "project does not have validate apply v2 enabled")
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &PatchAppRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "operation-count", Value: len(request.Operations)},
+	)
+
+	if len(request.Operations) == 0 {
+		err := telemetry.Error(ctx, span, nil, "must provide at least one patch operation")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "patch-app", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	appRevisions, err := porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              porterApp.ID,
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	latestRevision, err := porter_app.RevisionByNumber(appRevisions, porter_app.LatestRevisionNumber(appRevisions))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "app has no revisions to patch")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "latest-revision-id", Value: latestRevision.Id},
+		telemetry.AttributeKV{Key: "latest-revision-number", Value: int(latestRevision.RevisionNumber)},
+	)
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the env lives in the app's env group rather than the revision, so it is resolved with secret values so that the complete env can be resubmitted.
+	// Values must never be added to the span
+	revisionWithEnv, err := encodedRevisionWithEnv(ctx, encodedRevisionWithEnvInput{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		DeploymentTarget:    deploymentTarget,
+		Agent:               agent,
+		PorterAppRepository: c.Repo().PorterApp(),
+	}, latestRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting app env")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	patchedApp, err := porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App:        latestRevision.App,
+		Env:        revisionWithEnv.Env,
+		Operations: request.Operations,
+	})
+	if err != nil {
+		if errors.Is(err, porter_app.ErrImmutableAppField) {
+			err := telemetry.Error(ctx, span, err, "patch modifies an immutable field")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusUnprocessableEntity))
+			return
+		}
+		if errors.Is(err, porter_app.ErrInvalidAppPatch) {
+			err := telemetry.Error(ctx, span, err, "invalid patch")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error patching app config")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	applyReq := connect.NewRequest(patchedApp.ApplyRequest(project.ID, deploymentTargetID.String()))
+	ccpResp, err := c.Config().ClusterControlPlaneClient.ApplyPorterApp(ctx, applyReq)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error calling ccp apply porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if ccpResp == nil || ccpResp.Msg == nil {
+		err := telemetry.Error(ctx, span, nil, "ccp resp is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if ccpResp.Msg.PorterAppRevisionId == "" {
+		err := telemetry.Error(ctx, span, nil, "ccp resp app revision id is empty")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "resp-app-revision-id", Value: ccpResp.Msg.PorterAppRevisionId},
+		telemetry.AttributeKV{Key: "cli-action", Value: ccpResp.Msg.CliAction.String()},
+	)
+
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_Deploy,
+		PorterAppID:        porterApp.ID,
+		AppName:            porterApp.Name,
+		DeploymentTargetID: deploymentTargetID.String(),
+		AppRevisionID:      ccpResp.Msg.PorterAppRevisionId,
+	})
+
+	c.WriteResult(w, r, &ApplyPorterAppResponse{
+		AppRevisionId: ccpResp.Msg.PorterAppRevisionId,
+		CLIAction:     ccpResp.Msg.CliAction,
+	})
+}
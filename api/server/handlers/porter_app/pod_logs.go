@@ -0,0 +1,177 @@
+package porter_app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// defaultPodLogTailLines is the number of log lines returned if tail_lines is not set
+	defaultPodLogTailLines = 100
+	// maxPodLogTailLines is the maximum number of log lines that can be requested
+	maxPodLogTailLines = 5000
+)
+
+// PodLogsHandler is the handler for GET /apps/{porter_app_name}/pods/{pod_name}/logs
+type PodLogsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewPodLogsHandler returns a new PodLogsHandler
+func NewPodLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PodLogsHandler {
+	return &PodLogsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// PodLogsRequest is the expected format for a request on GET /apps/{porter_app_name}/pods/{pod_name}/logs
+type PodLogsRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// Container is the name of the container to get logs for. If empty, the pod's first container is used
+	Container string `schema:"container"`
+	// TailLines is the number of most recent log lines to return. Defaults to 100, with a maximum of 5000
+	TailLines int64 `schema:"tail_lines"`
+}
+
+// ServeHTTP returns a snapshot of the most recent log lines of a container in one of the app's pods.
+// The pod must be labeled with the app name and deployment target, so that logs of other apps cannot be read through this endpoint.
+func (c *PodLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-pod-logs")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "porter app name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	podName, reqErr := requestutils.GetURLParamString(r, types.URLParamPodName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "pod name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName}, telemetry.AttributeKV{Key: "pod-name", Value: podName})
+
+	request := &PodLogsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "invalid request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.DeploymentTargetID == "" {
+		err := telemetry.Error(ctx, span, nil, "must provide deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	tailLines := request.TailLines
+	if tailLines == 0 {
+		tailLines = defaultPodLogTailLines
+	}
+	if tailLines < 0 || tailLines > maxPodLogTailLines {
+		err := telemetry.Error(ctx, span, nil, "tail lines must be between 1 and 5000")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "container", Value: request.Container},
+		telemetry.AttributeKV{Key: "tail-lines", Value: tailLines},
+	)
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: request.DeploymentTargetID,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	pod, err := agent.GetPodByName(podName, namespace)
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			err = telemetry.Error(ctx, span, err, "pod not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "unable to get pod")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// a pod that is not part of this app is reported as not found, so that the endpoint does not reveal which pods exist
+	if !podBelongsToApp(pod, c.Config().ServerConf.PodLabelPrefix, appName, request.DeploymentTargetID) {
+		err = telemetry.Error(ctx, span, nil, "pod does not belong to app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	container := request.Container
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "resolved-container", Value: container})
+
+	rawLines, err := agent.GetPodLogLines(ctx, namespace, podName, container, tailLines)
+	if err != nil {
+		var badRequestErr *kubernetes.BadRequestError
+		if errors.As(err, &badRequestErr) {
+			// e.g. the container does not exist or has not started yet
+			err = telemetry.Error(ctx, span, err, "unable to get pod logs")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "unable to get pod logs")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// lines are returned oldest first
+	res := make([]porter_app.PodLogLine, 0, len(rawLines))
+	for _, line := range rawLines {
+		res = append(res, porter_app.PodLogLineFromK8s(line))
+	}
+
+	c.WriteResult(w, r, res)
+}
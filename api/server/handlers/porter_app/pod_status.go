@@ -1,8 +1,10 @@
 package porter_app
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -12,9 +14,14 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
 	"github.com/porter-dev/porter/internal/telemetry"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // PodStatusHandler is the handler for GET /apps/pods
@@ -37,25 +44,82 @@ func NewPodStatusHandler(
 
 // PodStatusRequest is the expected format for a request body on GET /apps/pods
 type PodStatusRequest struct {
-	DeploymentTargetID string `schema:"deployment_target_id"`
-	ServiceName        string `schema:"service"`
+	DeploymentTargetID string `schema:"deployment_target_id" form:"omitempty,uuid"`
+	// ServiceName restricts the pods to a service. This may be a comma-separated list to select pods of any of several services, e.g. "web,web-canary"
+	ServiceName string `schema:"service"`
+	// Phases is an optional comma-separated list of pod phases to return, e.g. "Running,Pending".
+	// Phases are matched exactly against pod.Status.Phase. If empty, pods in all phases are returned.
+	// Completed job pods are only returned if IncludeCompleted is also set, even if Succeeded is requested
+	Phases string `schema:"phases"`
+	// IncludeEvents attaches the most recent kubernetes events to each returned pod
+	IncludeEvents bool `schema:"include_events"`
+	// IncludeMetrics attaches the current cpu and memory usage to each container. Usage is omitted if the metrics server is not installed
+	IncludeMetrics bool `schema:"include_metrics"`
+	// ExcludeLabels is an optional list of labels in the form key=value. Pods with any of these labels are excluded, e.g. "porter.run/job=true"
+	// to exclude job pods which share the app name label.
+	ExcludeLabels []string `schema:"exclude_labels"`
+	// CountOnly returns the number of pods of each service in each phase, keyed by service name, instead of the status of each pod.
+	// Events and metrics are not fetched when set
+	CountOnly bool `schema:"count_only"`
+	// Limit is the maximum number of pods to list. If set, the pods are paginated and the continue token for the next page is returned in the
+	// X-Continue-Token response header, which is empty on the last page. Pods are filtered by phase after listing, so a page may contain fewer pods
+	Limit int64 `schema:"limit" form:"gte=0,lte=500"`
+	// Continue is the continue token returned with the previous page of pods
+	Continue string `schema:"continue"`
+	// IncludeCompleted returns pods of jobs which ran to completion, i.e. pods in the Succeeded phase owned by a Job. These are excluded by default,
+	// since apps with cron jobs accumulate completed pods which would otherwise crowd out the pods of long-running services
+	IncludeCompleted bool `schema:"include_completed"`
+	// SortBy sorts the returned pods. The only supported value is "restarts", which sorts pods by the total restart count of their containers.
+	// If empty, pods are returned in the order they are listed. Sorting applies within a page, and is ignored if CountOnly is set
+	SortBy string `schema:"sort_by" form:"omitempty,oneof=restarts"`
+	// SortOrder is the direction to sort pods in when SortBy is set, either "asc" or "desc". Defaults to "desc", so that the pods with the most restarts are first
+	SortOrder string `schema:"sort_order" form:"omitempty,oneof=asc desc"`
+	// Fields is an optional comma-separated list of pod status fields to return, e.g. "name,phase,ready". Other fields are omitted from
+	// each pod. If empty, all fields are returned. Ignored if CountOnly is set
+	Fields string `schema:"fields"`
+	// GroupBy groups the returned pods. The only supported value is "service", which returns a map of service name to the pods of the service,
+	// with pods missing the service label under "unknown". If empty, a flat list of pods is returned. Pods keep their sort order within each
+	// service, and GroupBy is ignored if CountOnly is set, since counts are already keyed by service
+	GroupBy string `schema:"group_by" form:"omitempty,oneof=service"`
 }
 
+const (
+	// podStatusSortBy_Restarts sorts pods by the total restart count of their containers
+	podStatusSortBy_Restarts = "restarts"
+	// podStatusSortOrder_Asc sorts pods in ascending order
+	podStatusSortOrder_Asc = "asc"
+	// podStatusGroupBy_Service groups pods by the service they belong to
+	podStatusGroupBy_Service = "service"
+	// unknownServiceGroup is the group of pods which are missing the service label
+	unknownServiceGroup = "unknown"
+)
+
+const (
+	// maxPodEvents is the maximum number of events returned per pod when events are requested
+	maxPodEvents = 10
+	// maxPodStatusLimit is the maximum number of pods which can be requested per page
+	maxPodStatusLimit = 500
+	// podStatusContinueHeader is the response header containing the continue token for the next page of pods
+	podStatusContinueHeader = "X-Continue-Token"
+)
+
 func (c *PodStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-pod-status")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	request := &PodStatusRequest{}
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
 		err := telemetry.Error(ctx, span, nil, "invalid request")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
 
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	if reqErr != nil {
 		err := telemetry.Error(ctx, span, reqErr, "porter app name not found in request")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
 		return
 	}
 
@@ -66,18 +130,26 @@ func (c *PodStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if request.DeploymentTargetID == "" {
 		err := telemetry.Error(ctx, span, nil, "must provide deployment target id")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidDeploymentTarget))
 		return
 	}
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID})
 
-	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ctx, deployment_target.DeploymentTargetDetailsInput{
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "pod-status", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
 		ProjectID:          int64(project.ID),
 		ClusterID:          int64(cluster.ID),
 		DeploymentTargetID: request.DeploymentTargetID,
 		CCPClient:          c.Config().ClusterControlPlaneClient,
 	})
 	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
 		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
@@ -86,6 +158,14 @@ func (c *PodStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	namespace := deploymentTarget.Namespace
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
 
+	// the namespace is empty while the deployment target is still being provisioned. Listing pods with an empty namespace would
+	// search all namespaces or the default namespace, which looks like the app has no pods or returns pods from other targets
+	if namespace == "" {
+		err := telemetry.Error(ctx, span, nil, "deployment target namespace is empty; the deployment target is not ready yet")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusConflict), types.APIErrorCode_TargetNotReady))
+		return
+	}
+
 	agent, err := c.GetAgent(r, cluster, "")
 	if err != nil {
 		err = telemetry.Error(ctx, span, err, "unable to get agent")
@@ -93,22 +173,287 @@ func (c *PodStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pods := []v1.Pod{}
+	excludeSelector, err := excludeLabelsSelector(request.ExcludeLabels)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid exclude labels")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+
+	if err := validateServiceNames(request.ServiceName); err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid service name")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
 
-	var selectors string
-	if request.ServiceName == "" {
-		selectors = fmt.Sprintf("porter.run/deployment-target-id=%s,porter.run/app-name=%s", request.DeploymentTargetID, appName)
+	appSelector := podSelector(c.Config().ServerConf.PodLabelPrefix, request.DeploymentTargetID, appName, request.ServiceName)
+	selector := appSelector
+	if excludeSelector != "" {
+		selector = fmt.Sprintf("%s,%s", selector, excludeSelector)
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "label-selector", Value: selector})
+
+	fields, err := porter_app.ParsePodStatusFields(request.Fields)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid fields")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "fields", Value: request.Fields})
+
+	if request.Limit < 0 || request.Limit > maxPodStatusLimit {
+		err := telemetry.Error(ctx, span, nil, "limit must be between 0 and 500, where 0 lists all pods")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+	if request.Continue != "" && request.Limit == 0 {
+		err := telemetry.Error(ctx, span, nil, "limit must be set when continue is set")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "limit", Value: int(request.Limit)},
+		telemetry.AttributeKV{Key: "paginated", Value: request.Continue != ""},
+	)
+
+	var podsList *v1.PodList
+	if request.Limit == 0 {
+		podsList, err = agent.GetPodsByLabel(selector, namespace)
 	} else {
-		selectors = fmt.Sprintf("porter.run/service-name=%s,porter.run/deployment-target-id=%s,porter.run/app-name=%s", request.ServiceName, request.DeploymentTargetID, appName)
+		podsList, err = agent.ListPodsByLabelPage(ctx, selector, namespace, request.Limit, request.Continue)
 	}
-	podsList, err := agent.GetPodsByLabel(selectors, namespace)
 	if err != nil {
+		// continue tokens expire after a few minutes, in which case the client must start again from the first page
+		if k8serrors.IsResourceExpired(err) || k8serrors.IsGone(err) {
+			err = telemetry.Error(ctx, span, err, "continue token has expired")
+			c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusGone), types.APIErrorCode_InvalidRequest))
+			return
+		}
 		err = telemetry.Error(ctx, span, err, "unable to get pods by label")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
 	}
+	if request.Limit > 0 {
+		w.Header().Set(podStatusContinueHeader, podsList.Continue)
+	}
+
+	phases := make(map[v1.PodPhase]bool)
+	for _, phase := range strings.Split(request.Phases, ",") {
+		phase = strings.TrimSpace(phase)
+		if phase != "" {
+			phases[v1.PodPhase(phase)] = true
+		}
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "phases", Value: request.Phases},
+		telemetry.AttributeKV{Key: "include-events", Value: request.IncludeEvents},
+		telemetry.AttributeKV{Key: "include-metrics", Value: request.IncludeMetrics},
+		telemetry.AttributeKV{Key: "count-only", Value: request.CountOnly},
+		telemetry.AttributeKV{Key: "include-completed", Value: request.IncludeCompleted},
+		telemetry.AttributeKV{Key: "sort-by", Value: request.SortBy},
+		telemetry.AttributeKV{Key: "sort-order", Value: request.SortOrder},
+		telemetry.AttributeKV{Key: "group-by", Value: request.GroupBy},
+	)
+
+	matchingPods := make([]v1.Pod, 0, len(podsList.Items))
+	for _, pod := range podsList.Items {
+		if len(phases) > 0 && !phases[pod.Status.Phase] {
+			continue
+		}
+		if !request.IncludeCompleted && porter_app.IsCompletedJobPod(pod) {
+			continue
+		}
+		matchingPods = append(matchingPods, pod)
+	}
+
+	if request.CountOnly {
+		c.WriteResult(w, r, porter_app.PodCountsByService(matchingPods, podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_ServiceName)))
+		return
+	}
+
+	podMetricsByName := make(map[string]kubernetes.PodMetrics)
+	if request.IncludeMetrics {
+		podMetrics, err := agent.GetPodMetricsByLabel(ctx, selector, namespace)
+		if err != nil {
+			// the metrics server is optional, so usage is omitted rather than failing the request
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "pod-metrics-error", Value: err.Error()})
+		}
+		for _, metrics := range podMetrics {
+			podMetricsByName[metrics.Name] = metrics
+		}
+	}
+
+	// replicasets carry the labels of their pods, so they are listed with the same selector to attribute pods to their deployments
+	var replicaSetOwners map[string]metav1.OwnerReference
+	replicaSets, err := agent.GetReplicaSetsBySelector(ctx, namespace, appSelector)
+	if err != nil {
+		// controllers are informational, so pods are attributed to their replicasets rather than failing the request
+		_ = telemetry.Error(ctx, span, err, "unable to list replicasets")
+	} else {
+		replicaSetOwners = porter_app.ReplicaSetOwners(replicaSets.Items)
+	}
+
+	pods := make([]porter_app.PodStatus, 0, len(matchingPods))
+	for _, pod := range matchingPods {
+		podStatus := porter_app.PodStatusFromPod(pod, podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_AppRevisionID))
+		podStatus.AttachController(pod, replicaSetOwners)
+		if metrics, ok := podMetricsByName[pod.Name]; ok {
+			podStatus.AttachContainerUsage(metrics)
+		}
+		// events are also fetched for pods which cannot pull their images, since the events distinguish bad credentials from missing images
+		if request.IncludeEvents || podStatus.HasImagePullErrors() {
+			eventList, err := agent.ListEvents(pod.Name, pod.Namespace)
+			if err != nil {
+				_ = telemetry.Error(ctx, span, err, fmt.Sprintf("unable to list events for pod %s", pod.Name))
+			} else {
+				podStatus.AttachImagePullErrors(eventList.Items)
+				if request.IncludeEvents {
+					podStatus.Events = porter_app.RecentPodEvents(eventList.Items, maxPodEvents)
+				}
+			}
+		}
+
+		pods = append(pods, podStatus)
+	}
+
+	if request.SortBy == podStatusSortBy_Restarts {
+		porter_app.SortPodStatusesByRestarts(pods, request.SortOrder != podStatusSortOrder_Asc)
+	}
+
+	// pods are grouped after sorting by their service label, which is read from the pods since it is not part of the pod status
+	serviceNameByPod := make(map[string]string, len(matchingPods))
+	if request.GroupBy == podStatusGroupBy_Service {
+		serviceNameKey := podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_ServiceName)
+		for _, pod := range matchingPods {
+			serviceName := pod.Labels[serviceNameKey]
+			if serviceName == "" {
+				serviceName = unknownServiceGroup
+			}
+			serviceNameByPod[pod.Name] = serviceName
+		}
+	}
+
+	if len(fields) > 0 {
+		projectedPods := make([]map[string]json.RawMessage, 0, len(pods))
+		for _, pod := range pods {
+			projected, err := pod.Project(fields)
+			if err != nil {
+				err = telemetry.Error(ctx, span, err, "error projecting pod status fields")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+				return
+			}
+			projectedPods = append(projectedPods, projected)
+		}
+		if request.GroupBy == podStatusGroupBy_Service {
+			groupedPods := make(map[string][]map[string]json.RawMessage)
+			for i, pod := range pods {
+				serviceName := serviceNameByPod[pod.Name]
+				groupedPods[serviceName] = append(groupedPods[serviceName], projectedPods[i])
+			}
+			c.WriteResult(w, r, groupedPods)
+			return
+		}
+		c.WriteResult(w, r, projectedPods)
+		return
+	}
 
-	pods = append(pods, podsList.Items...)
+	if request.GroupBy == podStatusGroupBy_Service {
+		groupedPods := make(map[string][]porter_app.PodStatus)
+		for _, pod := range pods {
+			serviceName := serviceNameByPod[pod.Name]
+			groupedPods[serviceName] = append(groupedPods[serviceName], pod)
+		}
+		c.WriteResult(w, r, groupedPods)
+		return
+	}
 
 	c.WriteResult(w, r, pods)
 }
+
+// podSelector returns the label selector for the pods of an app in a deployment target, optionally restricted to one or more services.
+// serviceName may be a comma-separated list of services, e.g. "web,web-canary", in which case pods of any of the services are selected.
+// Label keys are under labelPrefix, which defaults to porter.run if empty.
+func podSelector(labelPrefix string, deploymentTargetID string, appName string, serviceName string) string {
+	serviceNames := splitServiceNames(serviceName)
+
+	deploymentTargetIDKey := podLabelKey(labelPrefix, podLabel_DeploymentTargetID)
+	appNameKey := podLabelKey(labelPrefix, podLabel_AppName)
+	serviceNameKey := podLabelKey(labelPrefix, podLabel_ServiceName)
+
+	switch len(serviceNames) {
+	case 0:
+		return fmt.Sprintf("%s=%s,%s=%s", deploymentTargetIDKey, deploymentTargetID, appNameKey, appName)
+	case 1:
+		return fmt.Sprintf("%s=%s,%s=%s,%s=%s", serviceNameKey, serviceNames[0], deploymentTargetIDKey, deploymentTargetID, appNameKey, appName)
+	default:
+		return fmt.Sprintf("%s in (%s),%s=%s,%s=%s", serviceNameKey, strings.Join(serviceNames, ","), deploymentTargetIDKey, deploymentTargetID, appNameKey, appName)
+	}
+}
+
+const (
+	// defaultPodLabelPrefix is the prefix of the labels porter sets on the pods of an app
+	defaultPodLabelPrefix = "porter.run"
+
+	podLabel_AppName            = "app-name"
+	podLabel_ServiceName        = "service-name"
+	podLabel_DeploymentTargetID = "deployment-target-id"
+	podLabel_AppRevisionID      = "app-revision-id"
+)
+
+// podLabelKey returns the key of a porter pod label under the given prefix, e.g. porter.run/app-name. The prefix defaults to porter.run if empty,
+// and is configurable for self-hosted installs which relabel resources
+func podLabelKey(labelPrefix string, name string) string {
+	if labelPrefix == "" {
+		labelPrefix = defaultPodLabelPrefix
+	}
+	return fmt.Sprintf("%s/%s", labelPrefix, name)
+}
+
+// podBelongsToApp returns true if the pod is labeled with the app name and deployment target, using label keys under labelPrefix
+func podBelongsToApp(pod *v1.Pod, labelPrefix string, appName string, deploymentTargetID string) bool {
+	return pod.Labels[podLabelKey(labelPrefix, podLabel_AppName)] == appName &&
+		pod.Labels[podLabelKey(labelPrefix, podLabel_DeploymentTargetID)] == deploymentTargetID
+}
+
+// splitServiceNames splits a comma-separated list of service names, ignoring empty entries
+func splitServiceNames(serviceName string) []string {
+	var serviceNames []string
+	for _, name := range strings.Split(serviceName, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			serviceNames = append(serviceNames, name)
+		}
+	}
+	return serviceNames
+}
+
+// validateServiceNames returns an error if any of the comma-separated service names is not a valid label value,
+// so that service names cannot alter the rest of the selector
+func validateServiceNames(serviceName string) error {
+	for _, name := range splitServiceNames(serviceName) {
+		if errs := validation.IsValidLabelValue(name); len(errs) > 0 {
+			return fmt.Errorf("invalid service name %q: %s", name, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
+// excludeLabelsSelector returns a label selector which excludes pods with any of the given key=value labels, e.g.
+// ["porter.run/job=true", "app=sidecar"] becomes "porter.run/job!=true,app!=sidecar". Keys and values are validated
+// as kubernetes label keys and values so that they cannot alter the rest of the selector.
+func excludeLabelsSelector(excludeLabels []string) (string, error) {
+	var requirements []string
+	for _, label := range excludeLabels {
+		key, value, found := strings.Cut(label, "=")
+		if !found {
+			return "", fmt.Errorf("exclude label %q must be in the form key=value", label)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return "", fmt.Errorf("invalid exclude label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return "", fmt.Errorf("invalid exclude label value %q: %s", value, strings.Join(errs, "; "))
+		}
+		requirements = append(requirements, fmt.Sprintf("%s!=%s", key, value))
+	}
+	return strings.Join(requirements, ","), nil
+}
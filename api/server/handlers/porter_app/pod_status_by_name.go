@@ -0,0 +1,171 @@
+package porter_app
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodStatusByNameHandler is the handler for GET /apps/{porter_app_name}/pods/{pod_name}
+type PodStatusByNameHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewPodStatusByNameHandler returns a new PodStatusByNameHandler
+func NewPodStatusByNameHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PodStatusByNameHandler {
+	return &PodStatusByNameHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// PodStatusByNameRequest is the expected format for a request on GET /apps/{porter_app_name}/pods/{pod_name}
+type PodStatusByNameRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id" form:"omitempty,uuid"`
+	// IncludeEvents attaches the most recent kubernetes events to the pod
+	IncludeEvents bool `schema:"include_events"`
+}
+
+// ServeHTTP returns the status of a single pod of the app.
+// The pod must be labeled with the app name and deployment target, so that the status of other apps' pods cannot be read through this endpoint.
+func (c *PodStatusByNameHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-pod-status-by-name")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "porter app name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	podName, reqErr := requestutils.GetURLParamString(r, types.URLParamPodName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "pod name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName}, telemetry.AttributeKV{Key: "pod-name", Value: podName})
+
+	request := &PodStatusByNameRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "invalid request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.DeploymentTargetID == "" {
+		err := telemetry.Error(ctx, span, nil, "must provide deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "include-events", Value: request.IncludeEvents},
+	)
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "pod-status-by-name", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: request.DeploymentTargetID,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	if namespace == "" {
+		err := telemetry.Error(ctx, span, nil, "deployment target namespace is empty; the deployment target is not ready yet")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusConflict), types.APIErrorCode_TargetNotReady))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	pod, err := agent.GetPodByName(podName, namespace)
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			err = telemetry.Error(ctx, span, err, "pod not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "unable to get pod")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// a pod that is not part of this app is reported as not found, so that the endpoint does not reveal which pods exist
+	if !podBelongsToApp(pod, c.Config().ServerConf.PodLabelPrefix, appName, request.DeploymentTargetID) {
+		err = telemetry.Error(ctx, span, nil, "pod does not belong to app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	podStatus := porter_app.PodStatusFromPod(*pod, podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_AppRevisionID))
+
+	var replicaSetOwners map[string]metav1.OwnerReference
+	replicaSets, err := agent.GetReplicaSetsBySelector(ctx, namespace, podSelector(c.Config().ServerConf.PodLabelPrefix, request.DeploymentTargetID, appName, ""))
+	if err != nil {
+		// the controller is informational, so the pod is attributed to its replicaset rather than failing the request
+		_ = telemetry.Error(ctx, span, err, "unable to list replicasets")
+	} else {
+		replicaSetOwners = porter_app.ReplicaSetOwners(replicaSets.Items)
+	}
+	podStatus.AttachController(*pod, replicaSetOwners)
+
+	// events are also fetched for pods which cannot pull their images, since the events distinguish bad credentials from missing images
+	if request.IncludeEvents || podStatus.HasImagePullErrors() {
+		eventList, err := agent.ListEvents(pod.Name, pod.Namespace)
+		if err != nil {
+			_ = telemetry.Error(ctx, span, err, "unable to list events for pod")
+		} else {
+			podStatus.AttachImagePullErrors(eventList.Items)
+			if request.IncludeEvents {
+				podStatus.Events = porter_app.RecentPodEvents(eventList.Items, maxPodEvents)
+			}
+		}
+	}
+
+	c.WriteResult(w, r, podStatus)
+}
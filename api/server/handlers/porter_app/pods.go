@@ -38,6 +38,8 @@ func (c *PorterAppPodsGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	ctx, span := telemetry.NewSpan(ctx, "serve-get-porter-app-pods")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	if reqErr != nil {
 		err := telemetry.Error(ctx, span, reqErr, "error getting stack name from url")
@@ -59,9 +59,9 @@ func (c *PredeployStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-predeploy-status")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
-	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
-	name, _ := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
 	appRevisionId, _ := requestutils.GetURLParamString(r, types.URLParamAppRevisionID)
 
 	if appRevisionId == "" {
@@ -71,9 +71,6 @@ func (c *PredeployStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 
 	telemetry.WithAttributes(span,
-		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
-		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
-		telemetry.AttributeKV{Key: "app-name", Value: name},
 		telemetry.AttributeKV{Key: "app-revision-id", Value: appRevisionId},
 	)
 
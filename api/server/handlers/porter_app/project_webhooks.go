@@ -0,0 +1,96 @@
+package porter_app
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app/webhooks"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// projectWebhookRequestTimeout is the timeout of a single request to a project webhook
+	projectWebhookRequestTimeout = 10 * time.Second
+	// projectWebhookDeliveryTimeout is the total time allowed to deliver an event to a project webhook, including retries
+	projectWebhookDeliveryTimeout = time.Minute
+)
+
+// projectWebhookClient refuses to connect to addresses inside a private network, so that webhooks cannot be used to reach internal services
+var projectWebhookClient = webhooks.NewClient(projectWebhookRequestTimeout)
+
+// sendAppEventWebhooks notifies the project's webhooks that a deploy or build event of an app reached a final status. Events which are not
+// delivered to webhooks, and events whose status is unchanged from previousStatus, are ignored so that an update is only delivered once
+func sendAppEventWebhooks(ctx context.Context, conf *config.Config, previousStatus string, appEvent models.PorterAppEvent) {
+	ctx, span := telemetry.NewSpan(ctx, "send-app-event-webhooks")
+	defer span.End()
+
+	if appEvent.Status == previousStatus {
+		return
+	}
+
+	eventType, ok := webhooks.EventTypeForAppEvent(appEvent.Type, appEvent.Status)
+	if !ok {
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "event", Value: string(eventType)})
+
+	app, err := conf.Repo.PorterApp().ReadPorterAppByID(ctx, appEvent.PorterAppID)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error reading porter app")
+		return
+	}
+
+	event := webhooks.Event{
+		Event:     eventType,
+		ProjectID: app.ProjectID,
+		ClusterID: app.ClusterID,
+		AppName:   app.Name,
+		EventID:   appEvent.ID.String(),
+		Status:    appEvent.Status,
+		Timestamp: time.Now().UTC(),
+	}
+	if appEvent.DeploymentTargetID != uuid.Nil {
+		event.DeploymentTargetID = appEvent.DeploymentTargetID.String()
+	}
+	event.AppRevisionID, _ = appEvent.Metadata["app_revision_id"].(string)
+
+	sendProjectWebhooks(ctx, conf, event)
+}
+
+// sendProjectWebhooks delivers an event to every webhook of the event's project.
+// Delivery happens in the background, so that retries against slow or failing endpoints do not hold up the caller
+func sendProjectWebhooks(ctx context.Context, conf *config.Config, event webhooks.Event) {
+	ctx, span := telemetry.NewSpan(ctx, "send-project-webhooks")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "event", Value: string(event.Event)},
+		telemetry.AttributeKV{Key: "project-id", Value: event.ProjectID},
+	)
+
+	projectWebhooks, err := conf.Repo.ProjectWebhook().ListByProjectID(ctx, event.ProjectID)
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error listing project webhooks")
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "webhook-count", Value: len(projectWebhooks)})
+
+	for _, projectWebhook := range projectWebhooks {
+		go func(projectWebhook *models.ProjectWebhook) {
+			// the request context is cancelled once the response is written, so delivery uses its own
+			deliveryCtx, cancel := context.WithTimeout(context.Background(), projectWebhookDeliveryTimeout)
+			defer cancel()
+
+			// errors are recorded on the delivery span
+			_ = webhooks.Deliver(deliveryCtx, webhooks.DeliverInput{
+				Client:        projectWebhookClient,
+				URL:           projectWebhook.URL,
+				SigningSecret: projectWebhook.SigningSecret,
+				Event:         event,
+			})
+		}(projectWebhook)
+	}
+}
@@ -0,0 +1,177 @@
+package porter_app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RestartPodHandler is the handler for POST /apps/{porter_app_name}/pods/{pod_name}/restart
+type RestartPodHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRestartPodHandler returns a new RestartPodHandler
+func NewRestartPodHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RestartPodHandler {
+	return &RestartPodHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RestartPodRequest is the expected format for a request on POST /apps/{porter_app_name}/pods/{pod_name}/restart
+type RestartPodRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// GracePeriodSeconds overrides how long the pod's containers are given to terminate before they are killed. 0 kills a stuck pod immediately.
+	// If unset, the pod's own termination grace period is used
+	GracePeriodSeconds *int64 `json:"grace_period_seconds" form:"omitempty,gte=0"`
+}
+
+// RestartPodResponse is the response for POST /apps/{porter_app_name}/pods/{pod_name}/restart
+type RestartPodResponse struct {
+	// PodName is the name of the deleted pod. The pod's controller creates a replacement with a new name
+	PodName   string `json:"pod_name"`
+	Namespace string `json:"namespace"`
+	// GracePeriodSeconds is the grace period the pod was deleted with, if one was requested
+	GracePeriodSeconds *int64 `json:"grace_period_seconds,omitempty"`
+}
+
+// ServeHTTP restarts a pod of the app by deleting it, so that its controller recreates it.
+// The pod must be labeled with the app name and deployment target, so that pods of other apps cannot be deleted through this endpoint.
+func (c *RestartPodHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-restart-pod")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "porter app name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	podName, reqErr := requestutils.GetURLParamString(r, types.URLParamPodName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "pod name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName}, telemetry.AttributeKV{Key: "pod-name", Value: podName})
+
+	request := &RestartPodRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "invalid request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.DeploymentTargetID == "" {
+		err := telemetry.Error(ctx, span, nil, "must provide deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID})
+	if request.GracePeriodSeconds != nil {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "grace-period-seconds", Value: int(*request.GracePeriodSeconds)})
+	}
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "restart-pod", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	deploymentTarget, err := deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+		ProjectID:          int64(project.ID),
+		ClusterID:          int64(cluster.ID),
+		DeploymentTargetID: request.DeploymentTargetID,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	pod, err := agent.GetPodByName(podName, namespace)
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			err = telemetry.Error(ctx, span, err, "pod not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "unable to get pod")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// a pod that is not part of this app is reported as not found, so that the endpoint does not reveal which pods exist
+	if !podBelongsToApp(pod, c.Config().ServerConf.PodLabelPrefix, appName, request.DeploymentTargetID) {
+		err = telemetry.Error(ctx, span, nil, "pod does not belong to app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	err = agent.DeletePodWithGracePeriod(ctx, namespace, podName, request.GracePeriodSeconds)
+	if err != nil {
+		if errors.Is(err, kubernetes.IsNotFoundError) {
+			// the pod was deleted between being read and deleted, e.g. by a concurrent restart
+			err = telemetry.Error(ctx, span, err, "pod not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err = telemetry.Error(ctx, span, err, "unable to delete pod")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	detail := fmt.Sprintf("pod %s", podName)
+	if request.GracePeriodSeconds != nil {
+		detail = fmt.Sprintf("%s with grace period %ds", detail, *request.GracePeriodSeconds)
+	}
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_RestartPod,
+		AppName:            appName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		Detail:             detail,
+	})
+
+	c.WriteResult(w, r, &RestartPodResponse{
+		PodName:            podName,
+		Namespace:          namespace,
+		GracePeriodSeconds: request.GracePeriodSeconds,
+	})
+}
@@ -0,0 +1,339 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"helm.sh/helm/v3/pkg/release"
+)
+
+// jsonMediaType is the media type requested through the Accept header to receive the manifest as json rather than yaml
+const jsonMediaType = "application/json"
+
+// RevisionManifestHandler handles requests to the /apps/{porter_app_name}/revisions/{app_revision_number}/manifest endpoint
+type RevisionManifestHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRevisionManifestHandler returns a new RevisionManifestHandler
+func NewRevisionManifestHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RevisionManifestHandler {
+	return &RevisionManifestHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RevisionManifestRequest is the request object for the /apps/{porter_app_name}/revisions/{app_revision_number}/manifest endpoint
+type RevisionManifestRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+	// IncludeSecrets returns the values of secrets in the manifest. This requires update access to the cluster, and values are redacted otherwise
+	IncludeSecrets bool `schema:"include_secrets"`
+}
+
+// RevisionManifestResponse is the response object for the /apps/{porter_app_name}/revisions/{app_revision_number}/manifest endpoint,
+// returned when json is requested through the Accept header
+type RevisionManifestResponse struct {
+	// RevisionNumber is the number of the revision the manifest was rendered for
+	RevisionNumber uint64 `json:"revision_number"`
+	// HelmRevision is the version of the helm release the manifest was read from
+	HelmRevision int `json:"helm_revision"`
+	// Objects are the kubernetes objects of the manifest, including those of hooks such as the pre-deploy job
+	Objects []map[string]interface{} `json:"objects"`
+}
+
+// ServeHTTP returns the rendered kubernetes manifest of a revision of an app as yaml, or as json if application/json is requested through the Accept header.
+// The manifest is read from the most recent release in the helm release history of the app whose objects are labeled with the id of the revision, so
+// revisions older than the release history kept by helm are not found.
+func (c *RevisionManifestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-revision-manifest")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &RevisionManifestRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "include-secrets", Value: request.IncludeSecrets},
+	)
+
+	if request.IncludeSecrets {
+		canReadSecrets, err := hasUpdateAccess(ctx, c.Config())
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error checking access to secrets")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		if !canReadSecrets {
+			err := telemetry.Error(ctx, span, nil, "include_secrets requires update access to the cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+	}
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "revision-manifest", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              porterApp.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			err := telemetry.Error(ctx, span, err, "app revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting app revision by number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-id", Value: appRevision.Id})
+
+	var deploymentTarget deployment_target.DeploymentTarget
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "revision-manifest", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	if namespace == "" {
+		err := telemetry.Error(ctx, span, nil, "deployment target namespace is empty; the deployment target is not ready yet")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusConflict), types.APIErrorCode_TargetNotReady))
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(ctx, r, cluster, namespace)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting helm agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// apps are installed as a helm release named after the app in the namespace of the deployment target
+	releases, err := helmAgent.GetReleaseHistory(ctx, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting helm release history")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "helm-release-count", Value: len(releases)})
+
+	revisionLabelKey := podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_AppRevisionID)
+	helmRelease, objects, err := releaseForRevision(releases, revisionLabelKey, appRevision.Id, request.IncludeSecrets)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing helm release manifest")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if helmRelease == nil {
+		err := telemetry.Error(ctx, span, nil, "no helm release in the release history was rendered for the revision; the release history only holds the most recent releases of the app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "helm-revision", Value: helmRelease.Version},
+		telemetry.AttributeKV{Key: "object-count", Value: len(objects)},
+	)
+
+	if requestutils.AcceptsMediaType(r, jsonMediaType) {
+		c.WriteResult(w, r, &RevisionManifestResponse{
+			RevisionNumber: appRevision.RevisionNumber,
+			HelmRevision:   helmRelease.Version,
+			Objects:        objects,
+		})
+		return
+	}
+
+	manifest, err := porter_app.ManifestYAML(objects)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error encoding manifest")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(manifest); err != nil {
+		_ = telemetry.Error(ctx, span, err, "error writing manifest")
+	}
+}
+
+// releaseForRevision returns the most recent of the helm releases whose manifest is labeled with the app revision id, along with the objects of its
+// manifest and hooks. A nil release is returned if no release was rendered for the revision
+func releaseForRevision(releases []*release.Release, revisionLabelKey string, appRevisionID string, includeSecrets bool) (*release.Release, []map[string]interface{}, error) {
+	sorted := make([]*release.Release, 0, len(releases))
+	for _, rel := range releases {
+		if rel != nil {
+			sorted = append(sorted, rel)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Version > sorted[j].Version
+	})
+
+	for _, rel := range sorted {
+		manifests := []string{rel.Manifest}
+		for _, hook := range rel.Hooks {
+			if hook != nil {
+				manifests = append(manifests, hook.Manifest)
+			}
+		}
+
+		// secret values must never be added to the span
+		objects, err := porter_app.ManifestObjects(strings.Join(manifests, "\n---\n"), includeSecrets)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing manifest of helm revision %d: %w", rel.Version, err)
+		}
+		if porter_app.ManifestHasLabel(objects, revisionLabelKey, appRevisionID) {
+			return rel, objects, nil
+		}
+	}
+
+	return nil, nil, nil
+}
+
+// hasUpdateAccess returns true if the policy of the api token or user in the request context permits updates on every scope of the request,
+// e.g. to reveal secrets on an endpoint which otherwise only requires read access
+func hasUpdateAccess(ctx context.Context, conf *config.Config) (bool, error) {
+	reqScopes, _ := ctx.Value(types.RequestScopeCtxKey).(map[types.PermissionScope]*types.RequestAction)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	if len(reqScopes) == 0 || project == nil {
+		return false, nil
+	}
+
+	opts := &policy.PolicyLoaderOpts{ProjectID: project.ID}
+	if apiToken, _ := ctx.Value("api_token").(*models.APIToken); apiToken != nil {
+		opts.ProjectToken = apiToken
+	} else if user, _ := ctx.Value(types.UserScope).(*models.User); user != nil {
+		opts.UserID = user.ID
+	} else {
+		return false, nil
+	}
+
+	policyDocs, reqErr := policy.NewBasicPolicyDocumentLoader(conf.Repo.Project(), conf.Repo.Policy()).LoadPolicyDocuments(opts)
+	if reqErr != nil {
+		return false, fmt.Errorf("error loading policy documents: %s", reqErr.Error())
+	}
+
+	updateScopes := make(map[types.PermissionScope]*types.RequestAction, len(reqScopes))
+	for scope, action := range reqScopes {
+		if action == nil {
+			continue
+		}
+		updateScopes[scope] = &types.RequestAction{
+			Verb:     types.APIVerbUpdate,
+			Resource: action.Resource,
+		}
+	}
+
+	return policy.HasScopeAccess(policyDocs, updateScopes), nil
+}
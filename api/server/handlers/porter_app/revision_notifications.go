@@ -0,0 +1,206 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/porter_app/notifications"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RevisionNotificationsHandler handles requests to the /apps/{porter_app_name}/revisions/{app_revision_number}/notifications endpoint
+type RevisionNotificationsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewRevisionNotificationsHandler returns a new RevisionNotificationsHandler
+func NewRevisionNotificationsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RevisionNotificationsHandler {
+	return &RevisionNotificationsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// RevisionNotificationsRequest is the request object for the /apps/{porter_app_name}/revisions/{app_revision_number}/notifications endpoint
+type RevisionNotificationsRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ClusterID is used to select the porter app when multiple apps in the project share the same name
+	ClusterID uint `schema:"cluster_id"`
+	// Limit is the maximum number of notifications to return, most recent first. Defaults to 50
+	Limit int `schema:"limit"`
+	// Offset is the number of most recent notifications to skip
+	Offset int `schema:"offset"`
+}
+
+// RevisionNotificationsResponse is the response object for the /apps/{porter_app_name}/revisions/{app_revision_number}/notifications endpoint
+type RevisionNotificationsResponse struct {
+	// Notifications are the notifications of the revision, most recent first. This is empty if the revision had no notifications
+	Notifications []notifications.Notification `json:"notifications"`
+	// TotalCount is the total number of notifications of the revision after repeated notifications are collapsed, before limit and offset are applied.
+	// Only the most recent notification events of the revision are considered, up to maxRevisionNotificationEvents
+	TotalCount int64 `json:"total_count"`
+}
+
+// ServeHTTP returns the notifications of a historical revision of an app, e.g. to explain why an older deploy failed
+func (c *RevisionNotificationsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-revision-notifications")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if revisionNumber == 0 {
+		err := telemetry.Error(ctx, span, nil, "app revision number must be a positive integer")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &RevisionNotificationsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit < 0 || limit > maxNotificationLimit || request.Offset < 0 {
+		err := telemetry.Error(ctx, span, nil, "limit must be between 1 and 500 and offset must not be negative")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "limit", Value: limit},
+		telemetry.AttributeKV{Key: "offset", Value: request.Offset},
+	)
+
+	porterApps, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting porter app from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	porterApp, err := selectPorterAppByCluster(porterApps, request.ClusterID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error selecting porter app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-id", Value: porterApp.ID})
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "revision-notifications", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              porterApp.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			err := telemetry.Error(ctx, span, err, "app revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting app revision by number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, appRevision)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error encoding revision from proto")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-revision-id", Value: encodedRevision.ID},
+		telemetry.AttributeKV{Key: "app-instance-id", Value: encodedRevision.AppInstanceID},
+	)
+
+	res := &RevisionNotificationsResponse{
+		Notifications: make([]notifications.Notification, 0),
+	}
+
+	// notifications are keyed by app instance, so a revision without one cannot have any
+	if encodedRevision.AppInstanceID == uuid.Nil {
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	// the most recent notifications of the revision are read, since repeated notifications must be collapsed before they are paginated
+	notificationEvents, err := c.Repo().PorterAppEvent().ReadNotificationsByAppRevisionID(ctx, encodedRevision.AppInstanceID, encodedRevision.ID, time.Time{}, maxRevisionNotificationEvents)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting notifications from repo")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-events-truncated", Value: len(notificationEvents) == maxRevisionNotificationEvents})
+
+	revisionNotifications := notifications.NotificationsFromPorterAppEvents(ctx, notificationEvents)
+	res.Notifications = notifications.PaginateNotifications(revisionNotifications, limit, request.Offset)
+	res.TotalCount = int64(len(revisionNotifications))
+
+	c.WriteResult(w, r, res)
+}
@@ -38,6 +38,8 @@ func NewRollbackPorterAppHandler(
 func (c *RollbackPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollback-porter-app")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
 	request := &types.RollbackPorterAppRequest{}
@@ -51,6 +51,8 @@ func (c *RollbackAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollback-app-revision")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
@@ -128,6 +130,14 @@ func (c *RollbackAppRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_Rollback,
+		PorterAppID:        app.ID,
+		AppName:            appName,
+		DeploymentTargetID: deploymentTargetID.String(),
+		AppRevisionID:      ccpResp.Msg.AppRevisionId,
+	})
+
 	c.WriteResult(w, r, &RollbackAppRevisionResponse{
 		TargetRevisionNumber: int(ccpResp.Msg.TargetRevisionNumber),
 	})
@@ -0,0 +1,338 @@
+package porter_app
+
+import (
+	"context"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RollbackToRevisionHandler redeploys the config of a specific revision of an app
+type RollbackToRevisionHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRollbackToRevisionHandler returns a new RollbackToRevisionHandler
+func NewRollbackToRevisionHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RollbackToRevisionHandler {
+	return &RollbackToRevisionHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RollbackToRevisionRequest is the request body for the /apps/{porter_app_name}/revisions/{app_revision_number}/rollback endpoint
+type RollbackToRevisionRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// DryRun resolves the target revision and returns its diff against the current revision, without rolling back
+	DryRun bool `json:"dry_run"`
+}
+
+// RollbackToRevisionResponse is the response body for the /apps/{porter_app_name}/revisions/{app_revision_number}/rollback endpoint
+type RollbackToRevisionResponse struct {
+	// TargetRevisionNumber is the revision number that was rolled back to
+	TargetRevisionNumber uint64 `json:"target_revision_number"`
+	// AppRevision is the new revision created by the rollback. Not set for dry runs
+	AppRevision *porter_app.Revision `json:"app_revision,omitempty"`
+	// DryRun is true if the rollback was not submitted
+	DryRun bool `json:"dry_run,omitempty"`
+	// CurrentRevisionNumber is the number of the current revision which the rollback would replace. Only set for dry runs
+	CurrentRevisionNumber uint64 `json:"current_revision_number,omitempty"`
+	// Diff are the changes the rollback would make to the current revision. Only set for dry runs
+	Diff *porter_app.RevisionDiff `json:"diff,omitempty"`
+}
+
+// ServeHTTP rolls an app back to the requested revision number, creating a new revision with that revision's config.
+// If dry_run is set, the diff from the current revision to the target revision is returned instead, and no revision is created.
+func (c *RollbackToRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollback-to-revision")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
+		err := telemetry.Error(ctx, span, nil, "project does not have validate apply v2 enabled")
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &RollbackToRevisionRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "dry-run", Value: request.DryRun},
+	)
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollback-to-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              app.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	targetRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "target revision not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "target-revision-id", Value: targetRevision.Id},
+		telemetry.AttributeKV{Key: "target-revision-status", Value: targetRevision.Status},
+	)
+
+	switch models.AppRevisionStatus(targetRevision.Status) {
+	case models.AppRevisionStatus_BuildFailed, models.AppRevisionStatus_BuildCanceled:
+		err := telemetry.Error(ctx, span, nil, "cannot roll back to a revision that failed to build")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.DryRun {
+		var currentAppRevisionResp *connect.Response[porterv1.CurrentAppRevisionResponse]
+		err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollback-to-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+			var err error
+			currentAppRevisionResp, err = c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+				ProjectId:          int64(project.ID),
+				AppId:              int64(app.ID),
+				DeploymentTargetId: deploymentTargetID.String(),
+			}))
+			return err
+		})
+		if err != nil {
+			if isCCPTimeout(err) {
+				err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+				return
+			}
+			err := telemetry.Error(ctx, span, err, "error getting current app revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil || currentAppRevisionResp.Msg.AppRevision == nil {
+			err := telemetry.Error(ctx, span, nil, "app has no current revision to diff against")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+			return
+		}
+		currentRevisionProto := currentAppRevisionResp.Msg.AppRevision
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "current-revision-id", Value: currentRevisionProto.Id})
+
+		agent, err := c.GetAgent(r, cluster, "")
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting agent")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		var deploymentTarget deployment_target.DeploymentTarget
+		err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollback-to-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+			var err error
+			deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+				ProjectID:          int64(project.ID),
+				ClusterID:          int64(cluster.ID),
+				DeploymentTargetID: deploymentTargetID.String(),
+				CCPClient:          c.Config().ClusterControlPlaneClient,
+			})
+			return err
+		})
+		if err != nil {
+			if isCCPTimeout(err) {
+				err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+				return
+			}
+			err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		revisionInput := encodedRevisionWithEnvInput{
+			ProjectID:           project.ID,
+			ClusterID:           cluster.ID,
+			DeploymentTarget:    deploymentTarget,
+			Agent:               agent,
+			PorterAppRepository: c.Repo().PorterApp(),
+		}
+
+		currentRevision, err := encodedRevisionWithEnv(ctx, revisionInput, currentRevisionProto)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting current revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		rollbackRevision, err := encodedRevisionWithEnv(ctx, revisionInput, targetRevision)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting target revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		diff, err := porter_app.DiffRevisions(ctx, currentRevision, rollbackRevision)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error diffing revisions")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		c.WriteResult(w, r, &RollbackToRevisionResponse{
+			TargetRevisionNumber:  targetRevision.RevisionNumber,
+			DryRun:                true,
+			CurrentRevisionNumber: currentRevisionProto.RevisionNumber,
+			Diff:                  &diff,
+		})
+		return
+	}
+
+	rollbackReq := connect.NewRequest(&porterv1.RollbackRevisionRequest{
+		ProjectId:          int64(project.ID),
+		AppId:              int64(app.ID),
+		DeploymentTargetId: deploymentTargetID.String(),
+		AppRevisionId:      targetRevision.Id,
+	})
+	// the rollback creates a revision, so it is not retried
+	rollbackCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "rollback-to-revision", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	ccpResp, err := c.Config().ClusterControlPlaneClient.RollbackRevision(rollbackCtx, rollbackReq)
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error calling ccp rollback revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if ccpResp == nil || ccpResp.Msg == nil {
+		err := telemetry.Error(ctx, span, nil, "ccp resp is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	newRevisionID, err := uuid.Parse(ccpResp.Msg.AppRevisionId)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing new app revision id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if newRevisionID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "rollback did not create a new revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "new-app-revision-id", Value: newRevisionID.String()})
+
+	var newRevision porter_app.Revision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollback-to-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		newRevision, err = porter_app.GetAppRevision(ccpCtx, porter_app.GetAppRevisionInput{
+			ProjectID:     project.ID,
+			AppRevisionID: newRevisionID,
+			CCPClient:     c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting new app revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_Rollback,
+		PorterAppID:        app.ID,
+		AppName:            appName,
+		DeploymentTargetID: deploymentTargetID.String(),
+		AppRevisionID:      newRevision.ID,
+		RevisionNumber:     newRevision.RevisionNumber,
+	})
+
+	c.WriteResult(w, r, &RollbackToRevisionResponse{
+		TargetRevisionNumber: targetRevision.RevisionNumber,
+		AppRevision:          &newRevision,
+	})
+}
@@ -0,0 +1,279 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RolloutStatusHandler handles requests to the /apps/{porter_app_name}/rollout-status endpoint
+type RolloutStatusHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRolloutStatusHandler returns a new RolloutStatusHandler
+func NewRolloutStatusHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RolloutStatusHandler {
+	return &RolloutStatusHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RolloutStatusRequest is the request object for the /apps/{porter_app_name}/rollout-status endpoint
+type RolloutStatusRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ChangedOnly limits the response to services whose image or config changed from the previous revision. Unchanged services are assumed stable and omitted
+	ChangedOnly bool `schema:"changed_only"`
+}
+
+// RolloutStatusResponse is the response object for the /apps/{porter_app_name}/rollout-status endpoint
+type RolloutStatusResponse struct {
+	// AppRevisionID is the id of the latest revision of the app
+	AppRevisionID string `json:"app_revision_id"`
+	// RevisionNumber is the number of the latest revision of the app
+	RevisionNumber uint64 `json:"revision_number"`
+	// Status is the overall rollout status of the app
+	Status porter_app.RolloutStatus `json:"status"`
+	// Services maps service name to the rollout status of the service
+	Services map[string]porter_app.ServiceRolloutStatus `json:"services"`
+	// Errors maps service name to the error encountered getting the status of the service
+	Errors map[string]string `json:"errors,omitempty"`
+	// UnchangedServices are the services omitted because they did not change from the previous revision. Only set if changed_only was requested
+	UnchangedServices []string `json:"unchanged_services,omitempty"`
+}
+
+// ServeHTTP returns the rollout status of the latest revision of an app. Each long-running service is healthy if all of its desired replicas are ready,
+// failed if any of its pods are crash looping or cannot pull their image, and progressing otherwise. If the status of a service cannot be determined,
+// the app is reported as progressing rather than healthy. Each service also reports its rollout progress as a percentage of updated replicas.
+// If changed_only is set, only services which changed from the previous revision are reported, and the overall status is computed from those services.
+func (c *RolloutStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollout-status")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &RolloutStatusRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "changed-only", Value: request.ChangedOnly},
+	)
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app == nil || app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	currentAppRevisionReq := connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(project.ID),
+		AppId:              int64(app.ID),
+		DeploymentTargetId: deploymentTargetID.String(),
+	})
+	var currentAppRevisionResp *connect.Response[porterv1.CurrentAppRevisionResponse]
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollout-status", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		currentAppRevisionResp, err = c.Config().ClusterControlPlaneClient.CurrentAppRevision(ccpCtx, currentAppRevisionReq)
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting current app revision from cluster control plane client")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if currentAppRevisionResp == nil || currentAppRevisionResp.Msg == nil || currentAppRevisionResp.Msg.AppRevision == nil {
+		err := telemetry.Error(ctx, span, nil, "current app revision resp is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	appRevision := currentAppRevisionResp.Msg.AppRevision
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-id", Value: appRevision.Id})
+
+	var deploymentTarget deployment_target.DeploymentTarget
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollout-status", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	serviceNames := porter_app.LongRunningServiceNames(appRevision.App)
+
+	var unchangedServices []string
+	if request.ChangedOnly {
+		changedServices, err := c.changedServiceNames(ctx, project.ID, app.ID, deploymentTargetID.String(), appRevision)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting changed services")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		changed := make(map[string]bool, len(changedServices))
+		for _, name := range changedServices {
+			changed[name] = true
+		}
+
+		var changedServiceNames []string
+		for _, name := range serviceNames {
+			if changed[name] {
+				changedServiceNames = append(changedServiceNames, name)
+			} else {
+				unchangedServices = append(unchangedServices, name)
+			}
+		}
+		serviceNames = changedServiceNames
+
+		telemetry.WithAttributes(span,
+			telemetry.AttributeKV{Key: "changed-service-count", Value: len(serviceNames)},
+			telemetry.AttributeKV{Key: "unchanged-service-count", Value: len(unchangedServices)},
+		)
+	}
+
+	workloads, errs := listServiceWorkloads(ctx, agent, deploymentTarget.Namespace, c.Config().ServerConf.PodLabelPrefix, deploymentTargetID.String(), appName, "", serviceNames)
+
+	res := &RolloutStatusResponse{
+		AppRevisionID:     appRevision.Id,
+		RevisionNumber:    appRevision.RevisionNumber,
+		Services:          make(map[string]porter_app.ServiceRolloutStatus, len(workloads)),
+		Errors:            make(map[string]string, len(errs)),
+		UnchangedServices: unchangedServices,
+	}
+	for serviceName, workload := range workloads {
+		status := porter_app.ServiceRolloutStatusFromK8s(workload.deployments, workload.pods)
+		status.Progress = porter_app.RolloutProgressFromK8s(workload.deployments, workload.statefulSets)
+		res.Services[serviceName] = status
+	}
+	for serviceName, err := range errs {
+		res.Errors[serviceName] = err.Error()
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: telemetry.AttributeKey(fmt.Sprintf("service-status-error-%s", serviceName)), Value: err.Error()})
+	}
+
+	res.Status = porter_app.OverallRolloutStatus(res.Services)
+	if res.Status == porter_app.RolloutStatus_Healthy && len(res.Errors) > 0 {
+		res.Status = porter_app.RolloutStatus_Progressing
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "rollout-status", Value: string(res.Status)})
+
+	c.WriteResult(w, r, res)
+}
+
+// changedServiceNames returns the services which changed between the previous revision and the given revision.
+// Every service is considered changed if there is no previous revision, since the first revision rolls out all services.
+func (c *RolloutStatusHandler) changedServiceNames(ctx context.Context, projectID, appID uint, deploymentTargetID string, appRevision *porterv1.AppRevision) ([]string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "changed-service-names")
+	defer span.End()
+
+	if appRevision.RevisionNumber <= 1 {
+		return porter_app.LongRunningServiceNames(appRevision.App), nil
+	}
+
+	var appRevisions []*porterv1.AppRevision
+	err := withCCPRetry(ctx, span, ccpTimeout(c.Config(), "rollout-status", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          projectID,
+			AppID:              appID,
+			DeploymentTargetID: deploymentTargetID,
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing app revisions")
+	}
+
+	previousRevision, err := porter_app.RevisionByNumber(appRevisions, appRevision.RevisionNumber-1)
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			return porter_app.LongRunningServiceNames(appRevision.App), nil
+		}
+		return nil, telemetry.Error(ctx, span, err, "error getting previous revision")
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "previous-revision-number", Value: int64(previousRevision.RevisionNumber)})
+
+	changedServices, err := porter_app.ChangedServiceNames(previousRevision.App, appRevision.App)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error comparing revisions")
+	}
+
+	return changedServices, nil
+}
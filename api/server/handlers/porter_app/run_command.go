@@ -42,6 +42,8 @@ func (c *RunPorterAppCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-run-porter-app-command")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	request := &types.RunPorterAppCommandRequest{}
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
 		err := telemetry.Error(ctx, span, nil, "error decoding request")
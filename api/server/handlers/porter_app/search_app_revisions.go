@@ -0,0 +1,133 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// SearchAppRevisionsHandler handles requests to the /apps/{porter_app_name}/revisions/search endpoint
+type SearchAppRevisionsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewSearchAppRevisionsHandler returns a new SearchAppRevisionsHandler
+func NewSearchAppRevisionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SearchAppRevisionsHandler {
+	return &SearchAppRevisionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// SearchAppRevisionsRequest is the request object for the /apps/{porter_app_name}/revisions/search endpoint
+type SearchAppRevisionsRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	// ImageTag is the image tag or digest (sha256:...) to search for
+	ImageTag string `schema:"image_tag"`
+}
+
+// SearchAppRevisionsResponse is the response object for the /apps/{porter_app_name}/revisions/search endpoint
+type SearchAppRevisionsResponse struct {
+	// AppRevisions are the matching revisions, newest first
+	AppRevisions []porter_app.Revision `json:"app_revisions"`
+}
+
+// ServeHTTP returns the revisions of an app in a deployment target which deployed the requested image tag or digest
+func (c *SearchAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-search-app-revisions")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &SearchAppRevisionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.ImageTag == "" {
+		err := telemetry.Error(ctx, span, nil, "must provide an image tag")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "image-tag", Value: request.ImageTag})
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app == nil || app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	appRevisions, err := porter_app.ListAppRevisions(ctx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              app.ID,
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	matches := porter_app.RevisionsByImageTag(appRevisions, request.ImageTag)
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "match-count", Value: len(matches)})
+
+	res := &SearchAppRevisionsResponse{
+		AppRevisions: make([]porter_app.Revision, 0, len(matches)),
+	}
+	for _, revision := range matches {
+		encodedRevision, err := porter_app.EncodedRevisionFromProto(ctx, revision)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error encoding revision from proto")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		res.AppRevisions = append(res.AppRevisions, encodedRevision)
+	}
+
+	c.WriteResult(w, r, res)
+}
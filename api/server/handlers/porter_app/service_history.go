@@ -0,0 +1,204 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// defaultServiceHistoryPageSize is the number of image changes returned per page when page_size is not set
+	defaultServiceHistoryPageSize = 20
+	// maxServiceHistoryPageSize is the maximum number of image changes that can be requested per page
+	maxServiceHistoryPageSize = 100
+)
+
+// ServiceHistoryHandler handles requests to the /apps/{porter_app_name}/services/{service_name}/history endpoint
+type ServiceHistoryHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewServiceHistoryHandler returns a new ServiceHistoryHandler
+func NewServiceHistoryHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ServiceHistoryHandler {
+	return &ServiceHistoryHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServiceHistoryRequest is the request object for the /apps/{porter_app_name}/services/{service_name}/history endpoint
+type ServiceHistoryRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id" form:"required,uuid"`
+	// Page is the 1-indexed page of image changes to return. Defaults to the first page, which holds the most recent changes
+	Page int `schema:"page" form:"gte=0"`
+	// PageSize is the number of image changes to return per page. Defaults to 20, and may not exceed 100
+	PageSize int `schema:"page_size" form:"gte=0,lte=100"`
+}
+
+// ServiceHistoryPagination contains pagination details for the /apps/{porter_app_name}/services/{service_name}/history endpoint
+type ServiceHistoryPagination struct {
+	// TotalCount is the total number of image changes across all pages
+	TotalCount int `json:"total_count"`
+	// CurrentPage is the page returned in the response
+	CurrentPage int `json:"current_page"`
+	// PageSize is the number of image changes per page
+	PageSize int `json:"page_size"`
+	// HasNextPage is true if there are older image changes after the current page
+	HasNextPage bool `json:"has_next_page"`
+}
+
+// ServiceHistoryResponse is the response object for the /apps/{porter_app_name}/services/{service_name}/history endpoint
+type ServiceHistoryResponse struct {
+	ServiceName string `json:"service_name"`
+	// History are the revisions in which the image of the service changed, newest first
+	History    []porter_app.ServiceImageChange `json:"history"`
+	Pagination ServiceHistoryPagination        `json:"pagination"`
+}
+
+// ServeHTTP returns the deploy history of a single service of an app: each revision in which the image of the service changed, newest first.
+// Revisions which only changed other services or the service's config are omitted. Pagination is reported in both the body and the response headers.
+func (c *ServiceHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-service-history")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	serviceName, reqErr := requestutils.GetURLParamString(r, types.URLParamServiceName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing service name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "service-name", Value: serviceName})
+
+	request := &ServiceHistoryRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	page := request.Page
+	if page == 0 {
+		page = 1
+	}
+	pageSize := request.PageSize
+	if pageSize == 0 {
+		pageSize = defaultServiceHistoryPageSize
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()},
+		telemetry.AttributeKV{Key: "page", Value: page},
+		telemetry.AttributeKV{Key: "page-size", Value: pageSize},
+	)
+
+	if pageSize > maxServiceHistoryPageSize {
+		err := telemetry.Error(ctx, span, nil, "page_size must be between 1 and 100")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app == nil || app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	ccpCtx, cancel := withCCPTimeout(ctx, span, ccpTimeout(c.Config(), "service-history", c.Config().ServerConf.CCPRequestTimeout))
+	defer cancel()
+
+	appRevisions, err := porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+		ProjectID:          project.ID,
+		AppID:              app.ID,
+		DeploymentTargetID: deploymentTargetID.String(),
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	history, found := porter_app.ServiceImageHistory(appRevisions, serviceName)
+	if !found {
+		err := telemetry.Error(ctx, span, nil, "service does not exist in any revision of the app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	totalCount := len(history)
+	start := (page - 1) * pageSize
+	if start > totalCount {
+		start = totalCount
+	}
+	end := start + pageSize
+	if end > totalCount {
+		end = totalCount
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "revision-count", Value: len(appRevisions)},
+		telemetry.AttributeKV{Key: "image-change-count", Value: totalCount},
+	)
+
+	requestutils.SetPaginationHeaders(w, r, "page", requestutils.PaginationHeaders{
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: totalCount,
+	})
+
+	c.WriteResult(w, r, &ServiceHistoryResponse{
+		ServiceName: serviceName,
+		History:     history[start:end],
+		Pagination: ServiceHistoryPagination{
+			TotalCount:  totalCount,
+			CurrentPage: page,
+			PageSize:    pageSize,
+			HasNextPage: end < totalCount,
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package porter_app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/porter_app"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// serviceWorkloads are the kubernetes resources running a single service
+type serviceWorkloads struct {
+	deployments  []appsv1.Deployment
+	statefulSets []appsv1.StatefulSet
+	pods         []v1.Pod
+}
+
+// listServiceWorkloads returns the deployments, statefulsets and pods of each of the given services of an app in a deployment target, using the same selector as the pod status endpoint.
+// If appRevisionID is set, only the pods of that revision are returned, so that pods of the previous revision are not counted during a rollout.
+// Services whose workloads cannot be listed are omitted and the error is returned keyed by service name.
+func listServiceWorkloads(
+	ctx context.Context,
+	agent *kubernetes.Agent,
+	namespace string,
+	labelPrefix string,
+	deploymentTargetID string,
+	appName string,
+	appRevisionID string,
+	serviceNames []string,
+) (map[string]serviceWorkloads, map[string]error) {
+	workloads := make(map[string]serviceWorkloads)
+	errs := make(map[string]error)
+
+	for _, serviceName := range serviceNames {
+		selector := podSelector(labelPrefix, deploymentTargetID, appName, serviceName)
+
+		deployments, err := agent.GetDeploymentsBySelector(ctx, namespace, selector)
+		if err != nil {
+			errs[serviceName] = fmt.Errorf("error listing deployments: %w", err)
+			continue
+		}
+
+		statefulSets, err := agent.GetStatefulSetsBySelector(ctx, namespace, selector)
+		if err != nil {
+			errs[serviceName] = fmt.Errorf("error listing statefulsets: %w", err)
+			continue
+		}
+
+		pods, err := agent.GetPodsByLabel(revisionPodSelector(selector, labelPrefix, appRevisionID), namespace)
+		if err != nil {
+			errs[serviceName] = fmt.Errorf("error listing pods: %w", err)
+			continue
+		}
+
+		workloads[serviceName] = serviceWorkloads{
+			deployments:  deployments.Items,
+			statefulSets: statefulSets.Items,
+			pods:         pods.Items,
+		}
+	}
+
+	return workloads, errs
+}
+
+// serviceReplicaStatuses returns the replica status of each of the given services of an app in a deployment target.
+// If appRevisionID is set, only ready pods of that revision count towards the ready replicas.
+// Services whose status cannot be determined are omitted and the error is returned keyed by service name.
+func serviceReplicaStatuses(
+	ctx context.Context,
+	agent *kubernetes.Agent,
+	namespace string,
+	labelPrefix string,
+	deploymentTargetID string,
+	appName string,
+	appRevisionID string,
+	serviceNames []string,
+) (map[string]porter_app.ServiceReplicaStatus, map[string]error) {
+	workloads, errs := listServiceWorkloads(ctx, agent, namespace, labelPrefix, deploymentTargetID, appName, appRevisionID, serviceNames)
+
+	statuses := make(map[string]porter_app.ServiceReplicaStatus, len(workloads))
+	for serviceName, workload := range workloads {
+		statuses[serviceName] = porter_app.ServiceReplicaStatusFromK8s(workload.deployments, workload.pods)
+	}
+
+	return statuses, errs
+}
+
+// revisionPodSelector restricts a pod selector to the pods of an app revision. The selector is returned unchanged if appRevisionID is empty
+func revisionPodSelector(selector string, labelPrefix string, appRevisionID string) string {
+	if appRevisionID == "" {
+		return selector
+	}
+	return fmt.Sprintf("%s,%s=%s", selector, podLabelKey(labelPrefix, podLabel_AppRevisionID), appRevisionID)
+}
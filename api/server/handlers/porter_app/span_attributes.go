@@ -0,0 +1,29 @@
+package porter_app
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withScopeAttributes tags a handler's root span with the project and cluster from the request context, and the app name from the url if the route has one,
+// so that traces of all porter_app handlers can be queried by the same attributes. Handlers should call it right after creating their root span.
+func withScopeAttributes(r *http.Request, span trace.Span) {
+	var attrs []telemetry.AttributeKV
+
+	if project, _ := r.Context().Value(types.ProjectScope).(*models.Project); project != nil {
+		attrs = append(attrs, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+	}
+	if cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster); cluster != nil {
+		attrs = append(attrs, telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID})
+	}
+	if appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName); reqErr == nil && appName != "" {
+		attrs = append(attrs, telemetry.AttributeKV{Key: "app-name", Value: appName})
+	}
+
+	telemetry.WithAttributes(span, attrs...)
+}
@@ -42,6 +42,8 @@ func (c *AppStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-app-logs")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	safeRW := ctx.Value(types.RequestCtxWebsocketKey).(*websocket.WebsocketSafeReadWriter)
 	request := &AppStatusRequest{}
 
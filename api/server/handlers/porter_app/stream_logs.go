@@ -42,6 +42,8 @@ func NewStreamLogsLokiHandler(
 func (c *StreamLogsLokiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-stream-app-logs")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	r = r.Clone(ctx)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
@@ -59,7 +61,6 @@ func (c *StreamLogsLokiHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
 	if request.ServiceName == "" {
 		err := telemetry.Error(ctx, span, nil, "must provide service name")
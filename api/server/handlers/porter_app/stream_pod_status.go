@@ -0,0 +1,247 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// podStatusHeartbeatInterval is how often a comment line is sent on the stream so that proxies do not time out idle connections
+const podStatusHeartbeatInterval = 30 * time.Second
+
+// PodStatusStreamEventType is the type of event sent on the pod status stream
+type PodStatusStreamEventType string
+
+const (
+	// PodStatusStreamEventType_Snapshot is sent once when the stream opens and contains all matching pods
+	PodStatusStreamEventType_Snapshot PodStatusStreamEventType = "snapshot"
+	// PodStatusStreamEventType_Added is sent when a matching pod is created
+	PodStatusStreamEventType_Added PodStatusStreamEventType = "added"
+	// PodStatusStreamEventType_Modified is sent when a matching pod is updated
+	PodStatusStreamEventType_Modified PodStatusStreamEventType = "modified"
+	// PodStatusStreamEventType_Deleted is sent when a matching pod is deleted
+	PodStatusStreamEventType_Deleted PodStatusStreamEventType = "deleted"
+)
+
+// StreamPodStatusHandler is the handler for GET /apps/{porter_app_name}/pods/stream
+type StreamPodStatusHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewStreamPodStatusHandler returns a new StreamPodStatusHandler
+func NewStreamPodStatusHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *StreamPodStatusHandler {
+	return &StreamPodStatusHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// StreamPodStatusRequest is the expected format for a request on GET /apps/{porter_app_name}/pods/stream
+type StreamPodStatusRequest struct {
+	DeploymentTargetID string `schema:"deployment_target_id"`
+	ServiceName        string `schema:"service"`
+}
+
+// ServeHTTP streams pod status changes as server-sent events. An initial snapshot of all matching pods is sent, followed by an event for each
+// pod that is added, modified, or deleted. The stream ends when the client disconnects or the underlying watch is closed by the cluster.
+func (c *StreamPodStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-stream-pod-status")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	request := &StreamPodStatusRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "invalid request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "porter app name not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "service-name", Value: request.ServiceName}, telemetry.AttributeKV{Key: "app-name", Value: appName})
+
+	if request.DeploymentTargetID == "" {
+		err := telemetry.Error(ctx, span, nil, "must provide deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID})
+
+	if err := validateServiceNames(request.ServiceName); err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid service name")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+
+	var deploymentTarget deployment_target.DeploymentTarget
+	err := withCCPRetry(ctx, span, ccpTimeout(c.Config(), "stream-pod-status", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: request.DeploymentTargetID,
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	namespace := deploymentTarget.Namespace
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+
+	// the namespace is empty while the deployment target is still being provisioned, and watching with an empty namespace would watch all namespaces
+	if namespace == "" {
+		err := telemetry.Error(ctx, span, nil, "deployment target namespace is empty; the deployment target is not ready yet")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusConflict), types.APIErrorCode_TargetNotReady))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	selector := podSelector(c.Config().ServerConf.PodLabelPrefix, request.DeploymentTargetID, appName, request.ServiceName)
+
+	podsList, err := agent.GetPodsByLabel(selector, namespace)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get pods by label")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the watch is bound to the request context, so it is stopped when the client disconnects
+	podWatch, err := agent.WatchPodsByLabel(r.Context(), selector, namespace, podsList.ResourceVersion)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to watch pods by label")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	defer podWatch.Stop()
+
+	rc := http.NewResponseController(w)
+	// the server write timeout would otherwise close the stream
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "set-write-deadline-error", Value: err.Error()})
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	revisionIDLabelKey := podLabelKey(c.Config().ServerConf.PodLabelPrefix, podLabel_AppRevisionID)
+	snapshot := make([]porter_app.PodStatus, 0, len(podsList.Items))
+	for _, pod := range podsList.Items {
+		snapshot = append(snapshot, porter_app.PodStatusFromPod(pod, revisionIDLabelKey))
+	}
+	if err := writePodStatusEvent(rc, w, PodStatusStreamEventType_Snapshot, snapshot); err != nil {
+		_ = telemetry.Error(ctx, span, err, "error writing pod status snapshot")
+		return
+	}
+
+	heartbeat := time.NewTicker(podStatusHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-requestutils.ShutdownSignal(r.Context()):
+			// ending the stream lets the client reconnect to another instance of the server before this one exits
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "server-shutting-down", Value: true})
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				_ = telemetry.Error(ctx, span, err, "error writing heartbeat")
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				_ = telemetry.Error(ctx, span, err, "error flushing heartbeat")
+				return
+			}
+		case event, ok := <-podWatch.ResultChan():
+			if !ok {
+				telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "watch-closed", Value: true})
+				return
+			}
+
+			var eventType PodStatusStreamEventType
+			switch event.Type {
+			case watch.Added:
+				eventType = PodStatusStreamEventType_Added
+			case watch.Modified:
+				eventType = PodStatusStreamEventType_Modified
+			case watch.Deleted:
+				eventType = PodStatusStreamEventType_Deleted
+			default:
+				continue
+			}
+
+			pod, ok := event.Object.(*v1.Pod)
+			if !ok || pod == nil {
+				continue
+			}
+
+			if err := writePodStatusEvent(rc, w, eventType, porter_app.PodStatusFromPod(*pod, revisionIDLabelKey)); err != nil {
+				_ = telemetry.Error(ctx, span, err, "error writing pod status event")
+				return
+			}
+		}
+	}
+}
+
+// writePodStatusEvent writes a single server-sent event with a json payload and flushes it to the client
+func writePodStatusEvent(rc *http.ResponseController, w http.ResponseWriter, eventType PodStatusStreamEventType, data any) error {
+	by, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("error marshalling event data: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", eventType, by); err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+
+	return rc.Flush()
+}
@@ -78,6 +78,8 @@ func (c *UpdateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-app")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
@@ -183,8 +185,10 @@ func (c *UpdateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		GitRepoID:           request.GitSource.GitRepoID,
 		PorterYamlPath:      request.PorterYAMLPath,
 		Image:               image,
+		CreatedByUserID:     requestUserID(ctx),
 		PorterAppRepository: c.Repo().PorterApp(),
 	})
+	c.Config().PorterAppNameCache.Invalidate(project.ID, appProto.Name)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "error creating or getting porter app")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
@@ -251,6 +255,13 @@ func (c *UpdateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "resp-app-revision-id", Value: ccpResp.Msg.AppRevisionId})
 
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_Deploy,
+		AppName:            appProto.Name,
+		DeploymentTargetID: deploymentTargetID,
+		AppRevisionID:      ccpResp.Msg.AppRevisionId,
+	})
+
 	response := &UpdateAppResponse{
 		AppRevisionId: ccpResp.Msg.AppRevisionId,
 		AppName:       appProto.Name,
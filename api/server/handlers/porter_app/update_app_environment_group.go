@@ -76,6 +76,8 @@ type UpdateAppEnvironmentResponse struct {
 func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-app-env-group")
 	defer span.End()
+
+	withScopeAttributes(r, span)
 	r = r.Clone(ctx)
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
@@ -86,7 +88,6 @@ func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
 	request := &UpdateAppEnvironmentRequest{}
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
@@ -45,6 +45,8 @@ func (c *UpdateAppRevisionStatusHandler) ServeHTTP(w http.ResponseWriter, r *htt
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-app-revision-status")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 
 	// read the request object from the decoder
@@ -51,6 +51,8 @@ func (c *UpdateImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-image")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 
 	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
@@ -65,7 +67,6 @@ func (c *UpdateImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
 	}
-	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
 
 	request := &UpdateImageRequest{}
 	if ok := c.DecodeAndValidate(w, r, request); !ok {
@@ -88,6 +89,12 @@ func (c *UpdateImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	recordAuditLogEntry(ctx, c.Config(), auditLogEntryInput{
+		Action:             types.AuditLogAction_Deploy,
+		AppName:            appName,
+		DeploymentTargetID: request.DeploymentTargetId,
+	})
+
 	res := &UpdateImageResponse{
 		Repository: ccpResp.Msg.RepositoryUrl,
 		Tag:        ccpResp.Msg.Tag,
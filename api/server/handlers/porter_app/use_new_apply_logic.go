@@ -43,6 +43,8 @@ func (c *UseNewApplyLogicHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-use-new-apply-logic")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
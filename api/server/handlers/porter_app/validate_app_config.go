@@ -0,0 +1,117 @@
+package porter_app
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ValidateAppConfigHandler handles requests to the /apps/{porter_app_name}/validate endpoint
+type ValidateAppConfigHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewValidateAppConfigHandler returns a new ValidateAppConfigHandler
+func NewValidateAppConfigHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ValidateAppConfigHandler {
+	return &ValidateAppConfigHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ValidateAppConfigRequest is the request object for the /apps/{porter_app_name}/validate endpoint
+type ValidateAppConfigRequest struct {
+	// Base64AppProto is the app to validate, in the same format as the /apps/apply endpoint. If the app has no name, the name in the path is used
+	Base64AppProto string `json:"b64_app_proto" form:"required"`
+}
+
+// ValidateAppConfigResponse is the response object for the /apps/{porter_app_name}/validate endpoint
+type ValidateAppConfigResponse struct {
+	// Ok is true if no problems were found
+	Ok bool `json:"ok"`
+	// Problems are the problems found in the app. This is empty if the app is valid
+	Problems []porter_app.ValidationProblem `json:"problems"`
+}
+
+// ServeHTTP validates an app before it is applied, e.g. in the plan step of IaC tooling. Problems with the app are returned in the response
+// rather than as an error. The app is only checked locally: the cluster control plane is not called and no revision is created
+func (c *ValidateAppConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-validate-app-config")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	if !project.GetFeatureFlag(models.ValidateApplyV2, c.Config().LaunchDarklyClient) {
+		err := telemetry.Error(ctx, span, nil, "project does not have validate apply v2 enabled")
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &ValidateAppConfigRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(request.Base64AppProto)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error decoding base yaml")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	appProto := &porterv1.PorterApp{}
+	err = helpers.UnmarshalContractObject(decoded, appProto)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error unmarshalling app proto")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	problems := make([]porter_app.ValidationProblem, 0)
+	switch appProto.Name {
+	case "":
+		appProto.Name = appName
+	case appName:
+	default:
+		problems = append(problems, porter_app.ValidationProblem{
+			Field:   "name",
+			Code:    porter_app.ValidationProblemCode_NameMismatch,
+			Message: fmt.Sprintf("app name %q does not match the app being validated %q", appProto.Name, appName),
+		})
+	}
+
+	problems = append(problems, porter_app.ValidateApp(appProto)...)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "problem-count", Value: len(problems)})
+
+	c.WriteResult(w, r, &ValidateAppConfigResponse{
+		Ok:       len(problems) == 0,
+		Problems: problems,
+	})
+}
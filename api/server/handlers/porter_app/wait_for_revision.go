@@ -0,0 +1,344 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/deployment_target"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	// defaultWaitForRevisionTimeout is used if no timeout is provided in the request
+	defaultWaitForRevisionTimeout = 5 * time.Minute
+	// maxWaitForRevisionTimeout is the longest a client can block waiting for a revision to become ready
+	maxWaitForRevisionTimeout = 15 * time.Minute
+	// waitForRevisionWatchBackoff is how long to wait before re-establishing a pod watch closed by the api server
+	waitForRevisionWatchBackoff = time.Second
+)
+
+// WaitForRevisionHandler handles requests to the /apps/{porter_app_name}/revisions/{app_revision_number}/wait endpoint
+type WaitForRevisionHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewWaitForRevisionHandler returns a new WaitForRevisionHandler
+func NewWaitForRevisionHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WaitForRevisionHandler {
+	return &WaitForRevisionHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// WaitForRevisionRequest is the request body for the /apps/{porter_app_name}/revisions/{app_revision_number}/wait endpoint
+type WaitForRevisionRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// TimeoutSeconds is how long to wait for the revision to become ready. Defaults to 5 minutes, with a maximum of 15 minutes
+	TimeoutSeconds int `json:"timeout_seconds"`
+	// ServiceName restricts the wait to a single long-running service of the revision, e.g. a canary. If empty, all long-running services are waited on
+	ServiceName string `json:"service_name"`
+}
+
+// WaitForRevisionResponse is the response body for the /apps/{porter_app_name}/revisions/{app_revision_number}/wait endpoint
+type WaitForRevisionResponse struct {
+	// Ready is true if every service waited on reached its desired ready replica count before the timeout
+	Ready bool `json:"ready"`
+	// TimedOut is true if the timeout elapsed before the revision became ready
+	TimedOut bool `json:"timed_out"`
+	// ServiceStatus is the last known replica status of each service, keyed by service name
+	ServiceStatus map[string]porter_app.ServiceReplicaStatus `json:"service_status"`
+}
+
+// ServeHTTP blocks until every long-running service in the revision has reached its desired ready replica count with pods of the revision,
+// or the timeout elapses. Pods of the revision are watched, and service status is recomputed whenever a pod changes.
+func (c *WaitForRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-wait-for-revision")
+	defer span.End()
+
+	withScopeAttributes(r, span)
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	revisionNumber, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppRevisionNumber)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app revision number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-number", Value: int(revisionNumber)})
+
+	request := &WaitForRevisionRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTargetID, err := uuid.Parse(request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	if deploymentTargetID == uuid.Nil {
+		err := telemetry.Error(ctx, span, nil, "deployment target id is nil")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID.String()})
+
+	timeout := defaultWaitForRevisionTimeout
+	if request.TimeoutSeconds != 0 {
+		timeout = time.Duration(request.TimeoutSeconds) * time.Second
+	}
+	if timeout <= 0 || timeout > maxWaitForRevisionTimeout {
+		err := telemetry.Error(ctx, span, nil, "timeout must be between 1 second and 15 minutes")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "timeout-seconds", Value: int(timeout.Seconds())})
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if app == nil || app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	var appRevisions []*porterv1.AppRevision
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "wait-for-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		appRevisions, err = porter_app.ListAppRevisions(ccpCtx, porter_app.ListAppRevisionsInput{
+			ProjectID:          project.ID,
+			AppID:              app.ID,
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error listing app revisions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	appRevision, err := porter_app.RevisionByNumber(appRevisions, uint64(revisionNumber))
+	if err != nil {
+		if errors.Is(err, porter_app.ErrRevisionNotFound) {
+			err := telemetry.Error(ctx, span, err, "app revision not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting app revision by number")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-id", Value: appRevision.Id})
+
+	serviceNames := porter_app.LongRunningServiceNames(appRevision.App)
+	if request.ServiceName != "" {
+		var found bool
+		for _, serviceName := range serviceNames {
+			if serviceName == request.ServiceName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			err := telemetry.Error(ctx, span, nil, "service is not a long-running service of the revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+		serviceNames = []string{request.ServiceName}
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "service-name", Value: request.ServiceName})
+
+	var deploymentTarget deployment_target.DeploymentTarget
+	err = withCCPRetry(ctx, span, ccpTimeout(c.Config(), "wait-for-revision", c.Config().ServerConf.CCPRequestTimeout), func(ccpCtx context.Context) error {
+		var err error
+		deploymentTarget, err = deployment_target.DeploymentTargetDetails(ccpCtx, deployment_target.DeploymentTargetDetailsInput{
+			ProjectID:          int64(project.ID),
+			ClusterID:          int64(cluster.ID),
+			DeploymentTargetID: deploymentTargetID.String(),
+			CCPClient:          c.Config().ClusterControlPlaneClient,
+		})
+		return err
+	})
+	if err != nil {
+		if isCCPTimeout(err) {
+			err := telemetry.Error(ctx, span, err, "cluster control plane was unreachable")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusGatewayTimeout))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting deployment target details")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to get agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the server write timeout would otherwise end the request before the wait timeout
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + time.Minute)); err != nil {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "set-write-deadline-error", Value: err.Error()})
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res, err := c.waitForServices(waitCtx, agent, deploymentTarget.Namespace, deploymentTargetID.String(), appName, appRevision.Id, request.ServiceName, serviceNames)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error waiting for revision")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "ready", Value: res.Ready},
+		telemetry.AttributeKV{Key: "timed-out", Value: res.TimedOut},
+	)
+
+	c.WriteResult(w, r, res)
+}
+
+// waitForServices watches the pods of an app revision and returns once every service is ready or ctx is done. Only pods labeled with
+// appRevisionID count towards readiness, so ready pods of the previous revision do not make a revision which is still rolling out ready.
+// The watch is bound to ctx, so it is stopped when the timeout elapses or the client disconnects. If the api server closes the watch before
+// then, it is re-established. If scopeServiceName is set, only the pods of that service are watched.
+func (c *WaitForRevisionHandler) waitForServices(
+	ctx context.Context,
+	agent *kubernetes.Agent,
+	namespace string,
+	deploymentTargetID string,
+	appName string,
+	appRevisionID string,
+	scopeServiceName string,
+	serviceNames []string,
+) (*WaitForRevisionResponse, error) {
+	ctx, span := telemetry.NewSpan(ctx, "wait-for-services")
+	defer span.End()
+
+	res := &WaitForRevisionResponse{
+		ServiceStatus: make(map[string]porter_app.ServiceReplicaStatus),
+	}
+
+	labelPrefix := c.Config().ServerConf.PodLabelPrefix
+	selector := revisionPodSelector(podSelector(labelPrefix, deploymentTargetID, appName, scopeServiceName), labelPrefix, appRevisionID)
+
+	var watchRestarts int
+	for {
+		podsList, err := agent.GetPodsByLabel(selector, namespace)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "unable to get pods by label")
+		}
+
+		podWatch, err := agent.WatchPodsByLabel(ctx, selector, namespace, podsList.ResourceVersion)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "unable to watch pods by label")
+		}
+
+		done, err := c.waitOnWatch(ctx, podWatch, agent, namespace, deploymentTargetID, appName, appRevisionID, serviceNames, res)
+		podWatch.Stop()
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "watch-restarts", Value: watchRestarts})
+			return res, nil
+		}
+
+		// the watch was closed by the api server before the timeout, so it is re-established from the current pod list
+		watchRestarts++
+		select {
+		case <-ctx.Done():
+			res.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "watch-restarts", Value: watchRestarts})
+			return res, nil
+		case <-time.After(waitForRevisionWatchBackoff):
+		}
+	}
+}
+
+// waitOnWatch recomputes the status of the services whenever a pod changes, until every service is ready, ctx is done, or the watch is closed.
+// done is false only if the watch was closed, in which case the caller should re-establish it. res is updated with the last known status
+func (c *WaitForRevisionHandler) waitOnWatch(
+	ctx context.Context,
+	podWatch watch.Interface,
+	agent *kubernetes.Agent,
+	namespace string,
+	deploymentTargetID string,
+	appName string,
+	appRevisionID string,
+	serviceNames []string,
+	res *WaitForRevisionResponse,
+) (done bool, err error) {
+	for {
+		statuses, errs := serviceReplicaStatuses(ctx, agent, namespace, c.Config().ServerConf.PodLabelPrefix, deploymentTargetID, appName, appRevisionID, serviceNames)
+		res.Ready = len(errs) == 0
+		for _, serviceName := range serviceNames {
+			status, ok := statuses[serviceName]
+			if !ok {
+				res.Ready = false
+				continue
+			}
+			res.ServiceStatus[serviceName] = status
+			if !status.IsReady() {
+				res.Ready = false
+			}
+		}
+		if res.Ready {
+			return true, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			res.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+			return true, nil
+		case _, ok := <-podWatch.ResultChan():
+			if !ok {
+				return false, nil
+			}
+		}
+	}
+}
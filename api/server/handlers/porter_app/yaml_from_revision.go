@@ -49,6 +49,8 @@ func (c *PorterYAMLFromRevisionHandler) ServeHTTP(w http.ResponseWriter, r *http
 	ctx, span := telemetry.NewSpan(r.Context(), "serve-porter-yaml-from-revision")
 	defer span.End()
 
+	withScopeAttributes(r, span)
+
 	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 
 	appRevisionID, reqErr := requestutils.GetURLParamString(r, types.URLParamAppRevisionID)
@@ -0,0 +1,67 @@
+package project_webhook
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app/webhooks"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreateProjectWebhookHandler is the handler for POST /api/projects/{project_id}/webhooks
+type CreateProjectWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreateProjectWebhookHandler returns a new CreateProjectWebhookHandler
+func NewCreateProjectWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateProjectWebhookHandler {
+	return &CreateProjectWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP registers a webhook which is notified of deploys, build failures and notifications of apps in the project. The url must resolve to public addresses
+func (c *CreateProjectWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-project-webhook")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateProjectWebhookRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "invalid request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "signing-enabled", Value: request.SigningSecret != ""})
+
+	// webhooks are posted from the server, so they must not be able to reach services inside its network
+	if err := webhooks.ValidateURL(ctx, request.URL); err != nil {
+		err := telemetry.Error(ctx, span, err, "invalid webhook url")
+		c.HandleAPIError(w, r, apierrors.WithErrorCode(apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest), types.APIErrorCode_InvalidRequest))
+		return
+	}
+
+	webhook, err := c.Repo().ProjectWebhook().Insert(ctx, &models.ProjectWebhook{
+		ProjectID:     project.ID,
+		URL:           request.URL,
+		SigningSecret: []byte(request.SigningSecret),
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error creating project webhook")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "webhook-id", Value: webhook.ID.String()})
+
+	c.WriteResult(w, r, webhook.ToProjectWebhookType())
+}
@@ -0,0 +1,76 @@
+package project_webhook
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// DeleteProjectWebhookHandler is the handler for DELETE /api/projects/{project_id}/webhooks/{webhook_id}
+type DeleteProjectWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewDeleteProjectWebhookHandler returns a new DeleteProjectWebhookHandler
+func NewDeleteProjectWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeleteProjectWebhookHandler {
+	return &DeleteProjectWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP deletes a webhook registered on a project
+func (c *DeleteProjectWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-project-webhook")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	webhookIDString, reqErr := requestutils.GetURLParamString(r, types.URLParamWebhookID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "webhook id not found in request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "webhook-id", Value: webhookIDString})
+
+	webhookID, err := uuid.Parse(webhookIDString)
+	if err != nil || webhookID == uuid.Nil {
+		err := telemetry.Error(ctx, span, err, "invalid webhook id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	webhook, err := c.Repo().ProjectWebhook().Get(ctx, project.ID, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "webhook not found")
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(err))
+			return
+		}
+		err := telemetry.Error(ctx, span, err, "error getting project webhook")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if err := c.Repo().ProjectWebhook().Delete(ctx, webhook); err != nil {
+		err := telemetry.Error(ctx, span, err, "error deleting project webhook")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, webhook.ToProjectWebhookType())
+}
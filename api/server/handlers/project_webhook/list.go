@@ -0,0 +1,54 @@
+package project_webhook
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListProjectWebhooksHandler is the handler for GET /api/projects/{project_id}/webhooks
+type ListProjectWebhooksHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListProjectWebhooksHandler returns a new ListProjectWebhooksHandler
+func NewListProjectWebhooksHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListProjectWebhooksHandler {
+	return &ListProjectWebhooksHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP lists the webhooks registered on a project
+func (c *ListProjectWebhooksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-project-webhooks")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	webhooks, err := c.Repo().ProjectWebhook().ListByProjectID(ctx, project.ID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error listing project webhooks")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "webhook-count", Value: len(webhooks)})
+
+	res := types.ListProjectWebhooksResponse{
+		Webhooks: make([]types.ProjectWebhook, 0, len(webhooks)),
+	}
+	for _, webhook := range webhooks {
+		res.Webhooks = append(res.Webhooks, webhook.ToProjectWebhookType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/healthcheck"
 	"github.com/porter-dev/porter/api/server/handlers/metadata"
+	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/handlers/user"
 	"github.com/porter-dev/porter/api/server/handlers/webhook"
@@ -81,6 +82,53 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/healthz -> healthcheck.NewLivezHandler
+	getHealthzEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/healthz",
+			},
+			Quiet: true,
+		},
+	)
+
+	getHealthzHandler := healthcheck.NewLivezHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getHealthzEndpoint,
+		Handler:  getHealthzHandler,
+		Router:   r,
+	})
+
+	// GET /api/openapi.json -> porter_app.NewOpenAPIHandler
+	getOpenAPIEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/openapi.json",
+			},
+		},
+	)
+
+	getOpenAPIHandler := porter_app.NewOpenAPIHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getOpenAPIEndpoint,
+		Handler:  getOpenAPIHandler,
+		Router:   r,
+	})
+
 	// GET /api/metadata -> metadata.NewMetadataGetHandler
 	getMetadataEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -0,0 +1,157 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	corsWildcardOrigin = "*"
+	corsDefaultMaxAge  = 300 * time.Second
+)
+
+var (
+	corsAllowedMethods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+	corsExposedHeaders = []string{"Link", "ETag", "X-Continue-Token", "X-Total-Count", "X-Page", RequestIDHeader}
+)
+
+// ErrCORSWildcardWithCredentials is returned when CORS is configured to allow credentials from any origin, which browsers reject
+var ErrCORSWildcardWithCredentials = errors.New("cors wildcard origin cannot be used when credentials are allowed")
+
+// CORSOptions configures which cross-origin requests are allowed
+type CORSOptions struct {
+	// AllowedOrigins are the origins that may make cross-origin requests. "*" allows any origin
+	AllowedOrigins []string
+	// AllowCredentials allows cross-origin requests to include cookies
+	AllowCredentials bool
+	// ExposedHeaders are response headers which browsers may read, in addition to the headers set by the API such as Link and ETag
+	ExposedHeaders []string
+	// MaxAge is how long browsers may cache the result of a preflight request. Defaults to 5 minutes if zero
+	MaxAge time.Duration
+}
+
+// Validate returns an error if the options are not allowed by the CORS spec
+func (o CORSOptions) Validate() error {
+	if !o.AllowCredentials {
+		return nil
+	}
+
+	for _, origin := range o.AllowedOrigins {
+		if origin == corsWildcardOrigin {
+			return ErrCORSWildcardWithCredentials
+		}
+	}
+
+	return nil
+}
+
+// CORSMiddleware sets CORS headers on responses and answers preflight requests
+type CORSMiddleware struct {
+	allowedOrigins   map[string]bool
+	allowAllOrigins  bool
+	allowCredentials bool
+	exposedHeaders   string
+	maxAgeSeconds    string
+}
+
+// NewCORSMiddleware returns a new CORSMiddleware. If credentials are allowed, a wildcard origin is ignored and only
+// explicitly allowed origins are echoed back, since browsers reject a wildcard origin on credentialed requests.
+func NewCORSMiddleware(opts CORSOptions) *CORSMiddleware {
+	maxAge := opts.MaxAge
+	if maxAge <= 0 {
+		maxAge = corsDefaultMaxAge
+	}
+
+	mw := &CORSMiddleware{
+		allowedOrigins:   make(map[string]bool),
+		allowCredentials: opts.AllowCredentials,
+		exposedHeaders:   strings.Join(corsExposedHeadersWith(opts.ExposedHeaders), ", "),
+		maxAgeSeconds:    strconv.Itoa(int(maxAge.Seconds())),
+	}
+
+	for _, origin := range opts.AllowedOrigins {
+		origin = strings.TrimSuffix(strings.TrimSpace(origin), "/")
+		if origin == "" {
+			continue
+		}
+		if origin == corsWildcardOrigin {
+			mw.allowAllOrigins = !opts.AllowCredentials
+			continue
+		}
+		mw.allowedOrigins[strings.ToLower(origin)] = true
+	}
+
+	return mw
+}
+
+// Middleware sets CORS headers for allowed origins. Preflight requests are answered directly without calling the next handler.
+func (mw *CORSMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		isPreflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+		w.Header().Add("Vary", "Origin")
+
+		if origin == "" || !mw.isAllowedOrigin(origin) {
+			if isPreflight {
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if mw.allowAllOrigins {
+			w.Header().Set("Access-Control-Allow-Origin", corsWildcardOrigin)
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+		}
+		if mw.allowCredentials {
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if isPreflight {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(corsAllowedMethods, ", "))
+			if requestHeaders := r.Header.Get("Access-Control-Request-Headers"); requestHeaders != "" {
+				w.Header().Set("Access-Control-Allow-Headers", requestHeaders)
+			}
+			w.Header().Set("Access-Control-Max-Age", mw.maxAgeSeconds)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Access-Control-Expose-Headers", mw.exposedHeaders)
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (mw *CORSMiddleware) isAllowedOrigin(origin string) bool {
+	if mw.allowAllOrigins {
+		return true
+	}
+	return mw.allowedOrigins[strings.ToLower(origin)]
+}
+
+// corsExposedHeadersWith returns the default exposed headers followed by the given headers, skipping empty and duplicate headers
+func corsExposedHeadersWith(headers []string) []string {
+	exposed := make([]string, 0, len(corsExposedHeaders)+len(headers))
+	seen := make(map[string]bool)
+
+	for _, header := range append(append([]string{}, corsExposedHeaders...), headers...) {
+		header = strings.TrimSpace(header)
+		canonical := http.CanonicalHeaderKey(header)
+		if header == "" || seen[canonical] {
+			continue
+		}
+		seen[canonical] = true
+		exposed = append(exposed, header)
+	}
+
+	return exposed
+}
@@ -0,0 +1,120 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+type corsTest struct {
+	description         string
+	opts                middleware.CORSOptions
+	method              string
+	origin              string
+	expAllowOrigin      string
+	expAllowCredentials string
+	expNextCalled       bool
+}
+
+var corsTests = []corsTest{
+	{
+		description:    "wildcard origin without credentials allows any origin",
+		opts:           middleware.CORSOptions{AllowedOrigins: []string{"*"}},
+		method:         http.MethodGet,
+		origin:         "https://dashboard.example.com",
+		expAllowOrigin: "*",
+		expNextCalled:  true,
+	},
+	{
+		description:         "credentials echo allowed origin",
+		opts:                middleware.CORSOptions{AllowedOrigins: []string{"https://dashboard.example.com"}, AllowCredentials: true},
+		method:              http.MethodGet,
+		origin:              "https://dashboard.example.com",
+		expAllowOrigin:      "https://dashboard.example.com",
+		expAllowCredentials: "true",
+		expNextCalled:       true,
+	},
+	{
+		description:   "credentials ignore wildcard origin",
+		opts:          middleware.CORSOptions{AllowedOrigins: []string{"*", "https://dashboard.example.com"}, AllowCredentials: true},
+		method:        http.MethodGet,
+		origin:        "https://evil.example.com",
+		expNextCalled: true,
+	},
+	{
+		description:         "preflight is answered without calling next handler",
+		opts:                middleware.CORSOptions{AllowedOrigins: []string{"https://dashboard.example.com"}, AllowCredentials: true},
+		method:              http.MethodOptions,
+		origin:              "https://dashboard.example.com",
+		expAllowOrigin:      "https://dashboard.example.com",
+		expAllowCredentials: "true",
+		expNextCalled:       false,
+	},
+}
+
+func TestCORSMiddleware(t *testing.T) {
+	for _, tc := range corsTests {
+		t.Run(tc.description, func(t *testing.T) {
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+
+			req := httptest.NewRequest(tc.method, "/api/projects", nil)
+			req.Header.Set("Origin", tc.origin)
+			if tc.method == http.MethodOptions {
+				req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			rr := httptest.NewRecorder()
+
+			middleware.NewCORSMiddleware(tc.opts).Middleware(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expAllowOrigin, rr.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, tc.expAllowCredentials, rr.Header().Get("Access-Control-Allow-Credentials"))
+			assert.Equal(t, tc.expNextCalled, nextCalled)
+		})
+	}
+}
+
+func TestCORSOptionsValidate(t *testing.T) {
+	assert.NoError(t, middleware.CORSOptions{AllowedOrigins: []string{"*"}}.Validate())
+	assert.ErrorIs(t, middleware.CORSOptions{AllowedOrigins: []string{"*"}, AllowCredentials: true}.Validate(), middleware.ErrCORSWildcardWithCredentials)
+}
+
+func TestCORSMiddlewareExposedHeadersAndMaxAge(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	defaults := middleware.NewCORSMiddleware(middleware.CORSOptions{AllowedOrigins: []string{"*"}}).Middleware(next)
+	configured := middleware.NewCORSMiddleware(middleware.CORSOptions{
+		AllowedOrigins: []string{"*"},
+		ExposedHeaders: []string{"X-Total-Count", "Retry-After", "x-request-id"},
+		MaxAge:         time.Hour,
+	}).Middleware(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+
+	rr := httptest.NewRecorder()
+	defaults.ServeHTTP(rr, req)
+	assert.Equal(t, "Link, ETag, X-Continue-Token, X-Total-Count, X-Page, X-Request-ID", rr.Header().Get("Access-Control-Expose-Headers"))
+
+	rr = httptest.NewRecorder()
+	configured.ServeHTTP(rr, req)
+	assert.Equal(t, "Link, ETag, X-Continue-Token, X-Total-Count, X-Page, X-Request-ID, Retry-After", rr.Header().Get("Access-Control-Expose-Headers"))
+
+	preflight := httptest.NewRequest(http.MethodOptions, "/api/projects", nil)
+	preflight.Header.Set("Origin", "https://dashboard.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	rr = httptest.NewRecorder()
+	defaults.ServeHTTP(rr, preflight)
+	assert.Equal(t, "300", rr.Header().Get("Access-Control-Max-Age"))
+
+	rr = httptest.NewRecorder()
+	configured.ServeHTTP(rr, preflight)
+	assert.Equal(t, "3600", rr.Header().Get("Access-Control-Max-Age"))
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+)
+
+// MaxBodySizeMiddleware limits the size of request bodies
+type MaxBodySizeMiddleware struct {
+	config   *config.Config
+	maxBytes int64
+}
+
+// NewMaxBodySizeMiddleware returns a new MaxBodySizeMiddleware which rejects request bodies larger than maxBytes
+func NewMaxBodySizeMiddleware(config *config.Config, maxBytes int64) *MaxBodySizeMiddleware {
+	return &MaxBodySizeMiddleware{
+		config:   config,
+		maxBytes: maxBytes,
+	}
+}
+
+// Middleware rejects requests with a 413 if their declared content length is too large. Otherwise, the body is wrapped
+// so that reading past the limit fails, which the request decoder reports as a 413.
+func (mw *MaxBodySizeMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > mw.maxBytes {
+			apierrors.HandleAPIError(
+				mw.config.Logger,
+				mw.config.Alerter,
+				w, r,
+				apierrors.NewErrPassThroughToClient(fmt.Errorf("request body must not be larger than %d bytes", mw.maxBytes), http.StatusRequestEntityTooLarge),
+				true,
+			)
+			return
+		}
+
+		if r.Body != nil {
+			r.Body = http.MaxBytesReader(w, r.Body, mw.maxBytes)
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
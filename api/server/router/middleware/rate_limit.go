@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterIdleTTL is how long a client's limiter is kept after its last request
+const rateLimiterIdleTTL = 10 * time.Minute
+
+type rateLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// RateLimitMiddleware limits the rate of requests from each client using a token bucket. Requests authenticated with an
+// api token are keyed by token, other authenticated requests by user id, and unauthenticated requests by remote ip. Limiter state is kept in memory, so limits apply per server instance.
+type RateLimitMiddleware struct {
+	config *config.Config
+	limit  rate.Limit
+	burst  int
+
+	mu        sync.Mutex
+	limiters  map[string]*rateLimiterEntry
+	lastSweep time.Time
+}
+
+// NewRateLimitMiddleware returns a new RateLimitMiddleware which allows requestsPerSecond requests per second per client,
+// with bursts of up to burst requests
+func NewRateLimitMiddleware(config *config.Config, requestsPerSecond float64, burst int) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		config:    config,
+		limit:     rate.Limit(requestsPerSecond),
+		burst:     burst,
+		limiters:  make(map[string]*rateLimiterEntry),
+		lastSweep: time.Now(),
+	}
+}
+
+// Middleware rejects requests over the client's rate limit with a 429 and a Retry-After header.
+// This must be added after authentication so that the user can be read from the request context.
+func (mw *RateLimitMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reservation := mw.limiterForKey(rateLimitKey(r)).Reserve()
+
+		if delay := reservation.Delay(); delay > 0 {
+			reservation.Cancel()
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			apierrors.HandleAPIError(
+				mw.config.Logger,
+				mw.config.Alerter,
+				w, r,
+				apierrors.NewErrPassThroughToClient(fmt.Errorf("rate limit exceeded, retry in %s", delay.Round(time.Second)), http.StatusTooManyRequests),
+				true,
+			)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (mw *RateLimitMiddleware) limiterForKey(key string) *rate.Limiter {
+	mw.mu.Lock()
+	defer mw.mu.Unlock()
+
+	now := time.Now()
+
+	// periodically drop limiters for clients which have not made a request recently, so that the map does not grow unbounded
+	if now.Sub(mw.lastSweep) > rateLimiterIdleTTL {
+		for k, entry := range mw.limiters {
+			if now.Sub(entry.lastSeen) > rateLimiterIdleTTL {
+				delete(mw.limiters, k)
+			}
+		}
+		mw.lastSweep = now
+	}
+
+	entry, ok := mw.limiters[key]
+	if !ok {
+		entry = &rateLimiterEntry{
+			limiter: rate.NewLimiter(mw.limit, mw.burst),
+		}
+		mw.limiters[key] = entry
+	}
+	entry.lastSeen = now
+
+	return entry.limiter
+}
+
+// rateLimitKey returns the project and token id for requests authenticated with an api token, the user id for other
+// authenticated requests, and the remote ip otherwise
+func rateLimitKey(r *http.Request) string {
+	// api token requests carry a placeholder user with id 0, so they are keyed by token to avoid sharing one limiter
+	if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok && apiToken != nil {
+		return fmt.Sprintf("token:%d:%s", apiToken.ProjectID, apiToken.UniqueID)
+	}
+
+	if user, ok := r.Context().Value(types.UserScope).(*models.User); ok && user != nil {
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return fmt.Sprintf("ip:%s", host)
+}
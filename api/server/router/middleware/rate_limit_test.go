@@ -0,0 +1,79 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newRateLimitedHandler() http.Handler {
+	conf := &config.Config{
+		Logger:  logger.New(false, os.Stdout),
+		Alerter: alerter.NoOpAlerter{},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// a very low rate ensures that the second request within a burst of one is always rejected
+	return middleware.NewRateLimitMiddleware(conf, 0.01, 1).Middleware(next)
+}
+
+func requestWithContext(ctx context.Context) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	return req.WithContext(ctx)
+}
+
+func apiTokenContext(projectID uint, tokenID string) context.Context {
+	ctx := context.WithValue(context.Background(), "api_token", &models.APIToken{ProjectID: projectID, UniqueID: tokenID})
+	return context.WithValue(ctx, types.UserScope, &models.User{})
+}
+
+func TestRateLimitMiddlewareRejectsOverLimit(t *testing.T) {
+	handler := newRateLimitedHandler()
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithContext(context.Background()))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithContext(context.Background()))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.NotEmpty(t, rr.Header().Get("Retry-After"))
+}
+
+func TestRateLimitMiddlewareIsolatesKeys(t *testing.T) {
+	handler := newRateLimitedHandler()
+
+	contexts := []context.Context{
+		apiTokenContext(1, "token-a"),
+		apiTokenContext(1, "token-b"),
+		apiTokenContext(2, "token-a"),
+		context.WithValue(context.Background(), types.UserScope, &models.User{Model: gorm.Model{ID: 1}}),
+		context.WithValue(context.Background(), types.UserScope, &models.User{Model: gorm.Model{ID: 2}}),
+	}
+
+	for _, ctx := range contexts {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, requestWithContext(ctx))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	// each key has used up its burst, so a repeated request is rejected without affecting the others
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, requestWithContext(apiTokenContext(1, "token-a")))
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+}
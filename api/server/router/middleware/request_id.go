@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RequestIDHeader is the header used to read and return the request id
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID attaches a request id to the request context and the response headers, so that a request reported by a user
+// can be correlated with its traces. If the client supplies a valid UUID in the X-Request-ID header, it is used unchanged;
+// otherwise a new id is generated.
+// This should be added before any middleware that creates spans, so that those spans are tagged with the request id.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if _, err := uuid.Parse(requestID); err != nil {
+			requestID = uuid.New().String()
+		}
+
+		w.Header().Set(RequestIDHeader, requestID)
+
+		ctx := telemetry.WithRequestID(r.Context(), requestID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
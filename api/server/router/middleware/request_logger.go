@@ -7,16 +7,22 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/pkg/logger"
 )
 
 type requestLoggerResponseWriter struct {
 	http.ResponseWriter
 	statusCode int
+	// streamed is set if the response was flushed or the connection hijacked, e.g. for server-sent events or websockets,
+	// in which case the latency is the lifetime of the stream rather than the time taken to respond
+	streamed bool
 }
 
 func newRequestLoggerResponseWriter(w http.ResponseWriter) *requestLoggerResponseWriter {
-	return &requestLoggerResponseWriter{w, http.StatusOK}
+	return &requestLoggerResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 }
 
 func (rw *requestLoggerResponseWriter) WriteHeader(code int) {
@@ -29,15 +35,36 @@ func (rw *requestLoggerResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, er
 	if !ok {
 		return nil, nil, errors.New("ResponseWriter Interface does not support hijacking")
 	}
+	rw.streamed = true
 	return h.Hijack()
 }
 
+// Flush sends any buffered data to the client, which is required for streaming responses
+func (rw *requestLoggerResponseWriter) Flush() {
+	rw.streamed = true
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying response writer so that it can be used with http.ResponseController
+func (rw *requestLoggerResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 type RequestLoggerMiddleware struct {
 	logger *logger.Logger
+	// slowRequestThreshold is the latency above which requests are additionally logged as a warning. Zero disables slow request logging
+	slowRequestThreshold time.Duration
 }
 
-func NewRequestLoggerMiddleware(logger *logger.Logger) *RequestLoggerMiddleware {
-	return &RequestLoggerMiddleware{logger}
+// NewRequestLoggerMiddleware returns a new RequestLoggerMiddleware which logs every request, and logs a warning
+// for requests which take longer than slowRequestThreshold. Streamed responses are never logged as slow
+func NewRequestLoggerMiddleware(logger *logger.Logger, slowRequestThreshold time.Duration) *RequestLoggerMiddleware {
+	return &RequestLoggerMiddleware{
+		logger:               logger,
+		slowRequestThreshold: slowRequestThreshold,
+	}
 }
 
 func (mw *RequestLoggerMiddleware) Middleware(next http.Handler) http.Handler {
@@ -55,5 +82,33 @@ func (mw *RequestLoggerMiddleware) Middleware(next http.Handler) http.Handler {
 		logger.AddLoggingRequestMeta(r, event)
 
 		event.Send()
+
+		if mw.slowRequestThreshold > 0 && latency > mw.slowRequestThreshold && !rw.streamed {
+			mw.logSlowRequest(r, rw.statusCode, latency)
+		}
 	})
 }
+
+// logSlowRequest logs a warning with the matched route pattern, so that slow requests can be grouped by endpoint
+func (mw *RequestLoggerMiddleware) logSlowRequest(r *http.Request, status int, latency time.Duration) {
+	event := mw.logger.Warn().
+		Bool("slow_request", true).
+		Dur("latency", latency).
+		Dur("threshold", mw.slowRequestThreshold).
+		Int("status", status).
+		Str("method", r.Method)
+
+	if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+		event.Str("route", routeCtx.RoutePattern())
+	}
+
+	if project, ok := r.Context().Value(types.ProjectScope).(*models.Project); ok && project != nil {
+		event.Uint("project_id", project.ID)
+	}
+
+	if cluster, ok := r.Context().Value(types.ClusterScope).(*models.Cluster); ok && cluster != nil {
+		event.Uint("cluster_id", cluster.ID)
+	}
+
+	event.Msg("slow request")
+}
@@ -0,0 +1,46 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/porter-dev/porter/pkg/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// serveLoggedRequest serves a request which takes longer than the slow request threshold, and returns what was logged
+func serveLoggedRequest(t *testing.T, next http.HandlerFunc) string {
+	logFile, err := os.CreateTemp(t.TempDir(), "request-logger")
+	assert.NoError(t, err)
+	defer logFile.Close()
+
+	handler := middleware.NewRequestLoggerMiddleware(logger.New(false, logFile), time.Millisecond).Middleware(next)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+
+	logged, err := os.ReadFile(logFile.Name())
+	assert.NoError(t, err)
+	return string(logged)
+}
+
+func TestRequestLoggerMiddlewareLogsSlowRequest(t *testing.T) {
+	logged := serveLoggedRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	assert.Contains(t, logged, "slow request")
+}
+
+func TestRequestLoggerMiddlewareSkipsStreamedResponse(t *testing.T) {
+	logged := serveLoggedRequest(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		assert.NoError(t, http.NewResponseController(w).Flush())
+		time.Sleep(5 * time.Millisecond)
+	})
+
+	assert.NotContains(t, logged, "slow request")
+}
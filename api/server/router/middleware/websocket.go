@@ -4,13 +4,19 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
+	gorillaws "github.com/gorilla/websocket"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/api/types"
 )
 
+// websocketCloseTimeout is how long to wait to send a close frame to a client when the server is shutting down
+const websocketCloseTimeout = time.Second
+
 type WebsocketMiddleware struct {
 	config *config.Config
 }
@@ -35,6 +41,22 @@ func (wm *WebsocketMiddleware) Middleware(next http.Handler) http.Handler {
 		w = newRW
 		defer conn.Close()
 
+		// websocket connections are hijacked, so the server does not wait for them when shutting down. Instead, clients are sent a
+		// close frame so that they can reconnect to another instance of the server
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			select {
+			case <-requestutils.ShutdownSignal(r.Context()):
+				_ = conn.WriteControl(
+					gorillaws.CloseMessage,
+					gorillaws.FormatCloseMessage(gorillaws.CloseGoingAway, "server is shutting down"),
+					time.Now().Add(websocketCloseTimeout),
+				)
+			case <-done:
+			}
+		}()
+
 		ctx := r.Context()
 		ctx = context.WithValue(ctx, types.RequestCtxWebsocketKey, safeRW)
 
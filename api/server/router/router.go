@@ -58,14 +58,28 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	userRegisterer := NewUserScopedRegisterer(projRegisterer, statusRegisterer)
 	panicMW := middleware.NewPanicMiddleware(config)
 
+	if len(config.ServerConf.CORSAllowedOrigins) > 0 {
+		corsOpts := middleware.CORSOptions{
+			AllowedOrigins:   config.ServerConf.CORSAllowedOrigins,
+			AllowCredentials: config.ServerConf.CORSAllowCredentials,
+			ExposedHeaders:   config.ServerConf.CORSExposedHeaders,
+			MaxAge:           config.ServerConf.CORSMaxAge,
+		}
+		if err := corsOpts.Validate(); err != nil {
+			config.Logger.Warn().Err(err).Msg("ignoring wildcard cors origin, only explicitly allowed origins will be accepted")
+		}
+		r.Use(middleware.NewCORSMiddleware(corsOpts).Middleware)
+	}
+
 	if config.ServerConf.PprofEnabled {
 		r.Mount("/debug", chiMiddleware.Profiler())
 	}
 
 	r.Route("/api", func(r chi.Router) {
 		r.Use(
+			middleware.RequestID,
 			otelchi.Middleware("porter-server-middleware", otelchi.WithRequestMethodInSpanName(true), otelchi.WithChiRoutes(r), otelchi.WithFilter(func(r *http.Request) bool {
-				if strings.HasSuffix(r.URL.Path, "/livez") || strings.HasSuffix(r.URL.Path, "/readyz") {
+				if strings.HasSuffix(r.URL.Path, "/livez") || strings.HasSuffix(r.URL.Path, "/healthz") || strings.HasSuffix(r.URL.Path, "/readyz") {
 					return false
 				}
 				return true
@@ -118,8 +132,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(
+			middleware.RequestID,
 			otelchi.Middleware("porter-server-middleware", otelchi.WithRequestMethodInSpanName(true), otelchi.WithChiRoutes(r), otelchi.WithFilter(func(r *http.Request) bool {
-				if strings.HasSuffix(r.URL.Path, "/livez") || strings.HasSuffix(r.URL.Path, "/readyz") {
+				if strings.HasSuffix(r.URL.Path, "/livez") || strings.HasSuffix(r.URL.Path, "/healthz") || strings.HasSuffix(r.URL.Path, "/readyz") {
 					return false
 				}
 				return true
@@ -237,7 +252,9 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 	policyDocLoader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.Policy())
 
 	// set up logging middleware to log information about the request
-	loggerMw := middleware.NewRequestLoggerMiddleware(config.Logger)
+	loggerMw := middleware.NewRequestLoggerMiddleware(config.Logger, config.ServerConf.SlowRequestThreshold)
+	// long-lived endpoints are logged without slow request warnings, since their latency is expected
+	longLivedLoggerMw := middleware.NewRequestLoggerMiddleware(config.Logger, 0)
 
 	// websocket middleware for upgrading requests
 	websocketMw := middleware.NewWebsocketMiddleware(config)
@@ -250,9 +267,18 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 
 	apiContractRevisionFactory := authz.NewAPIContractRevisionScopedFactory(config)
 
+	// rate limiting middleware, keyed by user for authenticated routes and remote ip otherwise
+	var rateLimitMw *middleware.RateLimitMiddleware
+	if config.ServerConf.RateLimitEnabled {
+		rateLimitMw = middleware.NewRateLimitMiddleware(config, config.ServerConf.RateLimitRequestsPerSecond, config.ServerConf.RateLimitBurst)
+	}
+
 	for _, route := range routes {
 		atomicGroup := route.Router.Group(nil)
 
+		// health checks are quiet and should never be rate limited
+		shouldRateLimit := rateLimitMw != nil && !route.Endpoint.Metadata.Quiet
+
 		for _, scope := range route.Endpoint.Metadata.Scopes {
 			switch scope {
 			case types.UserScope:
@@ -262,6 +288,12 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 				} else {
 					atomicGroup.Use(authNFactory.NewAuthenticated)
 				}
+
+				// requests are throttled as soon as the caller is known, before the policy and scoped resources are loaded
+				if shouldRateLimit {
+					atomicGroup.Use(rateLimitMw.Middleware)
+					shouldRateLimit = false
+				}
 			case types.ProjectScope:
 				policyFactory := authz.NewPolicyMiddleware(config, *route.Endpoint.Metadata, policyDocLoader)
 
@@ -296,14 +328,31 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 			}
 		}
 
+		// routes without authn are rate limited by remote ip
+		if shouldRateLimit {
+			atomicGroup.Use(rateLimitMw.Middleware)
+		}
+
 		if !route.Endpoint.Metadata.Quiet {
-			atomicGroup.Use(loggerMw.Middleware)
+			if route.Endpoint.Metadata.LongLived || route.Endpoint.Metadata.IsWebsocket {
+				atomicGroup.Use(longLivedLoggerMw.Middleware)
+			} else {
+				atomicGroup.Use(loggerMw.Middleware)
+			}
 		}
 
 		if route.Endpoint.Metadata.IsWebsocket {
 			atomicGroup.Use(websocketMw.Middleware)
 		}
 
+		maxRequestBodyBytes := config.ServerConf.MaxRequestBodyBytes
+		if route.Endpoint.Metadata.MaxRequestBodyBytes != 0 {
+			maxRequestBodyBytes = route.Endpoint.Metadata.MaxRequestBodyBytes
+		}
+		if maxRequestBodyBytes > 0 {
+			atomicGroup.Use(middleware.NewMaxBodySizeMiddleware(config, maxRequestBodyBytes).Middleware)
+		}
+
 		if route.Endpoint.Metadata.CheckUsage && config.ServerConf.UsageTrackingEnabled {
 			usageMW := middleware.NewUsageMiddleware(config, route.Endpoint.Metadata.UsageMetric)
 			atomicGroup.Use(usageMW.Middleware)
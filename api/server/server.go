@@ -3,10 +3,12 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 )
 
 // PorterAPIServer contains the routing and configuration options for starting the PorterAPIServer
@@ -19,29 +21,32 @@ type PorterAPIServer struct {
 	ServerConf *env.ServerConf
 }
 
-// ListenAndServe starts the Porter API server
+// ListenAndServe starts the Porter API server. When the context is canceled, the server stops accepting new connections and waits up to
+// the configured shutdown grace period for in-flight requests to finish
 func (p PorterAPIServer) ListenAndServe(ctx context.Context) error {
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
-
 	address := fmt.Sprintf(":%d", p.Port)
 
+	// shuttingDown is closed once shutdown begins, so that streaming requests which would otherwise never finish can end cleanly
+	shuttingDown := make(chan struct{})
+
 	srv := &http.Server{
 		Addr:         address,
 		Handler:      p.Router,
 		ReadTimeout:  p.ServerConf.TimeoutRead,
 		WriteTimeout: p.ServerConf.TimeoutWrite,
 		IdleTimeout:  p.ServerConf.TimeoutIdle,
+		BaseContext: func(net.Listener) context.Context {
+			return requestutils.WithShutdownSignal(context.Background(), shuttingDown)
+		},
 	}
-	defer srv.Shutdown(ctx) // nolint:errcheck
+	srv.RegisterOnShutdown(func() {
+		close(shuttingDown)
+	})
 
-	errChan := make(chan error)
+	errChan := make(chan error, 1)
 
 	go func() {
-		err := srv.ListenAndServe()
-		if err != nil {
-			errChan <- err
-		}
+		errChan <- srv.ListenAndServe()
 	}()
 
 	select {
@@ -50,5 +55,15 @@ func (p PorterAPIServer) ListenAndServe(ctx context.Context) error {
 	case <-ctx.Done():
 	}
 
+	// ctx is already canceled, so in-flight requests are drained with a separate context bounded by the grace period
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), p.ServerConf.ShutdownGracePeriod)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		// requests still running after the grace period are cut off
+		_ = srv.Close()
+		return fmt.Errorf("error draining in-flight requests: %w", err)
+	}
+
 	return nil
 }
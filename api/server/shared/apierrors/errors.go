@@ -117,6 +117,51 @@ func (e *ErrNotFound) GetStatusCode() int {
 	return http.StatusNotFound
 }
 
+// ErrWithCode attaches a machine-readable error code to a RequestError, which is written to the
+// error_code field of the error response
+type ErrWithCode struct {
+	RequestError
+	code types.APIErrorCode
+}
+
+// WithErrorCode returns err with the given error code attached
+func WithErrorCode(err RequestError, code types.APIErrorCode) RequestError {
+	return &ErrWithCode{err, code}
+}
+
+// ErrorCode returns the machine-readable error code
+func (e *ErrWithCode) ErrorCode() types.APIErrorCode {
+	return e.code
+}
+
+// ErrWithFieldErrors attaches the invalid request fields to a RequestError, which are written to the
+// field_errors field of the error response
+type ErrWithFieldErrors struct {
+	RequestError
+	fieldErrors []types.FieldError
+}
+
+// WithFieldErrors returns err with the given field errors attached
+func WithFieldErrors(err RequestError, fieldErrors []types.FieldError) RequestError {
+	return &ErrWithFieldErrors{err, fieldErrors}
+}
+
+// FieldErrors returns the invalid request fields
+func (e *ErrWithFieldErrors) FieldErrors() []types.FieldError {
+	return e.fieldErrors
+}
+
+// fieldErrorsFromRequestError returns the field errors attached to err, which may also have an error code attached
+func fieldErrorsFromRequestError(err RequestError) []types.FieldError {
+	if codedErr, ok := err.(*ErrWithCode); ok {
+		err = codedErr.RequestError
+	}
+	if fieldErr, ok := err.(*ErrWithFieldErrors); ok {
+		return fieldErr.FieldErrors()
+	}
+	return nil
+}
+
 type ErrorOpts struct {
 	Code uint
 }
@@ -137,6 +182,10 @@ func HandleAPIError(
 		Str("internal_error", err.InternalError()).
 		Str("external_error", extErrorStr)
 
+	if codedErr, ok := err.(*ErrWithCode); ok {
+		event = event.Str("error_code", string(codedErr.ErrorCode()))
+	}
+
 	data := logger.AddLoggingContextScopes(r.Context(), event)
 	logger.AddLoggingRequestMeta(r, event)
 
@@ -164,6 +213,12 @@ func HandleAPIError(
 			resp.Code = opts[0].Code
 		}
 
+		if codedErr, ok := err.(*ErrWithCode); ok {
+			resp.ErrorCode = codedErr.ErrorCode()
+		}
+
+		resp.FieldErrors = fieldErrorsFromRequestError(err)
+
 		// write the status code
 		w.WriteHeader(err.GetStatusCode())
 
@@ -15,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/internal/nats"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/porter_app/namecache"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/internal/telemetry"
@@ -82,6 +83,9 @@ type Config struct {
 	// URLCache contains a cache of chart names to chart repos
 	URLCache *urlcache.ChartURLCache
 
+	// PorterAppNameCache contains a short-lived cache of porter apps keyed by project id and app name
+	PorterAppNameCache *namecache.Cache
+
 	// ProvisionerClient is an authenticated client for the provisioner service
 	ProvisionerClient *client.Client
 
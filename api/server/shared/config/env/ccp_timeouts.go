@@ -0,0 +1,41 @@
+package env
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CCPHandlerTimeouts are cluster control plane call timeouts keyed by handler name, e.g. latest-app-revision.
+// It is decoded from a semicolon-separated list of name=duration pairs, e.g. "latest-app-revision=5s;wait-for-revision=30s"
+type CCPHandlerTimeouts map[string]time.Duration
+
+// Decode parses a semicolon-separated list of name=duration pairs
+func (t *CCPHandlerTimeouts) Decode(value string) error {
+	timeouts := make(CCPHandlerTimeouts)
+
+	for _, pair := range strings.Split(value, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		name, rawTimeout, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return fmt.Errorf("ccp handler timeout %q must be in the form name=duration", pair)
+		}
+
+		timeout, err := time.ParseDuration(strings.TrimSpace(rawTimeout))
+		if err != nil {
+			return fmt.Errorf("error parsing ccp handler timeout for %s: %w", name, err)
+		}
+		if timeout < 0 {
+			return fmt.Errorf("ccp handler timeout for %s must not be negative", name)
+		}
+
+		timeouts[strings.TrimSpace(name)] = timeout
+	}
+
+	*t = timeouts
+	return nil
+}
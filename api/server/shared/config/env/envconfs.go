@@ -106,6 +106,10 @@ type ServerConf struct {
 	AdminEmail  string `env:"ADMIN_EMAIL"`
 	AdminUserId string `env:"ADMIN_USER_ID"`
 
+	// ImpersonationAdminUsers is a semicolon-separated list of ids of users who may use the X-Impersonate-Project and X-Impersonate-Cluster
+	// headers to view projects they are not a member of, e.g. for support engineers debugging a customer's app
+	ImpersonationAdminUsers []uint `env:"IMPERSONATION_ADMIN_USERS"`
+
 	SentryDSN string `env:"SENTRY_DSN"`
 	SentryEnv string `env:"SENTRY_ENV,default=dev"`
 
@@ -116,6 +120,50 @@ type ServerConf struct {
 	// Token for internal retool to authenticate to internal API endpoints
 	RetoolToken string `env:"RETOOL_TOKEN"`
 
+	// CORSAllowedOrigins is a semicolon-separated list of origins that may make cross-origin requests to the API.
+	// If empty, CORS headers are not set. A wildcard origin is not allowed when CORSAllowCredentials is true.
+	CORSAllowedOrigins []string `env:"CORS_ALLOWED_ORIGINS"`
+	// CORSAllowCredentials allows cross-origin requests to include cookies
+	CORSAllowCredentials bool `env:"CORS_ALLOW_CREDENTIALS,default=false"`
+	// CORSExposedHeaders is a semicolon-separated list of response headers which browsers may read, in addition to the headers set by the API
+	// such as Link, ETag, X-Total-Count and X-Request-ID, e.g. "Retry-After;X-RateLimit-Remaining"
+	CORSExposedHeaders []string `env:"CORS_EXPOSED_HEADERS"`
+	// CORSMaxAge is how long browsers may cache the result of a preflight request
+	CORSMaxAge time.Duration `env:"CORS_MAX_AGE,default=5m"`
+
+	// RateLimitEnabled enables per-client rate limiting of API requests. Health check endpoints are not rate limited
+	RateLimitEnabled bool `env:"RATE_LIMIT_ENABLED,default=false"`
+	// RateLimitRequestsPerSecond is the sustained number of requests per second allowed for each user, or each remote ip for unauthenticated requests
+	RateLimitRequestsPerSecond float64 `env:"RATE_LIMIT_REQUESTS_PER_SECOND,default=10"`
+	// RateLimitBurst is the maximum number of requests a client can make at once before being limited
+	RateLimitBurst int `env:"RATE_LIMIT_BURST,default=50"`
+
+	// MaxRequestBodyBytes is the maximum size of a request body. Larger requests are rejected with a 413. Endpoints may override this limit
+	MaxRequestBodyBytes int64 `env:"MAX_REQUEST_BODY_BYTES,default=5242880"`
+
+	// CCPRequestTimeout is the timeout for cluster control plane calls made by interactive endpoints, e.g. those polled by the dashboard. Set to 0 to disable
+	CCPRequestTimeout time.Duration `env:"CCP_REQUEST_TIMEOUT,default=5s"`
+	// CCPBulkRequestTimeout is the timeout for cluster control plane calls made by endpoints which operate on many apps at once. Set to 0 to disable
+	CCPBulkRequestTimeout time.Duration `env:"CCP_BULK_REQUEST_TIMEOUT,default=30s"`
+	// CCPHandlerTimeouts overrides CCPRequestTimeout or CCPBulkRequestTimeout for individual handlers, as a semicolon-separated list of
+	// handler=duration pairs, e.g. "latest-app-revision=3s;rollback-to-revision=20s". A duration of 0 disables the timeout for the handler
+	CCPHandlerTimeouts CCPHandlerTimeouts `env:"CCP_HANDLER_TIMEOUTS"`
+	// CCPBatchConcurrency is the maximum number of concurrent cluster control plane calls made by each request to a batch endpoint
+	CCPBatchConcurrency int `env:"CCP_BATCH_CONCURRENCY,default=8"`
+
+	// PodLabelPrefix is the prefix of the app-name, service-name and deployment-target-id labels used to select the pods of an app,
+	// for self-hosted installs which relabel resources
+	PodLabelPrefix string `env:"POD_LABEL_PREFIX,default=porter.run"`
+
+	// ShutdownGracePeriod is how long the server waits for in-flight requests to finish when shutting down before closing their connections
+	ShutdownGracePeriod time.Duration `env:"SERVER_SHUTDOWN_GRACE_PERIOD,default=25s"`
+
+	// SlowRequestThreshold is the latency above which requests are logged as a warning with their route. Set to 0 to disable
+	SlowRequestThreshold time.Duration `env:"SLOW_REQUEST_THRESHOLD,default=2s"`
+
+	// PorterAppNameCacheTTL is how long porter app name lookups are cached in memory. Set to 0 to disable caching
+	PorterAppNameCacheTTL time.Duration `env:"PORTER_APP_NAME_CACHE_TTL,default=10s"`
+
 	// Enable pprof profiling endpoints
 	PprofEnabled    bool `env:"PPROF_ENABLED,default=false"`
 	ProvisionerTest bool `env:"PROVISIONER_TEST,default=false"`
@@ -148,6 +196,8 @@ type ServerConf struct {
 	TelemetryName string `env:"TELEMETRY_NAME"`
 	// TelemetryCollectorURL is the URL (host:port) for collecting spans
 	TelemetryCollectorURL string `env:"TELEMETRY_COLLECTOR_URL,default=localhost:4317"`
+	// TelemetryMetricsEnabled exports metrics to the telemetry collector in addition to spans
+	TelemetryMetricsEnabled bool `env:"TELEMETRY_METRICS_ENABLED,default=false"`
 }
 
 // DBConf is the database configuration: if generated from environment variables,
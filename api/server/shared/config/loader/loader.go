@@ -31,6 +31,7 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/porter_app/namecache"
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/internal/repository/gorm"
 	"github.com/porter-dev/porter/internal/telemetry"
@@ -294,6 +295,8 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	res.URLCache = urlcache.Init(sc.DefaultApplicationHelmRepoURL, sc.DefaultAddonHelmRepoURL)
 	res.Logger.Info().Msg("Created URL Cache")
 
+	res.PorterAppNameCache = namecache.New(sc.PorterAppNameCacheTTL)
+
 	res.Logger.Info().Msg("Creating provisioner service client")
 	provClient, err := getProvisionerServiceClient(sc)
 	if err == nil && provClient != nil {
@@ -333,8 +336,9 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	}
 
 	res.TelemetryConfig = telemetry.TracerConfig{
-		ServiceName:  sc.TelemetryName,
-		CollectorURL: sc.TelemetryCollectorURL,
+		ServiceName:    sc.TelemetryName,
+		CollectorURL:   sc.TelemetryCollectorURL,
+		MetricsEnabled: sc.TelemetryMetricsEnabled,
 	}
 
 	return res, nil
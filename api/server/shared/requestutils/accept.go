@@ -0,0 +1,30 @@
+package requestutils
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AcceptsMediaType returns true if the Accept header of the request explicitly lists the given media type, e.g. application/x-ndjson.
+// Wildcards are not matched, so that clients which accept anything keep receiving the default representation
+func AcceptsMediaType(r *http.Request, mediaType string) bool {
+	for _, header := range r.Header.Values("Accept") {
+		for _, accepted := range strings.Split(header, ",") {
+			parsed, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+			if err != nil {
+				continue
+			}
+			// a quality of 0 means the media type is not acceptable
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				continue
+			}
+			if strings.EqualFold(parsed, mediaType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
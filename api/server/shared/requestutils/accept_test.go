@@ -0,0 +1,34 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptsMediaType(t *testing.T) {
+	tests := []struct {
+		description string
+		accept      []string
+		expected    bool
+	}{
+		{description: "no accept header", accept: nil, expected: false},
+		{description: "exact match", accept: []string{"application/x-ndjson"}, expected: true},
+		{description: "match among several types", accept: []string{"application/json, application/x-ndjson;q=0.9"}, expected: true},
+		{description: "match in a repeated header", accept: []string{"application/json", "Application/X-NDJSON"}, expected: true},
+		{description: "wildcards do not match", accept: []string{"*/*", "application/*"}, expected: false},
+		{description: "zero quality does not match", accept: []string{"application/x-ndjson;q=0"}, expected: false},
+		{description: "other type", accept: []string{"application/json"}, expected: false},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("POST", "/", nil)
+		for _, accept := range test.accept {
+			req.Header.Add("Accept", accept)
+		}
+
+		assert.Equal(t, test.expected, requestutils.AcceptsMediaType(req, "application/x-ndjson"), test.description)
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/gorilla/schema"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
 )
 
 // Decoder populates a request form from the request body and URL.
@@ -63,12 +65,19 @@ func (d *DefaultDecoder) Decode(
 func requestErrorFromJSONErr(err error) apierrors.RequestError {
 	var syntaxErr *json.SyntaxError
 	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
 	var clientErr error
 
-	if errors.As(err, &syntaxErr) {
+	if errors.As(err, &maxBytesErr) {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("request body must not be larger than %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+	} else if errors.As(err, &syntaxErr) {
 		clientErr = fmt.Errorf("JSON syntax error at character %d", syntaxErr.Offset)
 	} else if errors.As(err, &typeErr) {
 		clientErr = fmt.Errorf("Invalid type for body param %s: expected %s, got %s", typeErr.Field, typeErr.Type.Kind().String(), typeErr.Value)
+
+		return apierrors.WithFieldErrors(apierrors.NewErrPassThroughToClient(clientErr, http.StatusBadRequest), []types.FieldError{
+			{Field: typeErr.Field, Reason: fmt.Sprintf("expected %s, got %s", typeErr.Type.Kind().String(), typeErr.Value)},
+		})
 	} else {
 		return apierrors.NewErrPassThroughToClient(fmt.Errorf("Could not parse JSON request"), http.StatusBadRequest, err.Error())
 	}
@@ -81,14 +90,21 @@ func requestErrorFromSchemaErr(err error) apierrors.RequestError {
 		errMap := map[string]error(multiErr)
 
 		resStrArr := make([]string, 0)
+		fieldErrors := make([]types.FieldError, 0, len(errMap))
 
-		for _, err := range errMap {
+		for key, err := range errMap {
 			resStrArr = append(resStrArr, readableStringFromSchemaErr(err))
+			fieldErrors = append(fieldErrors, types.FieldError{Field: key, Reason: readableReasonFromSchemaErr(err)})
 		}
 
+		// map iteration order is random, so field errors are sorted for stable responses
+		sort.Slice(fieldErrors, func(i, j int) bool {
+			return fieldErrors[i].Field < fieldErrors[j].Field
+		})
+
 		clientErr := fmt.Errorf(strings.Join(resStrArr, ","))
 
-		return apierrors.NewErrPassThroughToClient(clientErr, http.StatusBadRequest)
+		return apierrors.WithFieldErrors(apierrors.NewErrPassThroughToClient(clientErr, http.StatusBadRequest), fieldErrors)
 	}
 
 	// if not castable to multi-error, this is likely a server-side error, such as the
@@ -109,3 +125,16 @@ func readableStringFromSchemaErr(err error) string {
 
 	return str
 }
+
+// readableReasonFromSchemaErr returns why a query param could not be decoded, without the name of the param
+func readableReasonFromSchemaErr(err error) string {
+	if typeErr := (schema.ConversionError{}); errors.As(err, &typeErr) {
+		return fmt.Sprintf("invalid type: expected %s", typeErr.Type.Kind().String())
+	} else if emptyFieldErr := (schema.EmptyFieldError{}); errors.As(err, &emptyFieldErr) {
+		return "cannot be empty"
+	} else if unknownKeyErr := (schema.UnknownKeyError{}); errors.As(err, &unknownKeyErr) {
+		return "unknown query param"
+	}
+
+	return "invalid value"
+}
@@ -0,0 +1,63 @@
+package requestutils
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// TotalCountHeader is the response header containing the total number of items across all pages
+	TotalCountHeader = "X-Total-Count"
+	// PageHeader is the response header containing the 1-indexed page returned in the response
+	PageHeader = "X-Page"
+)
+
+// PaginationHeaders describes a page of a paginated response
+type PaginationHeaders struct {
+	// Page is the 1-indexed page returned in the response
+	Page int
+	// PageSize is the number of items per page
+	PageSize int
+	// TotalCount is the total number of items across all pages
+	TotalCount int
+}
+
+// SetPaginationHeaders sets the X-Total-Count, X-Page and Link headers on a paginated response. The Link header contains the
+// next and prev pages, if they exist, as the request url with the page query parameter replaced. pageParam is the name of
+// that query parameter, e.g. "page"
+func SetPaginationHeaders(w http.ResponseWriter, r *http.Request, pageParam string, pagination PaginationHeaders) {
+	w.Header().Set(TotalCountHeader, strconv.Itoa(pagination.TotalCount))
+	w.Header().Set(PageHeader, strconv.Itoa(pagination.Page))
+
+	var links []string
+	if pagination.PageSize > 0 && pagination.Page*pagination.PageSize < pagination.TotalCount {
+		links = append(links, pageLink(r, pageParam, pagination.Page+1, "next"))
+	}
+	if pagination.Page > 1 {
+		// a page past the end links back to the last page, so that clients can recover from a stale page number
+		prev := pagination.Page - 1
+		if pagination.PageSize > 0 {
+			if lastPage := (pagination.TotalCount + pagination.PageSize - 1) / pagination.PageSize; lastPage < prev {
+				prev = lastPage
+			}
+		}
+		if prev >= 1 {
+			links = append(links, pageLink(r, pageParam, prev, "prev"))
+		}
+	}
+
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
+
+func pageLink(r *http.Request, pageParam string, page int, rel string) string {
+	u := *r.URL
+	query := u.Query()
+	query.Set(pageParam, strconv.Itoa(page))
+	u.RawQuery = query.Encode()
+
+	return fmt.Sprintf(`<%s>; rel="%s"`, u.RequestURI(), rel)
+}
@@ -0,0 +1,55 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPaginationHeaders(t *testing.T) {
+	tests := []struct {
+		description string
+		pagination  requestutils.PaginationHeaders
+		link        string
+	}{
+		{
+			description: "first page",
+			pagination:  requestutils.PaginationHeaders{Page: 1, PageSize: 20, TotalCount: 45},
+			link:        `</api/apps/revisions?deployment_target_id=abc&page=2>; rel="next"`,
+		},
+		{
+			description: "middle page",
+			pagination:  requestutils.PaginationHeaders{Page: 2, PageSize: 20, TotalCount: 45},
+			link:        `</api/apps/revisions?deployment_target_id=abc&page=3>; rel="next", </api/apps/revisions?deployment_target_id=abc&page=1>; rel="prev"`,
+		},
+		{
+			description: "last page",
+			pagination:  requestutils.PaginationHeaders{Page: 3, PageSize: 20, TotalCount: 45},
+			link:        `</api/apps/revisions?deployment_target_id=abc&page=2>; rel="prev"`,
+		},
+		{
+			description: "page past the end links to the last page",
+			pagination:  requestutils.PaginationHeaders{Page: 10, PageSize: 20, TotalCount: 45},
+			link:        `</api/apps/revisions?deployment_target_id=abc&page=3>; rel="prev"`,
+		},
+		{
+			description: "single page",
+			pagination:  requestutils.PaginationHeaders{Page: 1, PageSize: 20, TotalCount: 5},
+			link:        "",
+		},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest("GET", "/api/apps/revisions?deployment_target_id=abc&page=7", nil)
+		rr := httptest.NewRecorder()
+
+		requestutils.SetPaginationHeaders(rr, req, "page", test.pagination)
+
+		assert.Equal(t, strconv.Itoa(test.pagination.TotalCount), rr.Header().Get(requestutils.TotalCountHeader), test.description)
+		assert.Equal(t, strconv.Itoa(test.pagination.Page), rr.Header().Get(requestutils.PageHeader), test.description)
+		assert.Equal(t, test.link, rr.Header().Get("Link"), test.description)
+	}
+}
@@ -0,0 +1,18 @@
+package requestutils
+
+import "context"
+
+type shutdownSignalKey struct{}
+
+// WithShutdownSignal returns a context carrying a channel which is closed when the server begins shutting down
+func WithShutdownSignal(ctx context.Context, shuttingDown <-chan struct{}) context.Context {
+	return context.WithValue(ctx, shutdownSignalKey{}, shuttingDown)
+}
+
+// ShutdownSignal returns a channel which is closed when the server begins shutting down. Long-lived requests such as streams should
+// end cleanly when it is closed, since the server only waits a grace period for in-flight requests to finish. If the context has no
+// shutdown signal, a nil channel is returned, which is never closed.
+func ShutdownSignal(ctx context.Context) <-chan struct{} {
+	shuttingDown, _ := ctx.Value(shutdownSignalKey{}).(<-chan struct{})
+	return shuttingDown
+}
@@ -3,10 +3,12 @@ package requestutils
 import (
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	v10Validator "github.com/go-playground/validator/v10"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/validator"
 )
 
@@ -49,14 +51,92 @@ func (v *DefaultValidator) Validate(s interface{}) apierrors.RequestError {
 
 	// convert all validator errors to error strings
 	errorStrs := make([]string, len(errs))
+	fieldErrors := make([]types.FieldError, len(errs))
 
 	for i, field := range errs {
 		errObj := NewValidationErrObject(field)
 
 		errorStrs[i] = errObj.SafeExternalError()
+		fieldErrors[i] = types.FieldError{
+			Field:  requestFieldName(reflect.TypeOf(s), field.StructNamespace()),
+			Reason: errObj.SafeExternalReason(),
+		}
 	}
 
-	return NewErrFailedRequestValidation(strings.Join(errorStrs, ","))
+	return apierrors.WithFieldErrors(NewErrFailedRequestValidation(strings.Join(errorStrs, ",")), fieldErrors)
+}
+
+// requestFieldName converts the struct namespace of a field, e.g. "CreateRequest.Services[0].Name", to the name sent in
+// the request, e.g. "services[0].name", using the json tag or else the schema tag of each field
+func requestFieldName(t reflect.Type, structNamespace string) string {
+	segments := strings.Split(structNamespace, ".")
+	if len(segments) < 2 {
+		return structNamespace
+	}
+
+	// the first segment is the name of the top-level struct
+	names := make([]string, 0, len(segments)-1)
+
+	for _, segment := range segments[1:] {
+		fieldName, index, _ := strings.Cut(segment, "[")
+		if index != "" {
+			index = "[" + index
+		}
+
+		t = derefStructType(t)
+		if t == nil || t.Kind() != reflect.Struct {
+			names = append(names, segment)
+			continue
+		}
+
+		field, ok := t.FieldByName(fieldName)
+		if !ok {
+			names = append(names, segment)
+			t = nil
+			continue
+		}
+
+		t = field.Type
+
+		name := tagName(field)
+		if name == "" {
+			// the fields of embedded structs are promoted, so the embedded struct is not part of the name
+			if field.Anonymous {
+				continue
+			}
+			name = field.Name
+		}
+
+		names = append(names, name+index)
+	}
+
+	return strings.Join(names, ".")
+}
+
+// derefStructType returns the type of the value stored in a pointer, slice, array or map, so that field names can be
+// resolved for nested structs
+func derefStructType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+
+	return nil
+}
+
+func tagName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "schema"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return ""
 }
 
 func NewErrFailedRequestValidation(valError string) apierrors.RequestError {
@@ -107,9 +187,15 @@ func NewValidationErrObject(fieldErr v10Validator.FieldError) *ValidationErrObje
 // Note: the test cases split on "," to parse out the different errors. Don't add commas to the
 // safe external error.
 func (obj *ValidationErrObject) SafeExternalError() string {
+	return fmt.Sprintf("validation failed on field '%s' on %s", obj.Field, obj.SafeExternalReason())
+}
+
+// SafeExternalReason is the same as SafeExternalError, without the name of the field. The same restrictions
+// on the actual value apply.
+func (obj *ValidationErrObject) SafeExternalReason() string {
 	var sb strings.Builder
 
-	sb.WriteString(fmt.Sprintf("validation failed on field '%s' on condition '%s'", obj.Field, obj.Condition))
+	sb.WriteString(fmt.Sprintf("condition '%s'", obj.Condition))
 
 	if obj.Param != "" {
 		sb.WriteString(fmt.Sprintf(" [ %s ]: got %s", obj.Param, obj.getActualValueString()))
@@ -9,6 +9,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
 )
 
 const (
@@ -241,3 +242,30 @@ func TestErrFailedRequestValidation(t *testing.T) {
 		"incorrect value for InternalError() method",
 	)
 }
+
+type validationFieldErrorsTestObj struct {
+	Limit    int `schema:"limit" form:"lte=10"`
+	Services []struct {
+		Name string `json:"name" form:"required"`
+	} `json:"services" form:"dive"`
+}
+
+func TestValidationFieldErrors(t *testing.T) {
+	validator := requestutils.NewDefaultValidator()
+
+	obj := &validationFieldErrorsTestObj{Limit: 11}
+	obj.Services = append(obj.Services, struct {
+		Name string `json:"name" form:"required"`
+	}{})
+
+	err := validator.Validate(obj)
+	assert.NotNil(t, err)
+
+	fieldErr, ok := err.(*apierrors.ErrWithFieldErrors)
+	assert.True(t, ok, "expected field errors to be attached")
+
+	assert.ElementsMatch(t, []types.FieldError{
+		{Field: "limit", Reason: "condition 'lte' [ 10 ]: got 11"},
+		{Field: "services[0].name", Reason: "condition 'required'"},
+	}, fieldErr.FieldErrors())
+}
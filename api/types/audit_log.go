@@ -0,0 +1,68 @@
+package types
+
+import "time"
+
+// AuditLogAction is the type of a mutating action recorded in a project's audit log
+type AuditLogAction string
+
+const (
+	// AuditLogAction_Deploy is recorded when an app is deployed, e.g. through apply or an image update
+	AuditLogAction_Deploy AuditLogAction = "deploy"
+	// AuditLogAction_Rollback is recorded when an app is rolled back to a previous revision
+	AuditLogAction_Rollback AuditLogAction = "rollback"
+	// AuditLogAction_Cancel is recorded when the build of a revision is canceled
+	AuditLogAction_Cancel AuditLogAction = "cancel"
+	// AuditLogAction_Impersonate is recorded when an admin makes a request as a member of the project, using the impersonation headers
+	AuditLogAction_Impersonate AuditLogAction = "impersonate"
+	// AuditLogAction_RestartPod is recorded when a pod of an app is deleted so that it is recreated
+	AuditLogAction_RestartPod AuditLogAction = "restart_pod"
+)
+
+// IsValid returns true if the action is one of the known audit log actions
+func (a AuditLogAction) IsValid() bool {
+	switch a {
+	case AuditLogAction_Deploy, AuditLogAction_Rollback, AuditLogAction_Cancel, AuditLogAction_Impersonate, AuditLogAction_RestartPod:
+		return true
+	}
+	return false
+}
+
+// AuditLogEntry is a record of a mutating action taken on an app
+type AuditLogEntry struct {
+	ID uint `json:"id"`
+	// CreatedAt is the time the action was taken
+	CreatedAt time.Time `json:"created_at"`
+	// UserID is the id of the user who took the action. For actions taken with an api token, this is the id of the user who created the token
+	UserID uint `json:"user_id"`
+	// APITokenID is the id of the api token the action was taken with, if any
+	APITokenID string         `json:"api_token_id,omitempty"`
+	ClusterID  uint           `json:"cluster_id"`
+	Action     AuditLogAction `json:"action"`
+	// PorterAppID is the id of the app the action was taken on, or 0 if the app was not known
+	PorterAppID        uint   `json:"porter_app_id,omitempty"`
+	AppName            string `json:"app_name,omitempty"`
+	DeploymentTargetID string `json:"deployment_target_id,omitempty"`
+	// AppRevisionID is the id of the revision resulting from the action, if any
+	AppRevisionID string `json:"app_revision_id,omitempty"`
+	// RevisionNumber is the number of the revision resulting from the action, or 0 if it was not known
+	RevisionNumber uint64 `json:"revision_number,omitempty"`
+	// Detail is additional context for actions which do not result in a revision, e.g. the request made while impersonating
+	Detail string `json:"detail,omitempty"`
+}
+
+// ListAuditLogRequest is the request to list the audit log of a project
+type ListAuditLogRequest struct {
+	// Since is an optional RFC3339 timestamp. If set, only entries recorded at or after this time are returned
+	Since string `schema:"since"`
+	// Until is an optional RFC3339 timestamp. If set, only entries recorded before this time are returned
+	Until string `schema:"until"`
+	// Action is an optional comma-separated list of actions, e.g. "deploy,rollback". If empty, entries of all actions are returned
+	Action string `schema:"action"`
+	// Limit is the maximum number of entries to return, most recent first. Defaults to 100, with a maximum of 1000
+	Limit int `schema:"limit" form:"gte=0,lte=1000"`
+}
+
+// ListAuditLogResponse is the response for listing the audit log of a project
+type ListAuditLogResponse struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
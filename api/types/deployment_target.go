@@ -16,4 +16,17 @@ type DeploymentTarget struct {
 	SelectorType string    `json:"selector_type"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+
+	// Name is the human-readable name of the target. This may be empty for targets created before names were backfilled
+	Name string `json:"name"`
+	// Namespace is the namespace the target deploys to. This is empty if the target is not selected by namespace
+	Namespace string `json:"namespace"`
+	// IsPreview is true if the target is a preview environment
+	IsPreview bool `json:"is_preview"`
+	// PullRequestNumber is the number of the pull request a preview target was created for. This is 0 for non-preview targets, or if it was not recorded
+	PullRequestNumber int `json:"pull_request_number"`
+	// Branch is the branch a preview target deploys. This is empty for non-preview targets
+	Branch string `json:"branch"`
+	// Repo is the source repository of a preview target's pull request, e.g. porter-dev/porter. This is empty for non-preview targets, or if it was not recorded
+	Repo string `json:"repo"`
 }
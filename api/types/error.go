@@ -4,9 +4,40 @@ const (
 	ErrCodeUnavailable uint = 601
 )
 
+// APIErrorCode is a stable, machine-readable identifier for the cause of an error, which clients can switch on
+// instead of parsing the error message. Values must not change once released.
+type APIErrorCode string
+
+const (
+	// APIErrorCode_InvalidRequest indicates that the request could not be decoded or is missing required fields
+	APIErrorCode_InvalidRequest APIErrorCode = "INVALID_REQUEST"
+	// APIErrorCode_AppNotFound indicates that no app with the requested name exists
+	APIErrorCode_AppNotFound APIErrorCode = "APP_NOT_FOUND"
+	// APIErrorCode_AppAmbiguous indicates that multiple apps share the requested name, and a cluster must be specified to select one
+	APIErrorCode_AppAmbiguous APIErrorCode = "APP_AMBIGUOUS"
+	// APIErrorCode_InvalidDeploymentTarget indicates that the deployment target is missing, malformed, or could not be found
+	APIErrorCode_InvalidDeploymentTarget APIErrorCode = "INVALID_DEPLOYMENT_TARGET"
+	// APIErrorCode_TargetNotReady indicates that the deployment target exists but has not finished provisioning
+	APIErrorCode_TargetNotReady APIErrorCode = "TARGET_NOT_READY"
+)
+
 type ExternalError struct {
 	// Optional error code for well-known error types
 	Code uint `json:"code,omitempty"`
 
+	// ErrorCode is an optional machine-readable code for the cause of the error
+	ErrorCode APIErrorCode `json:"error_code,omitempty"`
+
 	Error string `json:"error"`
+
+	// FieldErrors are the request fields which could not be decoded or failed validation, if any
+	FieldErrors []FieldError `json:"field_errors,omitempty"`
+}
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	// Field is the name of the field as sent in the request, e.g. the query param or json key. Nested fields are separated by dots
+	Field string `json:"field"`
+	// Reason is a readable description of why the field is invalid
+	Reason string `json:"reason"`
 }
@@ -31,6 +31,25 @@ type PorterApp struct {
 
 	// Helm
 	HelmRevisionNumber int `json:"helm_revision_number,omitempty"`
+
+	// CreatedAt is the time the app was created
+	CreatedAt time.Time `json:"created_at"`
+	// CreatedByUserID is the id of the user who created the app, or 0 if the creator was not recorded
+	CreatedByUserID uint `json:"created_by_user_id,omitempty"`
+	// Owner is the user who created the app. This is only set by endpoints which resolve the creator, and is omitted if the creator was not recorded
+	Owner *PorterAppOwner `json:"owner,omitempty"`
+	// Labels are arbitrary key-value pairs attached to the app
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// PorterAppOwner is the user who created a porter app
+type PorterAppOwner struct {
+	// UserID is the id of the user, which is set even if the user has since been deleted
+	UserID uint `json:"user_id"`
+	// Email is the email of the user, or a tombstone identifier of the form "deleted-user-{id}" if the user has since been deleted
+	Email string `json:"email"`
+	// Deleted is true if the user has since been deleted
+	Deleted bool `json:"deleted"`
 }
 
 // swagger:model
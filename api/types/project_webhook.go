@@ -0,0 +1,25 @@
+package types
+
+import "time"
+
+// ProjectWebhook is an external endpoint which is notified of deploys, build failures and notifications of apps in a project
+type ProjectWebhook struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// SigningEnabled is true if payloads are signed with a shared secret
+	SigningEnabled bool      `json:"signing_enabled"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// CreateProjectWebhookRequest is the request to register a webhook on a project
+type CreateProjectWebhookRequest struct {
+	// URL is the endpoint that events are posted to. Must use https, and must not resolve to a loopback, private or link-local address
+	URL string `json:"url" form:"required,url,startswith=https://"`
+	// SigningSecret is an optional shared secret. If set, each payload is signed with HMAC-SHA256 and the signature is sent in the X-Porter-Signature header
+	SigningSecret string `json:"signing_secret"`
+}
+
+// ListProjectWebhooksResponse is the response for listing the webhooks of a project
+type ListProjectWebhooksResponse struct {
+	Webhooks []ProjectWebhook `json:"webhooks"`
+}
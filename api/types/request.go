@@ -53,8 +53,12 @@ const (
 	URLParamPorterAppName         URLParam = "porter_app_name"
 	URLParamPorterAppEventID      URLParam = "porter_app_event_id"
 	URLParamAppRevisionID         URLParam = "app_revision_id"
+	URLParamAppRevisionNumber     URLParam = "app_revision_number"
+	URLParamNotificationID        URLParam = "notification_id"
+	URLParamPodName               URLParam = "pod_name"
 	URLParamDeploymentTargetID    URLParam = "deployment_target_id"
 	URLParamWebhookID             URLParam = "webhook_id"
+	URLParamServiceName           URLParam = "service_name"
 )
 
 type Path struct {
@@ -75,11 +79,19 @@ type APIRequestMetadata struct {
 	// Whether the endpoint upgrades to a websocket
 	IsWebsocket bool
 
+	// Whether the endpoint holds the request open by design, e.g. to wait on a rollout, so that it is not logged as a slow request.
+	// Websocket endpoints and streamed responses are never logged as slow
+	LongLived bool
+
 	// Whether the endpoint should check for a usage limit
 	CheckUsage bool
 
 	// The usage metric that the request should check for, if CheckUsage
 	UsageMetric UsageMetric
+
+	// MaxRequestBodyBytes overrides the server's maximum request body size for the endpoint if positive.
+	// If negative, the request body size is not limited, e.g. for streaming uploads
+	MaxRequestBodyBytes int64
 }
 
 const RequestScopeCtxKey = "requestscopes"
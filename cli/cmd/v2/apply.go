@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/porter-dev/porter/api/server/handlers/deployment_target"
 	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -54,11 +55,6 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 		return Update(ctx, UpdateInput(inp))
 	}
 
-	deploymentTargetID, err := deploymentTargetFromConfig(ctx, client, cliConf.Project, cliConf.Cluster, inp.PreviewApply)
-	if err != nil {
-		return fmt.Errorf("error getting deployment target from config: %w", err)
-	}
-
 	var prNumber int
 	prNumberEnv := os.Getenv("PORTER_PR_NUMBER")
 	if prNumberEnv != "" {
@@ -68,6 +64,11 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 		}
 	}
 
+	deploymentTargetID, err := deploymentTargetFromConfig(ctx, client, cliConf.Project, cliConf.Cluster, inp.PreviewApply, prNumber)
+	if err != nil {
+		return fmt.Errorf("error getting deployment target from config: %w", err)
+	}
+
 	porterYamlExists := len(inp.PorterYamlPath) != 0
 
 	if porterYamlExists {
@@ -195,7 +196,7 @@ func Apply(ctx context.Context, inp ApplyInput) error {
 	if applyResp.CLIAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_BUILD {
 		color.New(color.FgGreen).Printf("Building new image...\n") // nolint:errcheck,gosec
 
-		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, commitSHA)
+		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, applyResp.AppRevisionId, commitSHA)
 
 		reportBuildFailureInput := reportBuildFailureInput{
 			client:             client,
@@ -484,7 +485,7 @@ func buildSettingsFromBase64AppProto(base64AppProto string) (buildInput, error)
 	}, nil
 }
 
-func deploymentTargetFromConfig(ctx context.Context, client api.Client, projectID, clusterID uint, previewApply bool) (string, error) {
+func deploymentTargetFromConfig(ctx context.Context, client api.Client, projectID, clusterID uint, previewApply bool, prNumber int) (string, error) {
 	var deploymentTargetID string
 
 	if os.Getenv("PORTER_DEPLOYMENT_TARGET_ID") != "" {
@@ -516,7 +517,12 @@ func deploymentTargetFromConfig(ctx context.Context, client api.Client, projectI
 			return deploymentTargetID, errors.New("branch name is empty. Please run apply in a git repository with access to the git CLI")
 		}
 
-		targetResp, err := client.CreateDeploymentTarget(ctx, projectID, clusterID, branchName, true)
+		targetResp, err := client.CreateDeploymentTargetWithOptions(ctx, projectID, clusterID, &deployment_target.CreateDeploymentTargetRequest{
+			Selector:          branchName,
+			Preview:           true,
+			PullRequestNumber: prNumber,
+			Repo:              os.Getenv("GITHUB_REPOSITORY"),
+		})
 		if err != nil {
 			return deploymentTargetID, fmt.Errorf("error calling create deployment target endpoint: %w", err)
 		}
@@ -127,7 +127,7 @@ func Update(ctx context.Context, inp UpdateInput) error {
 	}
 
 	if buildSettings != nil && buildSettings.Build.Method != "" {
-		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, commitSHA)
+		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, updateResp.AppRevisionId, commitSHA)
 
 		var buildFinished bool
 		var buildError error
@@ -94,12 +94,10 @@ export const useAppStatus = (
             let newPods = data
                 // Parse only data that we need
                 .map((pod: any) => {
-                    const replicaSetName =
-                        Array.isArray(pod?.metadata?.ownerReferences) &&
-                        pod?.metadata?.ownerReferences[0]?.name;
+                    const replicaSetName = pod?.replica_set;
                     const containerStatus =
-                        Array.isArray(pod?.status?.containerStatuses) &&
-                        pod?.status?.containerStatuses[0];
+                        Array.isArray(pod?.containers) &&
+                        pod?.containers[0];
 
                     // const restartCount = containerStatus
                     //     ? containerStatus.restartCount
@@ -109,8 +107,8 @@ export const useAppStatus = (
                     //     new Date(pod?.metadata?.creationTimestamp)
                     // );
 
-                    const isFailing = containerStatus?.state?.waiting?.reason === "CrashLoopBackOff" ?? false;
-                    const crashLoopReason = containerStatus?.lastState?.terminated?.message ?? "";
+                    const isFailing = containerStatus?.state === "waiting" && containerStatus?.reason === "CrashLoopBackOff";
+                    const crashLoopReason = containerStatus?.last_termination?.message ?? "";
 
                     return {
                         // namespace: pod?.metadata?.namespace,
@@ -121,8 +119,8 @@ export const useAppStatus = (
                         // containerStatus,
                         // podAge: pod?.metadata?.creationTimestamp ? podAge : "N/A",
                         replicaSetName,
-                        revisionId: pod?.metadata?.labels?.["porter.run/app-revision-id"],
-                        helmRevision: pod?.metadata?.annotations?.["helm.sh/revision"] || "N/A",
+                        revisionId: pod?.revision_id,
+                        helmRevision: pod?.helm_revision || "N/A",
                         crashLoopReason,
                         isFailing
                     };
@@ -2,19 +2,28 @@ package deployment_target
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/telemetry"
 )
 
+// ErrDeploymentTargetNotFound is returned when a deployment target does not exist in the project, or belongs to a different cluster
+var ErrDeploymentTargetNotFound = errors.New("deployment target not found")
+
 // DeploymentTargetDetailsInput is the input to the DeploymentTargetDetails function
 type DeploymentTargetDetailsInput struct {
 	ProjectID          int64
 	ClusterID          int64
 	DeploymentTargetID string
 	CCPClient          porterv1connect.ClusterControlPlaneServiceClient
+	// DeploymentTargetRepository is used to read the pull request metadata of preview targets. If nil, the metadata is left empty
+	DeploymentTargetRepository repository.DeploymentTargetRepository
 }
 
 // DeploymentTarget is a struct representing the unique cluster, namespace pair for a deployment target
@@ -25,6 +34,12 @@ type DeploymentTarget struct {
 	Namespace string `json:"namespace"`
 	IsPreview bool   `json:"is_preview"`
 	IsDefault bool   `json:"is_default"`
+	// PullRequestNumber is the number of the pull request a preview target was created for. This is 0 for non-preview targets, or if it was not recorded
+	PullRequestNumber int `json:"pull_request_number"`
+	// Branch is the branch a preview target deploys. This is empty for non-preview targets
+	Branch string `json:"branch"`
+	// Repo is the source repository of a preview target's pull request, e.g. porter-dev/porter. This is empty for non-preview targets, or if it was not recorded
+	Repo string `json:"repo"`
 }
 
 // DeploymentTargetDetails gets the deployment target details from CCP
@@ -54,6 +69,9 @@ func DeploymentTargetDetails(ctx context.Context, inp DeploymentTargetDetailsInp
 
 	deploymentTargetDetailsResp, err := inp.CCPClient.DeploymentTargetDetails(ctx, deploymentTargetDetailsReq)
 	if err != nil {
+		if connect.CodeOf(err) == connect.CodeNotFound {
+			return deploymentTarget, telemetry.Error(ctx, span, fmt.Errorf("%w: %w", ErrDeploymentTargetNotFound, err), "deployment target not found by cluster control plane client")
+		}
 		return deploymentTarget, telemetry.Error(ctx, span, err, "error getting deployment target details from cluster control plane client")
 	}
 
@@ -62,8 +80,11 @@ func DeploymentTargetDetails(ctx context.Context, inp DeploymentTargetDetailsInp
 	}
 
 	target := deploymentTargetDetailsResp.Msg.DeploymentTarget
+	if target == nil {
+		return deploymentTarget, telemetry.Error(ctx, span, ErrDeploymentTargetNotFound, "deployment target details resp has no deployment target")
+	}
 	if target.ClusterId != inp.ClusterID {
-		return deploymentTarget, telemetry.Error(ctx, span, nil, "deployment target details resp cluster id does not match cluster id")
+		return deploymentTarget, telemetry.Error(ctx, span, fmt.Errorf("%w: deployment target details resp cluster id does not match cluster id", ErrDeploymentTargetNotFound), "deployment target details resp cluster id does not match cluster id")
 	}
 
 	deploymentTarget = DeploymentTarget{
@@ -75,5 +96,28 @@ func DeploymentTargetDetails(ctx context.Context, inp DeploymentTargetDetailsInp
 		IsDefault: target.IsDefault,
 	}
 
+	if target.IsPreview && inp.DeploymentTargetRepository != nil {
+		// preview metadata is not part of the cluster control plane's deployment target, so it is read from the database
+		deploymentTargetUUID, err := uuid.Parse(inp.DeploymentTargetID)
+		if err != nil {
+			return deploymentTarget, telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		}
+
+		dbDeploymentTarget, err := inp.DeploymentTargetRepository.DeploymentTarget(uint(inp.ProjectID), deploymentTargetUUID)
+		if err != nil {
+			return deploymentTarget, telemetry.Error(ctx, span, err, "error reading deployment target from database")
+		}
+
+		previewMetadata := dbDeploymentTarget.PreviewMetadata()
+		deploymentTarget.PullRequestNumber = previewMetadata.PullRequestNumber
+		deploymentTarget.Branch = previewMetadata.Branch
+		deploymentTarget.Repo = previewMetadata.Repo
+
+		// fall back to the target's name, since preview targets are named after the branch they deploy
+		if deploymentTarget.Branch == "" {
+			deploymentTarget.Branch = target.Name
+		}
+	}
+
 	return deploymentTarget, nil
 }
@@ -1009,6 +1009,21 @@ func (a *Agent) GetDeploymentsBySelector(ctx context.Context, namespace string,
 	return res, nil
 }
 
+// GetStatefulSetsBySelector gets the statefulsets matching the label selector in a namespace
+func (a *Agent) GetStatefulSetsBySelector(ctx context.Context, namespace string, selector string) (*appsv1.StatefulSetList, error) {
+	res, err := a.Clientset.AppsV1().StatefulSets(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: selector,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetStatefulSet gets the statefulset given the name and namespace
 func (a *Agent) GetStatefulSet(c grapher.Object) (*appsv1.StatefulSet, error) {
 	res, err := a.Clientset.AppsV1().StatefulSets(c.Namespace).Get(
@@ -1028,6 +1043,21 @@ func (a *Agent) GetStatefulSet(c grapher.Object) (*appsv1.StatefulSet, error) {
 	return res, nil
 }
 
+// GetReplicaSetsBySelector gets the replicasets matching the label selector in a namespace
+func (a *Agent) GetReplicaSetsBySelector(ctx context.Context, namespace string, selector string) (*appsv1.ReplicaSetList, error) {
+	res, err := a.Clientset.AppsV1().ReplicaSets(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: selector,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 // GetReplicaSet gets the replicaset given the name and namespace
 func (a *Agent) GetReplicaSet(c grapher.Object) (*appsv1.ReplicaSet, error) {
 	res, err := a.Clientset.AppsV1().ReplicaSets(c.Namespace).Get(
@@ -1115,6 +1145,65 @@ func (a *Agent) GetPodsByLabel(selector string, namespace string) (*v1.PodList,
 	)
 }
 
+// ListPodsByLabelPage returns up to limit pods matching the given selector in the namespace, starting from the given continue token.
+// The continue token for the next page is set on the returned list's metadata, and is empty if there are no more pods.
+func (a *Agent) ListPodsByLabelPage(ctx context.Context, selector string, namespace string, limit int64, continueToken string) (*v1.PodList, error) {
+	return a.Clientset.CoreV1().Pods(namespace).List(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector: selector,
+			Limit:         limit,
+			Continue:      continueToken,
+		},
+	)
+}
+
+// WatchPodsByLabel watches pods matching the given selector in the namespace, starting from the given resource version.
+// The watch is stopped when the context is cancelled or Stop is called on the returned watch.
+func (a *Agent) WatchPodsByLabel(ctx context.Context, selector string, namespace string, resourceVersion string) (watch.Interface, error) {
+	return a.Clientset.CoreV1().Pods(namespace).Watch(
+		ctx,
+		metav1.ListOptions{
+			LabelSelector:   selector,
+			ResourceVersion: resourceVersion,
+		},
+	)
+}
+
+// PodMetrics is the current resource usage of a pod as reported by the metrics API
+type PodMetrics struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Containers        []ContainerMetrics `json:"containers"`
+}
+
+// ContainerMetrics is the current resource usage of a container as reported by the metrics API
+type ContainerMetrics struct {
+	Name  string          `json:"name"`
+	Usage v1.ResourceList `json:"usage"`
+}
+
+// GetPodMetricsByLabel retrieves the current resource usage of pods matching the given selector from the metrics API.
+// An error is returned if the metrics server is not installed in the cluster.
+func (a *Agent) GetPodMetricsByLabel(ctx context.Context, selector string, namespace string) ([]PodMetrics, error) {
+	raw, err := a.Clientset.CoreV1().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1/namespaces", namespace, "pods").
+		Param("labelSelector", selector).
+		DoRaw(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pod metrics: %w", err)
+	}
+
+	podMetricsList := struct {
+		Items []PodMetrics `json:"items"`
+	}{}
+	err = json.Unmarshal(raw, &podMetricsList)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling pod metrics: %w", err)
+	}
+
+	return podMetricsList.Items, nil
+}
+
 // GetPodByName retrieves a single instance of pod with given name
 func (a *Agent) GetPodByName(name string, namespace string) (*v1.Pod, error) {
 	// Get pod by name
@@ -1135,6 +1224,33 @@ func (a *Agent) GetPodByName(name string, namespace string) (*v1.Pod, error) {
 	return pod, nil
 }
 
+// GetPodLogLines returns the most recent tailLines log lines of a container in a pod. Each line is prefixed with its RFC3339 timestamp.
+func (a *Agent) GetPodLogLines(ctx context.Context, namespace string, name string, container string, tailLines int64) ([]string, error) {
+	podLogOpts := v1.PodLogOptions{
+		Container:  container,
+		TailLines:  &tailLines,
+		Timestamps: true,
+	}
+
+	logs, err := a.Clientset.CoreV1().Pods(namespace).GetLogs(name, &podLogOpts).DoRaw(ctx)
+	if err != nil && errors.IsNotFound(err) {
+		return nil, IsNotFoundError
+	}
+	if err != nil && errors.IsBadRequest(err) {
+		return nil, &BadRequestError{err.Error()}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot get logs from pod %s: %w", name, err)
+	}
+
+	lines := strings.Split(strings.TrimRight(string(logs), "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return []string{}, nil
+	}
+
+	return lines, nil
+}
+
 // DeletePod deletes a pod by name and namespace
 func (a *Agent) DeletePod(namespace string, name string) error {
 	err := a.Clientset.CoreV1().Pods(namespace).Delete(
@@ -1150,6 +1266,24 @@ func (a *Agent) DeletePod(namespace string, name string) error {
 	return err
 }
 
+// DeletePodWithGracePeriod deletes a pod, waiting up to gracePeriodSeconds for its containers to terminate before they are killed.
+// If gracePeriodSeconds is nil, the pod's own termination grace period is used. A grace period of 0 kills the pod immediately.
+func (a *Agent) DeletePodWithGracePeriod(ctx context.Context, namespace string, name string, gracePeriodSeconds *int64) error {
+	err := a.Clientset.CoreV1().Pods(namespace).Delete(
+		ctx,
+		name,
+		metav1.DeleteOptions{
+			GracePeriodSeconds: gracePeriodSeconds,
+		},
+	)
+
+	if err != nil && errors.IsNotFound(err) {
+		return IsNotFoundError
+	}
+
+	return err
+}
+
 // GetPodLogs streams real-time logs from a given pod.
 func (a *Agent) GetPodLogs(namespace string, name string, selectedContainer string, rw *websocket.WebsocketSafeReadWriter) error {
 	// get the pod to read in the list of contains
@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -62,7 +63,20 @@ func (j JSONB) Value() (driver.Value, error) {
 
 // Scan implements the sql.Scanner interface
 func (j *JSONB) Scan(value interface{}) error {
-	if err := json.Unmarshal(value.([]byte), &j); err != nil {
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		// sqlite returns jsonb columns as text
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported jsonb value of type %T", value)
+	}
+
+	if err := json.Unmarshal(data, &j); err != nil {
 		return err
 	}
 	return nil
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditLogEntry is a record of a mutating action taken on an app through the API, e.g. a deploy or rollback, or of a privileged
+// action taken in a project, e.g. an admin impersonating a member of the project
+type AuditLogEntry struct {
+	gorm.Model
+
+	// ProjectID is the ID of the project that the action was taken in
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	// UserID is the ID of the user who took the action. For actions taken with an api token, this is the ID of the user who created the token
+	UserID uint
+
+	// APITokenID is the unique ID of the api token the action was taken with, if any
+	APITokenID string
+
+	// Action is the type of action taken, e.g. deploy
+	Action string `gorm:"index"`
+
+	// PorterAppID is the ID of the app the action was taken on. This is 0 if the app was not known when the action was recorded
+	PorterAppID uint
+	AppName     string
+
+	// DeploymentTargetID is the ID of the deployment target the action was taken on, if known
+	DeploymentTargetID string
+
+	// AppRevisionID is the ID of the revision resulting from the action, if any
+	AppRevisionID string
+	// RevisionNumber is the number of the revision resulting from the action. This is 0 if the number was not known when the action was recorded
+	RevisionNumber uint64
+
+	// Detail is additional context for actions which do not result in a revision, e.g. the method and path of a request made while impersonating
+	Detail string
+}
+
+// ToAuditLogEntryType converts an AuditLogEntry to its api type
+func (e *AuditLogEntry) ToAuditLogEntryType() types.AuditLogEntry {
+	return types.AuditLogEntry{
+		ID:                 e.ID,
+		CreatedAt:          e.CreatedAt,
+		UserID:             e.UserID,
+		APITokenID:         e.APITokenID,
+		ClusterID:          e.ClusterID,
+		Action:             types.AuditLogAction(e.Action),
+		PorterAppID:        e.PorterAppID,
+		AppName:            e.AppName,
+		DeploymentTargetID: e.DeploymentTargetID,
+		AppRevisionID:      e.AppRevisionID,
+		RevisionNumber:     e.RevisionNumber,
+		Detail:             e.Detail,
+	}
+}
@@ -48,13 +48,84 @@ type DeploymentTarget struct {
 
 // ToDeploymentTargetType generates an external types.PorterApp to be shared over REST
 func (d *DeploymentTarget) ToDeploymentTargetType() *types.DeploymentTarget {
+	var namespace string
+	if d.SelectorType == DeploymentTargetSelectorType_Namespace {
+		namespace = d.Selector
+	}
+
+	previewMetadata := d.PreviewMetadata()
+
 	return &types.DeploymentTarget{
-		ID:           d.ID,
-		ProjectID:    uint(d.ProjectID),
-		ClusterID:    uint(d.ClusterID),
-		Selector:     d.Selector,
-		SelectorType: string(d.SelectorType),
-		CreatedAt:    d.CreatedAt,
-		UpdatedAt:    d.UpdatedAt,
+		ID:                d.ID,
+		ProjectID:         uint(d.ProjectID),
+		ClusterID:         uint(d.ClusterID),
+		Selector:          d.Selector,
+		SelectorType:      string(d.SelectorType),
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		Name:              d.VanityName,
+		Namespace:         namespace,
+		IsPreview:         d.Preview,
+		PullRequestNumber: previewMetadata.PullRequestNumber,
+		Branch:            previewMetadata.Branch,
+		Repo:              previewMetadata.Repo,
+	}
+}
+
+const (
+	deploymentTargetMetadataKey_PullRequestNumber = "pull_request_number"
+	deploymentTargetMetadataKey_Branch            = "branch"
+	deploymentTargetMetadataKey_Repo              = "repo"
+)
+
+// DeploymentTargetPreviewMetadata describes the pull request a preview deployment target was created for
+type DeploymentTargetPreviewMetadata struct {
+	// PullRequestNumber is the number of the pull request. This is 0 if it was not recorded
+	PullRequestNumber int
+	// Branch is the branch the preview deploys
+	Branch string
+	// Repo is the source repository of the pull request, e.g. porter-dev/porter
+	Repo string
+}
+
+// PreviewMetadata returns the pull request metadata stored on a preview deployment target. Non-preview targets have empty metadata.
+// Preview targets are named after the branch they deploy, so the name is used if no branch was recorded
+func (d *DeploymentTarget) PreviewMetadata() DeploymentTargetPreviewMetadata {
+	var metadata DeploymentTargetPreviewMetadata
+	if !d.Preview {
+		return metadata
+	}
+
+	// numbers read from jsonb are decoded as float64
+	switch prNumber := d.Metadata[deploymentTargetMetadataKey_PullRequestNumber].(type) {
+	case float64:
+		metadata.PullRequestNumber = int(prNumber)
+	case int:
+		metadata.PullRequestNumber = prNumber
+	}
+	metadata.Branch, _ = d.Metadata[deploymentTargetMetadataKey_Branch].(string)
+	metadata.Repo, _ = d.Metadata[deploymentTargetMetadataKey_Repo].(string)
+
+	if metadata.Branch == "" {
+		metadata.Branch = d.VanityName
+	}
+
+	return metadata
+}
+
+// SetPreviewMetadata records pull request metadata on the deployment target. Fields which are empty are left unchanged
+func (d *DeploymentTarget) SetPreviewMetadata(metadata DeploymentTargetPreviewMetadata) {
+	if d.Metadata == nil {
+		d.Metadata = make(JSONB)
+	}
+
+	if metadata.PullRequestNumber != 0 {
+		d.Metadata[deploymentTargetMetadataKey_PullRequestNumber] = metadata.PullRequestNumber
+	}
+	if metadata.Branch != "" {
+		d.Metadata[deploymentTargetMetadataKey_Branch] = metadata.Branch
+	}
+	if metadata.Repo != "" {
+		d.Metadata[deploymentTargetMetadataKey_Repo] = metadata.Repo
 	}
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"fmt"
+
 	"gorm.io/gorm"
 
 	"github.com/porter-dev/porter/api/types"
@@ -30,25 +32,34 @@ type PorterApp struct {
 
 	// Porter YAML
 	PorterYamlPath string
+
+	// Labels are arbitrary key-value pairs attached to the app, e.g. to record the team which owns it
+	Labels JSONB `json:"labels" sql:"type:jsonb" gorm:"type:jsonb;default:'{}'"`
+
+	// CreatedByUserID is the id of the user who created the app. This is 0 for apps created before the creator was recorded
+	CreatedByUserID uint
 }
 
 // ToPorterAppType generates an external types.PorterApp to be shared over REST
 func (a *PorterApp) ToPorterAppType() *types.PorterApp {
 	return &types.PorterApp{
-		ID:             a.ID,
-		ProjectID:      a.ProjectID,
-		ClusterID:      a.ClusterID,
-		Name:           a.Name,
-		ImageRepoURI:   a.ImageRepoURI,
-		GitRepoID:      a.GitRepoID,
-		RepoName:       a.RepoName,
-		GitBranch:      a.GitBranch,
-		BuildContext:   a.BuildContext,
-		Builder:        a.Builder,
-		Buildpacks:     a.Buildpacks,
-		Dockerfile:     a.Dockerfile,
-		PullRequestURL: a.PullRequestURL,
-		PorterYamlPath: a.PorterYamlPath,
+		ID:              a.ID,
+		ProjectID:       a.ProjectID,
+		ClusterID:       a.ClusterID,
+		Name:            a.Name,
+		ImageRepoURI:    a.ImageRepoURI,
+		GitRepoID:       a.GitRepoID,
+		RepoName:        a.RepoName,
+		GitBranch:       a.GitBranch,
+		BuildContext:    a.BuildContext,
+		Builder:         a.Builder,
+		Buildpacks:      a.Buildpacks,
+		Dockerfile:      a.Dockerfile,
+		PullRequestURL:  a.PullRequestURL,
+		PorterYamlPath:  a.PorterYamlPath,
+		CreatedAt:       a.CreatedAt,
+		CreatedByUserID: a.CreatedByUserID,
+		Labels:          a.LabelsMap(),
 	}
 }
 
@@ -71,6 +82,22 @@ func (a *PorterApp) ToPorterAppTypeWithRevision(revision int) *types.PorterApp {
 		Dockerfile:         a.Dockerfile,
 		PullRequestURL:     a.PullRequestURL,
 		PorterYamlPath:     a.PorterYamlPath,
+		CreatedAt:          a.CreatedAt,
+		CreatedByUserID:    a.CreatedByUserID,
 		HelmRevisionNumber: revision,
+		Labels:             a.LabelsMap(),
+	}
+}
+
+// LabelsMap returns the labels of the app as strings. Values which are not strings are formatted with fmt
+func (a *PorterApp) LabelsMap() map[string]string {
+	labels := make(map[string]string, len(a.Labels))
+	for k, v := range a.Labels {
+		if s, ok := v.(string); ok {
+			labels[k] = s
+			continue
+		}
+		labels[k] = fmt.Sprint(v)
 	}
+	return labels
 }
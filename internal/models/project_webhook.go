@@ -0,0 +1,34 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ProjectWebhook is an external endpoint which is notified of deploy events on apps in a project
+type ProjectWebhook struct {
+	gorm.Model
+
+	// ID is a UUID for the webhook
+	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+
+	// ProjectID is the ID of the project that the webhook is associated with
+	ProjectID uint `gorm:"index"`
+
+	// URL is the endpoint that events are posted to
+	URL string
+
+	// SigningSecret is the shared secret used to sign payloads, encrypted at rest. Payloads are not signed if this is empty
+	SigningSecret []byte
+}
+
+// ToProjectWebhookType converts a ProjectWebhook to its api type. The signing secret is never returned
+func (w *ProjectWebhook) ToProjectWebhookType() types.ProjectWebhook {
+	return types.ProjectWebhook{
+		ID:             w.ID.String(),
+		URL:            w.URL,
+		SigningEnabled: len(w.SigningSecret) > 0,
+		CreatedAt:      w.CreatedAt,
+	}
+}
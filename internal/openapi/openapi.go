@@ -0,0 +1,379 @@
+// Package openapi generates OpenAPI 3 definitions from the request and response structs of API handlers.
+// Query parameters are derived from the `schema` tags used to decode requests, and response schemas from `json` tags,
+// so that the generated definitions match what the server actually accepts and returns.
+package openapi
+
+import (
+	"encoding"
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version is the OpenAPI version of generated documents
+const Version = "3.0.3"
+
+// Operation describes a single API endpoint to generate a definition for
+type Operation struct {
+	// Method is the http method of the endpoint, e.g. GET
+	Method string
+	// Path is the full path of the endpoint, with path parameters in braces, e.g. /api/projects/{project_id}
+	Path string
+	// OperationID is a unique identifier for the operation, used by client generators to name methods
+	OperationID string
+	// Summary is a short description of the endpoint
+	Summary string
+	// Tags are used to group operations
+	Tags []string
+	// Request is a value of the struct the request is decoded into. Fields are query parameters for GET and DELETE requests, and the json body otherwise.
+	// May be nil if the endpoint takes no parameters
+	Request any
+	// Response is a value of the type written on success. May be nil if the endpoint writes no body
+	Response any
+}
+
+// Document is an OpenAPI document
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*PathItem `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+// Info is the metadata of an OpenAPI document
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// PathItem is a single operation on a path
+type PathItem struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is a path or query parameter
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+// RequestBody is the body of a request
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is a response to an operation
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is the schema of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the named schemas referenced by operations
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is a subset of the OpenAPI schema object sufficient to describe go types
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+const jsonMediaType = "application/json"
+
+var pathParamRegex = regexp.MustCompile(`{([^}]+)}`)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Generate returns an OpenAPI document describing the given operations
+func Generate(title string, version string, operations []Operation) (*Document, error) {
+	g := &generator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:   title,
+			Version: version,
+		},
+		Paths: make(map[string]map[string]*PathItem),
+		Components: Components{
+			Schemas: g.schemas,
+		},
+	}
+
+	for _, op := range operations {
+		method := strings.ToLower(op.Method)
+		if _, ok := doc.Paths[op.Path][method]; ok {
+			return nil, fmt.Errorf("duplicate operation %s %s", op.Method, op.Path)
+		}
+
+		item, err := g.pathItem(op)
+		if err != nil {
+			return nil, fmt.Errorf("error generating %s %s: %w", op.Method, op.Path, err)
+		}
+
+		if doc.Paths[op.Path] == nil {
+			doc.Paths[op.Path] = make(map[string]*PathItem)
+		}
+		doc.Paths[op.Path][method] = item
+	}
+
+	return doc, nil
+}
+
+type generator struct {
+	// schemas are the named schemas, keyed by component name
+	schemas map[string]*Schema
+	// names are the component names of go types which have already been added to schemas
+	names map[reflect.Type]string
+}
+
+func (g *generator) pathItem(op Operation) (*PathItem, error) {
+	item := &PathItem{
+		OperationID: op.OperationID,
+		Summary:     op.Summary,
+		Tags:        op.Tags,
+		Responses:   make(map[string]Response),
+	}
+
+	for _, match := range pathParamRegex.FindAllStringSubmatch(op.Path, -1) {
+		item.Parameters = append(item.Parameters, Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+
+	if op.Request != nil {
+		requestType := derefType(reflect.TypeOf(op.Request))
+		if requestType.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("request must be a struct, got %s", requestType)
+		}
+
+		switch strings.ToUpper(op.Method) {
+		case "GET", "DELETE":
+			params, err := g.queryParameters(requestType)
+			if err != nil {
+				return nil, err
+			}
+			item.Parameters = append(item.Parameters, params...)
+		default:
+			item.RequestBody = &RequestBody{
+				Required: true,
+				Content: map[string]MediaType{
+					jsonMediaType: {Schema: g.schema(requestType)},
+				},
+			}
+		}
+	}
+
+	if op.Response == nil {
+		item.Responses["200"] = Response{Description: "OK"}
+	} else {
+		item.Responses["200"] = Response{
+			Description: "OK",
+			Content: map[string]MediaType{
+				jsonMediaType: {Schema: g.schema(reflect.TypeOf(op.Response))},
+			},
+		}
+	}
+
+	return item, nil
+}
+
+// queryParameters returns a query parameter for each field of the request with a schema tag, following gorilla/schema's naming
+func (g *generator) queryParameters(t reflect.Type) ([]Parameter, error) {
+	var params []Parameter
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("schema"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := derefType(field.Type)
+			if embedded.Kind() == reflect.Struct {
+				embeddedParams, err := g.queryParameters(embedded)
+				if err != nil {
+					return nil, err
+				}
+				params = append(params, embeddedParams...)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema := g.schema(field.Type)
+		if schema.Ref != "" || schema.Type == "object" {
+			return nil, fmt.Errorf("query parameter %s must be a scalar or a list of scalars", name)
+		}
+
+		params = append(params, Parameter{
+			Name:   name,
+			In:     "query",
+			Schema: schema,
+		})
+	}
+
+	return params, nil
+}
+
+// schema returns the schema for a go type, following encoding/json's rules. Named structs are added to the components and referenced.
+func (g *generator) schema(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Pointer {
+		schema := g.schema(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Kind() != reflect.String && (t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)):
+		// types such as uuid.UUID are encoded as strings
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.componentName(t)}
+	default:
+		// interfaces and other types which cannot be described are left unconstrained
+		return &Schema{}
+	}
+}
+
+// componentName adds the schema for a named struct to the components if it has not been added yet, and returns its name
+func (g *generator) componentName(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	// qualify names by package, so that e.g. porter_app.Revision and types.Revision do not collide
+	name := fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+	for i := 2; g.schemas[name] != nil; i++ {
+		name = fmt.Sprintf("%s.%s%d", path.Base(t.PkgPath()), t.Name(), i)
+	}
+
+	// the name is registered before the schema is generated, so that recursive types reference themselves instead of recursing forever
+	g.names[t] = name
+	g.schemas[name] = &Schema{}
+	*g.schemas[name] = *g.structSchema(t)
+
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	g.addStructFields(schema, t)
+
+	sort.Strings(schema.Required)
+
+	return schema
+}
+
+func (g *generator) addStructFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		// embedded structs without a json name have their fields promoted, as with encoding/json
+		if field.Anonymous && name == "" {
+			embedded := derefType(field.Type)
+			if embedded.Kind() == reflect.Struct {
+				g.addStructFields(schema, embedded)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.schema(field.Type)
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,72 @@
+package openapi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/openapi"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	DeploymentTargetID string   `schema:"deployment_target_id"`
+	Limit              int      `schema:"limit"`
+	Labels             []string `schema:"labels"`
+	Ignored            string   `schema:"-"`
+}
+
+type testItem struct {
+	Name     string            `json:"name"`
+	Created  time.Time         `json:"created_at"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Children []testItem        `json:"children,omitempty"`
+	Parent   *testItem         `json:"parent,omitempty"`
+	internal string
+}
+
+type testResponse struct {
+	Items []testItem `json:"items"`
+	Total int64      `json:"total_count"`
+}
+
+func TestGenerate(t *testing.T) {
+	doc, err := openapi.Generate("test", "v1", []openapi.Operation{
+		{
+			Method:      "GET",
+			Path:        "/apps/{porter_app_name}/items",
+			OperationID: "listItems",
+			Request:     &testRequest{},
+			Response:    testResponse{},
+		},
+	})
+	require.NoError(t, err)
+
+	op := doc.Paths["/apps/{porter_app_name}/items"]["get"]
+	require.NotNil(t, op)
+
+	var paramNames []string
+	for _, param := range op.Parameters {
+		paramNames = append(paramNames, param.In+":"+param.Name)
+	}
+	require.Equal(t, []string{"path:porter_app_name", "query:deployment_target_id", "query:limit", "query:labels"}, paramNames)
+	require.Equal(t, "array", op.Parameters[3].Schema.Type)
+
+	response := op.Responses["200"].Content["application/json"].Schema
+	require.Equal(t, "#/components/schemas/openapi_test.testResponse", response.Ref)
+
+	item := doc.Components.Schemas["openapi_test.testItem"]
+	require.NotNil(t, item)
+	require.Equal(t, []string{"created_at", "name"}, item.Required)
+	require.Equal(t, "date-time", item.Properties["created_at"].Format)
+	require.Equal(t, "#/components/schemas/openapi_test.testItem", item.Properties["children"].Items.Ref)
+	require.Equal(t, "#/components/schemas/openapi_test.testItem", item.Properties["parent"].Ref)
+	require.Equal(t, "string", item.Properties["labels"].AdditionalProperties.Type)
+	require.NotContains(t, item.Properties, "internal")
+}
+
+func TestGenerateDuplicateOperation(t *testing.T) {
+	op := openapi.Operation{Method: "GET", Path: "/apps"}
+
+	_, err := openapi.Generate("test", "v1", []openapi.Operation{op, op})
+	require.Error(t, err)
+}
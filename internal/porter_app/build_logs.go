@@ -0,0 +1,36 @@
+package porter_app
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// buildLogsMetadataKey is the key under a build event's errors metadata which holds the base64 encoded build logs reported by the CLI
+const buildLogsMetadataKey = "b64-build-logs"
+
+// BuildLogLinesFromEventMetadata returns the lines of the build logs stored on a build event. The CLI only reports logs for failed builds,
+// so nil is returned if the event has no logs
+func BuildLogLinesFromEventMetadata(metadata map[string]any) ([]string, error) {
+	errs, ok := metadata["errors"].(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+
+	b64Logs, ok := errs[buildLogsMetadataKey].(string)
+	if !ok || b64Logs == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(b64Logs)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding build logs: %w", err)
+	}
+
+	logs := strings.TrimRight(strings.ReplaceAll(string(decoded), "\r\n", "\n"), "\n")
+	if logs == "" {
+		return nil, nil
+	}
+
+	return strings.Split(logs, "\n"), nil
+}
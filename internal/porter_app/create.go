@@ -41,6 +41,7 @@ type CreateGithubAppInput struct {
 	GitRepoName         string
 	PorterYamlPath      string
 	GitRepoID           uint
+	CreatedByUserID     uint
 	PorterAppRepository repository.PorterAppRepository
 }
 
@@ -51,6 +52,7 @@ type CreateDockerRegistryAppInput struct {
 	Name                string
 	Repository          string
 	Tag                 string
+	CreatedByUserID     uint
 	PorterAppRepository repository.PorterAppRepository
 }
 
@@ -59,6 +61,7 @@ type CreateLocalAppInput struct {
 	ProjectID           uint
 	ClusterID           uint
 	Name                string
+	CreatedByUserID     uint
 	PorterAppRepository repository.PorterAppRepository
 }
 
@@ -73,6 +76,8 @@ type CreateOrGetAppRecordInput struct {
 	GitRepoID      uint
 	PorterYamlPath string
 	Image          *Image
+	// CreatedByUserID is the id of the user creating the app. It is not changed if the app already exists
+	CreatedByUserID uint
 
 	PorterAppRepository repository.PorterAppRepository
 }
@@ -140,6 +145,7 @@ func CreateOrGetAppRecord(ctx context.Context, input CreateOrGetAppRecordInput)
 			GitBranch:           input.GitBranch,
 			GitRepoName:         input.GitRepoName,
 			PorterYamlPath:      input.PorterYamlPath,
+			CreatedByUserID:     input.CreatedByUserID,
 			PorterAppRepository: input.PorterAppRepository,
 		}
 
@@ -163,6 +169,7 @@ func CreateOrGetAppRecord(ctx context.Context, input CreateOrGetAppRecordInput)
 			Name:                input.Name,
 			Repository:          input.Image.Repository,
 			Tag:                 input.Image.Tag,
+			CreatedByUserID:     input.CreatedByUserID,
 			PorterAppRepository: input.PorterAppRepository,
 		}
 
@@ -176,6 +183,7 @@ func CreateOrGetAppRecord(ctx context.Context, input CreateOrGetAppRecordInput)
 			ProjectID:           input.ProjectID,
 			ClusterID:           input.ClusterID,
 			Name:                input.Name,
+			CreatedByUserID:     input.CreatedByUserID,
 			PorterAppRepository: input.PorterAppRepository,
 		}
 
@@ -198,13 +206,14 @@ func createGithubApp(ctx context.Context, input CreateGithubAppInput) (*models.P
 	defer span.End()
 
 	porterApp := &models.PorterApp{
-		Name:           input.Name,
-		ProjectID:      input.ProjectID,
-		ClusterID:      input.ClusterID,
-		GitRepoID:      input.GitRepoID,
-		GitBranch:      input.GitBranch,
-		RepoName:       input.GitRepoName,
-		PorterYamlPath: input.PorterYamlPath,
+		Name:            input.Name,
+		ProjectID:       input.ProjectID,
+		ClusterID:       input.ClusterID,
+		GitRepoID:       input.GitRepoID,
+		GitBranch:       input.GitBranch,
+		RepoName:        input.GitRepoName,
+		PorterYamlPath:  input.PorterYamlPath,
+		CreatedByUserID: input.CreatedByUserID,
 	}
 
 	porterApp, err := input.PorterAppRepository.CreatePorterApp(porterApp)
@@ -220,10 +229,11 @@ func createDockerRegistryApp(ctx context.Context, input CreateDockerRegistryAppI
 	defer span.End()
 
 	porterApp := &models.PorterApp{
-		Name:         input.Name,
-		ProjectID:    input.ProjectID,
-		ClusterID:    input.ClusterID,
-		ImageRepoURI: fmt.Sprintf("%s:%s", input.Repository, input.Tag),
+		Name:            input.Name,
+		ProjectID:       input.ProjectID,
+		ClusterID:       input.ClusterID,
+		ImageRepoURI:    fmt.Sprintf("%s:%s", input.Repository, input.Tag),
+		CreatedByUserID: input.CreatedByUserID,
 	}
 
 	porterApp, err := input.PorterAppRepository.CreatePorterApp(porterApp)
@@ -241,9 +251,10 @@ func createLocalApp(ctx context.Context, input CreateLocalAppInput) (*models.Por
 	defer span.End()
 
 	porterApp := &models.PorterApp{
-		Name:      input.Name,
-		ProjectID: input.ProjectID,
-		ClusterID: input.ClusterID,
+		Name:            input.Name,
+		ProjectID:       input.ProjectID,
+		ClusterID:       input.ClusterID,
+		CreatedByUserID: input.CreatedByUserID,
 	}
 
 	porterApp, err := input.PorterAppRepository.CreatePorterApp(porterApp)
@@ -0,0 +1,338 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DiffType describes how a key changed between two revisions
+type DiffType string
+
+const (
+	// DiffType_Added indicates that the key exists in the new revision but not the old one
+	DiffType_Added DiffType = "added"
+	// DiffType_Removed indicates that the key exists in the old revision but not the new one
+	DiffType_Removed DiffType = "removed"
+	// DiffType_Changed indicates that the key exists in both revisions with different values
+	DiffType_Changed DiffType = "changed"
+)
+
+// KeyDiff is a single changed key between two revisions
+type KeyDiff struct {
+	// Key is the name of the key that changed
+	Key string `json:"key"`
+	// Type is how the key changed
+	Type DiffType `json:"type"`
+	// OldValue is the value of the key in the old revision. Empty if the key was added or the value is redacted
+	OldValue string `json:"old_value,omitempty"`
+	// NewValue is the value of the key in the new revision. Empty if the key was removed or the value is redacted
+	NewValue string `json:"new_value,omitempty"`
+	// Redacted is true if the values were withheld because they are secret
+	Redacted bool `json:"redacted,omitempty"`
+}
+
+// ServiceDiff describes how a single service changed between two revisions
+type ServiceDiff struct {
+	// Name is the name of the service
+	Name string `json:"name"`
+	// Type is how the service changed
+	Type DiffType `json:"type"`
+	// Fields are the changed fields of the service definition. Only set when the service exists in both revisions
+	Fields []KeyDiff `json:"fields,omitempty"`
+}
+
+// RevisionDiff is a structured diff between two revisions of the same app
+type RevisionDiff struct {
+	// Env are the changed environment variables
+	Env []KeyDiff `json:"env"`
+	// Services are the added, removed, and changed services
+	Services []ServiceDiff `json:"services"`
+	// Image are the changes to the app image repository and tag
+	Image []KeyDiff `json:"image"`
+}
+
+// IsEmpty returns true if there are no differences between the revisions
+func (d RevisionDiff) IsEmpty() bool {
+	return len(d.Env) == 0 && len(d.Services) == 0 && len(d.Image) == 0
+}
+
+// OnlyImageTagDiffers returns true if the image tag is the only difference between the revisions. This is expected when
+// comparing deployment targets which deploy environment-specific tags of the same image, e.g. "staging" and "production"
+func (d RevisionDiff) OnlyImageTagDiffers() bool {
+	if len(d.Env) != 0 || len(d.Services) != 0 || len(d.Image) == 0 {
+		return false
+	}
+
+	for _, imageDiff := range d.Image {
+		if imageDiff.Key != "tag" || imageDiff.Type != DiffType_Changed {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RevisionChangeSummary summarizes which parts of an app changed from the previous revision
+type RevisionChangeSummary struct {
+	// HasPreviousRevision is false if the revision is the first revision of the app in its deployment target, in which case nothing is marked changed
+	HasPreviousRevision bool `json:"has_previous_revision"`
+	// PreviousRevisionNumber is the number of the revision compared against. Only set if HasPreviousRevision is true
+	PreviousRevisionNumber uint64 `json:"previous_revision_number,omitempty"`
+	// ImagesChanged is true if the app image repository or tag changed
+	ImagesChanged bool `json:"images_changed"`
+	// EnvChanged is true if any environment variable was added, removed, or changed
+	EnvChanged bool `json:"env_changed"`
+	// ServicesChanged is true if any service was added, removed, or changed
+	ServicesChanged bool `json:"services_changed"`
+}
+
+// Summary returns a summary of the diff against the previous revision with the given number
+func (d RevisionDiff) Summary(previousRevisionNumber uint64) RevisionChangeSummary {
+	return RevisionChangeSummary{
+		HasPreviousRevision:    true,
+		PreviousRevisionNumber: previousRevisionNumber,
+		ImagesChanged:          len(d.Image) > 0,
+		EnvChanged:             len(d.Env) > 0,
+		ServicesChanged:        len(d.Services) > 0,
+	}
+}
+
+// DiffRevisions returns the differences going from the old revision to the new revision.
+// If env has been attached to the revisions, secret variables are included in the diff with their values redacted.
+func DiffRevisions(ctx context.Context, oldRevision, newRevision Revision) (RevisionDiff, error) {
+	ctx, span := telemetry.NewSpan(ctx, "diff-revisions")
+	defer span.End()
+
+	var diff RevisionDiff
+
+	oldApp, err := appProtoFromRevision(oldRevision)
+	if err != nil {
+		return diff, telemetry.Error(ctx, span, err, "error decoding old revision")
+	}
+	newApp, err := appProtoFromRevision(newRevision)
+	if err != nil {
+		return diff, telemetry.Error(ctx, span, err, "error decoding new revision")
+	}
+
+	diff.Env = append(diff.Env, diffStringMaps(envVariables(oldApp, oldRevision.Env), envVariables(newApp, newRevision.Env), false)...)
+	diff.Env = append(diff.Env, diffStringMaps(oldRevision.Env.SecretVariables, newRevision.Env.SecretVariables, true)...)
+
+	diff.Services, err = diffServices(oldApp, newApp)
+	if err != nil {
+		return diff, telemetry.Error(ctx, span, err, "error diffing services")
+	}
+
+	diff.Image = diffStringMaps(imageFields(oldApp), imageFields(newApp), false)
+
+	return diff, nil
+}
+
+// ChangedServiceNames returns the sorted names of the services in the new app which are rolled out again going from the old app to the new app:
+// services which were added or whose definition changed. Every service is changed if the app image, env, or env group versions changed,
+// since those are shared by all services.
+func ChangedServiceNames(oldApp, newApp *porterv1.PorterApp) ([]string, error) {
+	if oldApp == nil || newApp == nil {
+		return nil, errors.New("app is nil")
+	}
+
+	names := make([]string, 0)
+
+	appChanged := len(diffStringMaps(imageFields(oldApp), imageFields(newApp), false)) > 0 ||
+		len(diffStringMaps(oldApp.Env, newApp.Env, false)) > 0 ||
+		len(diffStringMaps(envGroupVersions(oldApp), envGroupVersions(newApp), false)) > 0
+	if appChanged {
+		for name := range servicesByName(newApp) {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+
+	serviceDiffs, err := diffServices(oldApp, newApp)
+	if err != nil {
+		return nil, err
+	}
+	for _, serviceDiff := range serviceDiffs {
+		if serviceDiff.Type != DiffType_Removed {
+			names = append(names, serviceDiff.Name)
+		}
+	}
+
+	return names, nil
+}
+
+func appProtoFromRevision(revision Revision) (*porterv1.PorterApp, error) {
+	decoded, err := base64.StdEncoding.DecodeString(revision.B64AppProto)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding app proto: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+	err = helpers.UnmarshalContractObject(decoded, app)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling app proto: %w", err)
+	}
+
+	return app, nil
+}
+
+// envVariables merges the variables defined on the app proto with those in the app's attached env group
+func envVariables(app *porterv1.PorterApp, envGroup environment_groups.EnvironmentGroup) map[string]string {
+	variables := make(map[string]string)
+	for k, v := range app.Env {
+		variables[k] = v
+	}
+	for k, v := range envGroup.Variables {
+		variables[k] = v
+	}
+	return variables
+}
+
+// envGroupVersions returns the version of each env group attached to the app, keyed by env group name
+func envGroupVersions(app *porterv1.PorterApp) map[string]string {
+	versions := make(map[string]string, len(app.EnvGroups))
+	for _, envGroup := range app.EnvGroups {
+		if envGroup != nil {
+			versions[envGroup.Name] = strconv.FormatInt(envGroup.Version, 10)
+		}
+	}
+	return versions
+}
+
+func imageFields(app *porterv1.PorterApp) map[string]string {
+	if app.Image == nil {
+		return nil
+	}
+	return map[string]string{
+		"repository": app.Image.Repository,
+		"tag":        app.Image.Tag,
+	}
+}
+
+// servicesByName returns the services of an app keyed by name, preferring the service list over the deprecated service map
+func servicesByName(app *porterv1.PorterApp) map[string]*porterv1.Service {
+	services := make(map[string]*porterv1.Service)
+
+	if len(app.ServiceList) > 0 {
+		for _, service := range app.ServiceList {
+			if service != nil {
+				services[service.Name] = service
+			}
+		}
+		return services
+	}
+
+	for name, service := range app.Services { // nolint:staticcheck // temporarily using deprecated field for backwards compatibility
+		if service != nil {
+			services[name] = service
+		}
+	}
+
+	return services
+}
+
+func diffServices(oldApp, newApp *porterv1.PorterApp) ([]ServiceDiff, error) {
+	oldServices := servicesByName(oldApp)
+	newServices := servicesByName(newApp)
+
+	diffs := make([]ServiceDiff, 0)
+
+	for name, newService := range newServices {
+		oldService, ok := oldServices[name]
+		if !ok {
+			diffs = append(diffs, ServiceDiff{Name: name, Type: DiffType_Added})
+			continue
+		}
+
+		oldFields, err := serviceFields(oldService)
+		if err != nil {
+			return nil, err
+		}
+		newFields, err := serviceFields(newService)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldDiffs := diffStringMaps(oldFields, newFields, false)
+		if len(fieldDiffs) > 0 {
+			diffs = append(diffs, ServiceDiff{Name: name, Type: DiffType_Changed, Fields: fieldDiffs})
+		}
+	}
+
+	for name := range oldServices {
+		if _, ok := newServices[name]; !ok {
+			diffs = append(diffs, ServiceDiff{Name: name, Type: DiffType_Removed})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Name < diffs[j].Name
+	})
+
+	return diffs, nil
+}
+
+// serviceFields flattens the top-level fields of a service into their json representations so they can be compared
+func serviceFields(service *porterv1.Service) (map[string]string, error) {
+	by, err := helpers.MarshalContractObject(context.Background(), service)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling service: %w", err)
+	}
+
+	raw := make(map[string]json.RawMessage)
+	err = json.Unmarshal(by, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling service: %w", err)
+	}
+
+	fields := make(map[string]string, len(raw))
+	for k, v := range raw {
+		fields[k] = string(v)
+	}
+
+	return fields, nil
+}
+
+func diffStringMaps(oldMap, newMap map[string]string, redact bool) []KeyDiff {
+	diffs := make([]KeyDiff, 0)
+
+	for k, newValue := range newMap {
+		oldValue, ok := oldMap[k]
+		switch {
+		case !ok:
+			diffs = append(diffs, KeyDiff{Key: k, Type: DiffType_Added, NewValue: newValue})
+		case oldValue != newValue:
+			diffs = append(diffs, KeyDiff{Key: k, Type: DiffType_Changed, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+
+	for k, oldValue := range oldMap {
+		if _, ok := newMap[k]; !ok {
+			diffs = append(diffs, KeyDiff{Key: k, Type: DiffType_Removed, OldValue: oldValue})
+		}
+	}
+
+	if redact {
+		for i := range diffs {
+			diffs[i].OldValue = ""
+			diffs[i].NewValue = ""
+			diffs[i].Redacted = true
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+
+	return diffs
+}
@@ -3,6 +3,7 @@ package porter_app
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	"github.com/porter-dev/porter/internal/deployment_target"
@@ -168,3 +169,38 @@ func AppTemplateEnvGroupName(ctx context.Context, appName string, clusterID uint
 
 	return fmt.Sprintf("%d-template-preview", porterApp.ID), nil
 }
+
+// MaskedSecretValue is returned in place of the value of a secret env variable
+const MaskedSecretValue = "••••"
+
+// EnvVariable is a single env variable of an app, with the value masked if it is a secret
+type EnvVariable struct {
+	// Key is the name of the variable
+	Key string `json:"key"`
+	// Value is the value of the variable, or MaskedSecretValue if the variable is a secret
+	Value string `json:"value"`
+	// IsSecret is true if the variable is stored in a secret
+	IsSecret bool `json:"is_secret"`
+}
+
+// MaskedEnvVariables returns the variables of an env group sorted by key, with the values of secret variables replaced by MaskedSecretValue.
+// A key that is both a normal and a secret variable is reported once, as a secret
+func MaskedEnvVariables(envGroup environment_groups.EnvironmentGroup) []EnvVariable {
+	variables := make([]EnvVariable, 0, len(envGroup.Variables)+len(envGroup.SecretVariables))
+
+	for key, value := range envGroup.Variables {
+		if _, ok := envGroup.SecretVariables[key]; ok {
+			continue
+		}
+		variables = append(variables, EnvVariable{Key: key, Value: value})
+	}
+	for key := range envGroup.SecretVariables {
+		variables = append(variables, EnvVariable{Key: key, Value: MaskedSecretValue, IsSecret: true})
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Key < variables[j].Key
+	})
+
+	return variables
+}
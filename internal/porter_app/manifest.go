@@ -0,0 +1,112 @@
+package porter_app
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// RedactedSecretValue replaces the values of secrets in manifests returned without secrets
+const RedactedSecretValue = "REDACTED"
+
+// manifestDocumentSeparator matches the lines separating the documents of a multi-document yaml manifest
+var manifestDocumentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// ManifestObjects splits a rendered multi-document yaml manifest, e.g. the manifest of a helm release, into its kubernetes objects.
+// Documents without an object, such as those holding only comments, are skipped. Unless includeSecrets is set, the values of the
+// data and stringData of every Secret are replaced with RedactedSecretValue, keeping their keys.
+func ManifestObjects(manifest string, includeSecrets bool) ([]map[string]interface{}, error) {
+	var objects []map[string]interface{}
+
+	for i, document := range manifestDocumentSeparator.Split(manifest, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		encoded, err := yaml.YAMLToJSON([]byte(document))
+		if err != nil {
+			return nil, fmt.Errorf("error parsing manifest document %d: %w", i, err)
+		}
+		if bytes.Equal(bytes.TrimSpace(encoded), []byte("null")) {
+			continue
+		}
+
+		object := make(map[string]interface{})
+		if err := yaml.Unmarshal(encoded, &object); err != nil {
+			return nil, fmt.Errorf("manifest document %d is not a kubernetes object: %w", i, err)
+		}
+
+		if !includeSecrets && object["kind"] == "Secret" {
+			redactSecretObject(object)
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// ManifestYAML encodes kubernetes objects as a multi-document yaml manifest
+func ManifestYAML(objects []map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for _, object := range objects {
+		encoded, err := yaml.Marshal(object)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding manifest object: %w", err)
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(encoded)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ManifestHasLabel returns true if any of the objects, or any template nested in them such as the pod template of a deployment, is labeled key=value
+func ManifestHasLabel(objects []map[string]interface{}, key string, value string) bool {
+	for _, object := range objects {
+		if hasNestedLabel(object, key, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasNestedLabel searches the labels maps of an object and of everything nested in it for key=value
+func hasNestedLabel(node interface{}, key string, value string) bool {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		if labels, ok := node["labels"].(map[string]interface{}); ok && labels[key] == value {
+			return true
+		}
+		for _, child := range node {
+			if hasNestedLabel(child, key, value) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, child := range node {
+			if hasNestedLabel(child, key, value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redactSecretObject replaces the values of a Secret object in place
+func redactSecretObject(object map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := object[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range values {
+			values[key] = RedactedSecretValue
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package namecache
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// Cache is a short-lived in-memory cache of porter apps keyed by project id and app name, used to avoid repeated
+// database lookups when resolving an app name for frequently polled endpoints.
+// Only unambiguous names are cached: if more than one app in a project shares a name, the lookup is not cached so that
+// callers always see the ambiguity. A nil Cache, or one with a non-positive ttl, never caches.
+type Cache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	projectID uint
+	name      string
+}
+
+type cacheEntry struct {
+	app       models.PorterApp
+	expiresAt time.Time
+}
+
+// New returns a new Cache whose entries expire after ttl
+func New(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		entries: make(map[cacheKey]cacheEntry),
+	}
+}
+
+// Get returns the cached app for the project and name, if an unexpired entry exists.
+// The returned app is a copy, so callers may modify it without affecting the cache.
+func (c *Cache) Get(projectID uint, name string) (*models.PorterApp, bool) {
+	if c == nil || c.ttl <= 0 {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey{projectID: projectID, name: name}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+
+	app := entry.app
+	return &app, true
+}
+
+// Set caches the result of looking up apps by project and name. The result is only cached if exactly one app matched.
+func (c *Cache) Set(projectID uint, name string, apps []*models.PorterApp) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	if len(apps) != 1 || apps[0] == nil {
+		c.Invalidate(projectID, name)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[cacheKey{projectID: projectID, name: name}] = cacheEntry{
+		app:       *apps[0],
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+// Invalidate removes any cached app for the project and name. This should be called whenever an app with the name is created or deleted.
+// Apps are looked up by name case-insensitively, so entries cached under any casing of the name are removed.
+func (c *Cache) Invalidate(projectID uint, name string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if key.projectID == projectID && strings.EqualFold(key.name, name) {
+			delete(c.entries, key)
+		}
+	}
+}
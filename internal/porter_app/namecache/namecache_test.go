@@ -0,0 +1,54 @@
+package namecache_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app/namecache"
+)
+
+func TestCache(t *testing.T) {
+	is := is.New(t)
+
+	cache := namecache.New(time.Minute)
+
+	_, ok := cache.Get(1, "web")
+	is.True(!ok)
+
+	cache.Set(1, "web", []*models.PorterApp{{Name: "web", ClusterID: 1}})
+	app, ok := cache.Get(1, "web")
+	is.True(ok)
+	is.Equal(app.ClusterID, uint(1))
+
+	_, ok = cache.Get(2, "web")
+	is.True(!ok) // entries are scoped to a project
+
+	cache.Set(1, "web", []*models.PorterApp{{Name: "web", ClusterID: 1}, {Name: "web", ClusterID: 2}})
+	_, ok = cache.Get(1, "web")
+	is.True(!ok) // ambiguous names are not cached
+
+	cache.Set(1, "web", []*models.PorterApp{{Name: "web", ClusterID: 1}})
+	cache.Invalidate(1, "web")
+	_, ok = cache.Get(1, "web")
+	is.True(!ok)
+
+	cache.Set(1, "Web", []*models.PorterApp{{Name: "web", ClusterID: 1}})
+	cache.Invalidate(1, "web")
+	_, ok = cache.Get(1, "Web")
+	is.True(!ok) // names are looked up case-insensitively, so every casing is invalidated
+}
+
+func TestCacheDisabled(t *testing.T) {
+	is := is.New(t)
+
+	cache := namecache.New(0)
+	cache.Set(1, "web", []*models.PorterApp{{Name: "web"}})
+	_, ok := cache.Get(1, "web")
+	is.True(!ok)
+
+	var nilCache *namecache.Cache
+	_, ok = nilCache.Get(1, "web")
+	is.True(!ok)
+}
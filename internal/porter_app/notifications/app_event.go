@@ -1,9 +1,16 @@
 package notifications
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
 )
 
 // AppEventMetadata is the metadata for an app event
@@ -46,7 +53,10 @@ func ParseAgentEventMetadata(metadata map[string]interface{}) (*AppEventMetadata
 	return appEventMetadata, nil
 }
 
-// NotificationFromPorterAppEvent converts a PorterAppEvent to a Notification
+// NotificationFromPorterAppEvent converts a PorterAppEvent to a Notification.
+// Acknowledged is populated from the acknowledged key which is set on the event metadata when the notification is acked.
+// Resolved is only set if the event itself reports that its condition has cleared; use ResolveNotifications to also resolve
+// notifications which were cleared by later events.
 func NotificationFromPorterAppEvent(appEvent *models.PorterAppEvent) (*Notification, error) {
 	notification := &Notification{}
 	bytes, err := json.Marshal(appEvent.Metadata)
@@ -58,5 +68,195 @@ func NotificationFromPorterAppEvent(appEvent *models.PorterAppEvent) (*Notificat
 		return notification, err
 	}
 
+	notification.Severity = severityFromPorterAppEvent(appEvent, notification)
+	notification.Resolved = appEvent.Status == string(types.PorterAppEventStatus_Success)
+	notification.OccurrenceCount = 1
+	notification.FirstSeen = notification.Timestamp
+	notification.LastSeen = notification.Timestamp
+
 	return notification, nil
 }
+
+// notificationConversionSkippedCounter counts events skipped by NotificationsFromPorterAppEvents, labeled by reason
+const notificationConversionSkippedCounter = "notification_conversion_skipped"
+
+// NotificationsFromPorterAppEvents converts notification events to notifications, skipping events which cannot be converted
+// and notifications in the old format without a scope. Notifications whose condition was cleared by a later event are marked as resolved,
+// and repeated notifications are collapsed with DedupNotifications. The events must include every event of the revision newer than the oldest
+// given event, e.g. all events since a point in time rather than a page from an offset, so that resolution and collapsing are not limited to a page;
+// use PaginateNotifications on the result instead
+func NotificationsFromPorterAppEvents(ctx context.Context, events []*models.PorterAppEvent) []Notification {
+	ctx, span := telemetry.NewSpan(ctx, "notifications-from-events")
+	defer span.End()
+
+	res := make([]Notification, 0, len(events))
+
+	for _, event := range events {
+		notification, err := NotificationFromPorterAppEvent(event)
+		if err != nil {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: err.Error()})
+			telemetry.IncrementCounter(ctx, notificationConversionSkippedCounter, telemetry.AttributeKV{Key: "reason", Value: "conversion-error"})
+			continue
+		}
+		if notification == nil {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: "notification is nil"})
+			telemetry.IncrementCounter(ctx, notificationConversionSkippedCounter, telemetry.AttributeKV{Key: "reason", Value: "nil"})
+			continue
+		}
+		// TODO: remove this check once this attribute is not found in the span for >30 days
+		if notification.Scope == "" {
+			telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "notification-conversion-error", Value: "old-notification-format"})
+			telemetry.IncrementCounter(ctx, notificationConversionSkippedCounter, telemetry.AttributeKV{Key: "reason", Value: "old-format"})
+			continue
+		}
+		res = append(res, *notification)
+	}
+
+	ResolveNotifications(res)
+
+	deduped := DedupNotifications(res, NotificationDedupWindow)
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "notification-count", Value: len(res)},
+		telemetry.AttributeKV{Key: "deduped-notification-count", Value: len(deduped)},
+	)
+
+	return deduped
+}
+
+// PaginateNotifications returns up to limit of the given notifications, starting at offset. A limit of 0 returns all notifications after offset.
+// Notifications are paginated after they are converted and collapsed, so that a collapsed notification appears on exactly one page
+func PaginateNotifications(notifications []Notification, limit int, offset int) []Notification {
+	if offset < 0 || offset >= len(notifications) {
+		return []Notification{}
+	}
+
+	end := len(notifications)
+	if limit > 0 && offset+limit < end {
+		end = offset + limit
+	}
+
+	return notifications[offset:end]
+}
+
+// NotificationDedupWindow is the maximum time between two occurrences of the same notification for them to be collapsed into one
+const NotificationDedupWindow = 10 * time.Minute
+
+// DedupNotifications collapses repeated notifications about the same condition with the same error, where each occurrence follows the
+// previous one within window, into a single notification. The most recent occurrence is kept, with OccurrenceCount, FirstSeen and LastSeen
+// covering all collapsed occurrences and Severity raised to the most severe of them. The order of the given notifications is preserved,
+// with each collapsed notification in the position of its most recent occurrence.
+func DedupNotifications(notifications []Notification, window time.Duration) []Notification {
+	if len(notifications) == 0 {
+		return notifications
+	}
+
+	// occurrences are grouped in chronological order, regardless of the order of the given notifications
+	order := make([]int, len(notifications))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return notifications[order[i]].Timestamp.Before(notifications[order[j]].Timestamp)
+	})
+
+	type group struct {
+		notification Notification
+		// position is the index of the most recent occurrence in the given notifications
+		position int
+	}
+	var groups []*group
+	openGroups := make(map[string]*group)
+
+	for _, i := range order {
+		notification := notifications[i]
+		if notification.OccurrenceCount == 0 {
+			notification.OccurrenceCount = 1
+		}
+		if notification.FirstSeen.IsZero() {
+			notification.FirstSeen = notification.Timestamp
+		}
+		if notification.LastSeen.IsZero() {
+			notification.LastSeen = notification.Timestamp
+		}
+		key := dedupKey(notification)
+
+		g, ok := openGroups[key]
+		if !ok || notification.FirstSeen.Sub(g.notification.LastSeen) > window {
+			g = &group{notification: notification, position: i}
+			groups = append(groups, g)
+			openGroups[key] = g
+			continue
+		}
+
+		collapsed := notification
+		collapsed.OccurrenceCount = g.notification.OccurrenceCount + notification.OccurrenceCount
+		collapsed.FirstSeen = g.notification.FirstSeen
+		if g.notification.Severity.AtLeast(collapsed.Severity) {
+			collapsed.Severity = g.notification.Severity
+		}
+		g.notification = collapsed
+		g.position = i
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].position < groups[j].position
+	})
+
+	res := make([]Notification, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, g.notification)
+	}
+
+	return res
+}
+
+// dedupKey identifies notifications which are repeats of each other, i.e. the same error about the same condition
+func dedupKey(notification Notification) string {
+	return fmt.Sprintf("%s/%d", conditionKey(notification), notification.Error.Code)
+}
+
+// ResolveNotifications marks notifications as resolved if a later notification about the same condition, i.e. the same scope, revision,
+// service and job run, reports that the condition has cleared. Since a notification can only be cleared by a later one, the given notifications
+// must include every notification newer than the oldest of them, i.e. they must not be a page from an offset.
+func ResolveNotifications(notifications []Notification) {
+	clearedAt := make(map[string]time.Time)
+	for _, notification := range notifications {
+		if !notification.Resolved {
+			continue
+		}
+		key := conditionKey(notification)
+		if notification.Timestamp.After(clearedAt[key]) {
+			clearedAt[key] = notification.Timestamp
+		}
+	}
+
+	for i, notification := range notifications {
+		cleared, ok := clearedAt[conditionKey(notification)]
+		if ok && !notification.Timestamp.After(cleared) {
+			notifications[i].Resolved = true
+		}
+	}
+}
+
+// conditionKey identifies the condition a notification reports on
+func conditionKey(notification Notification) string {
+	return strings.Join([]string{
+		string(notification.Scope),
+		notification.AppRevisionID,
+		notification.Metadata.ServiceName,
+		notification.Metadata.JobRunID,
+	}, "/")
+}
+
+// severityFromPorterAppEvent derives the severity of a notification from its underlying event.
+// Failures are critical, notifications raised while a deployment is still in progress are often transient and are informational,
+// and everything else is a warning.
+func severityFromPorterAppEvent(appEvent *models.PorterAppEvent, notification *Notification) Severity {
+	if appEvent.Status == string(types.PorterAppEventStatus_Failed) || notification.Metadata.Deployment.Status == DeploymentStatus_Failure {
+		return Severity_Critical
+	}
+	if appEvent.Status == string(types.PorterAppEventStatus_Progressing) || notification.Metadata.Deployment.Status == DeploymentStatus_Pending {
+		return Severity_Info
+	}
+	return Severity_Warning
+}
@@ -24,6 +24,49 @@ type Notification struct {
 	Scope Scope `json:"scope"`
 	// Metadata is the metadata of the notification
 	Metadata Metadata `json:"metadata"`
+	// Acknowledged is true if a user has marked the notification as seen
+	Acknowledged bool `json:"acknowledged"`
+	// Severity is how urgent the notification is
+	Severity Severity `json:"severity"`
+	// Resolved is true if the condition the notification reports on has since cleared, e.g. a crashing service has recovered.
+	// Notifications which are not resolved are ongoing
+	Resolved bool `json:"resolved"`
+	// OccurrenceCount is the number of identical notifications which were collapsed into this one, e.g. by a flapping readiness probe.
+	// The notification itself is the most recent occurrence
+	OccurrenceCount int `json:"occurrence_count"`
+	// FirstSeen is the time of the earliest collapsed occurrence
+	FirstSeen time.Time `json:"first_seen"`
+	// LastSeen is the time of the most recent collapsed occurrence
+	LastSeen time.Time `json:"last_seen"`
+}
+
+// Severity is how urgent a notification is
+type Severity string
+
+const (
+	// Severity_Info indicates that the notification is informational and likely transient
+	Severity_Info Severity = "info"
+	// Severity_Warning indicates that the notification may need attention
+	Severity_Warning Severity = "warning"
+	// Severity_Critical indicates that the notification requires action, e.g. a failed deploy
+	Severity_Critical Severity = "critical"
+)
+
+var severityRanks = map[Severity]int{
+	Severity_Info:     0,
+	Severity_Warning:  1,
+	Severity_Critical: 2,
+}
+
+// IsValid returns true if the severity is a known severity
+func (s Severity) IsValid() bool {
+	_, ok := severityRanks[s]
+	return ok
+}
+
+// AtLeast returns true if the severity is at least as severe as the minimum severity
+func (s Severity) AtLeast(minimum Severity) bool {
+	return severityRanks[s] >= severityRanks[minimum]
 }
 
 // Metadata is the metadata of the notification
@@ -0,0 +1,255 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrImmutableAppField is returned when a patch modifies a field of the app config which cannot be changed after creation
+var ErrImmutableAppField = errors.New("patch modifies an immutable field")
+
+// ErrInvalidAppPatch is returned when a patch cannot be decoded or applied to the app config
+var ErrInvalidAppPatch = errors.New("invalid patch")
+
+// immutableAppConfigPaths are the json pointers of app config fields which cannot be patched
+var immutableAppConfigPaths = []string{"/name"}
+
+// AppConfigPatchOperation is a single JSON Patch (RFC 6902) operation on an app config
+type AppConfigPatchOperation struct {
+	// Op is one of add, remove, replace, move, copy or test
+	Op string `json:"op"`
+	// Path is the json pointer of the field to operate on, e.g. /env/LOG_LEVEL
+	Path string `json:"path"`
+	// From is the json pointer of the source field for move and copy operations
+	From string `json:"from,omitempty"`
+	// Value is the value for add, replace and test operations
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// PatchAppInput is the input to PatchApp
+type PatchAppInput struct {
+	// App is the app config to patch, e.g. from the latest revision
+	App *porterv1.PorterApp
+	// Env is the app's env, resolved from its default env group including secret values. It is patched as /env, since the app config does not carry the env
+	Env environment_groups.EnvironmentGroup
+	// Operations are the JSON Patch operations to apply
+	Operations []AppConfigPatchOperation
+}
+
+// PatchedApp is the result of patching an app config
+type PatchedApp struct {
+	// App is the patched app config, without env
+	App *porterv1.PorterApp
+	// Env is the complete patched app env. Variables which were secret before the patch stay secret, and added variables are not secret
+	Env *porterv1.EnvGroupVariables
+}
+
+// ApplyRequest returns the request to submit the patched app as a new revision. The env is submitted as a hard update, since it is the
+// complete env of the app and variables removed by the patch must be removed from the app
+func (p PatchedApp) ApplyRequest(projectID uint, deploymentTargetID string) *porterv1.ApplyPorterAppRequest {
+	return &porterv1.ApplyPorterAppRequest{
+		ProjectId:          int64(projectID),
+		DeploymentTargetId: deploymentTargetID,
+		App:                p.App,
+		AppEnv:             p.Env,
+		IsHardEnvUpdate:    true,
+	}
+}
+
+// PatchApp applies the given JSON Patch operations to the json representation of an app config with its env set as /env, returning the patched config and env.
+// Services are patched as the serviceList, so that a service is addressed by its index, e.g. /serviceList/0/port.
+// Returns ErrImmutableAppField if an operation touches an immutable field such as the app name, and ErrInvalidAppPatch if the patch cannot be applied.
+func PatchApp(ctx context.Context, inp PatchAppInput) (PatchedApp, error) {
+	ctx, span := telemetry.NewSpan(ctx, "patch-app")
+	defer span.End()
+
+	var patchedApp PatchedApp
+
+	if inp.App == nil {
+		return patchedApp, telemetry.Error(ctx, span, nil, "app proto is nil")
+	}
+	if len(inp.Operations) == 0 {
+		return patchedApp, telemetry.Error(ctx, span, ErrInvalidAppPatch, "patch has no operations")
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "operation-count", Value: len(inp.Operations)})
+
+	for _, op := range inp.Operations {
+		paths := []string{op.Path}
+		// a move removes its source, so the source must be mutable as well
+		if op.Op == "move" {
+			paths = append(paths, op.From)
+		}
+
+		for _, path := range paths {
+			if touchesImmutableAppField(path) {
+				telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "immutable-path", Value: path})
+				return patchedApp, telemetry.Error(ctx, span, ErrImmutableAppField, fmt.Sprintf("cannot %s %s", op.Op, path))
+			}
+		}
+	}
+
+	original := normalizedServiceList(inp.App)
+	original.Env = nil
+
+	encodedApp, err := helpers.MarshalContractObject(ctx, original)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error marshalling app proto")
+	}
+
+	// the env is added to the document as a plain map, so that variables are addressed by name regardless of whether they are secret.
+	// Secret values must never be added to the span
+	document := make(map[string]json.RawMessage)
+	err = json.Unmarshal(encodedApp, &document)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error unmarshalling app document")
+	}
+
+	env := make(map[string]string, len(inp.Env.Variables)+len(inp.Env.SecretVariables))
+	for key, value := range inp.Env.Variables {
+		env[key] = value
+	}
+	for key, value := range inp.Env.SecretVariables {
+		env[key] = value
+	}
+	encodedEnv, err := json.Marshal(env)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error marshalling app env")
+	}
+	document["env"] = encodedEnv
+
+	encodedDocument, err := json.Marshal(document)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error marshalling app document")
+	}
+
+	encodedPatch, err := json.Marshal(inp.Operations)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error marshalling patch operations")
+	}
+
+	patch, err := jsonpatch.DecodePatch(encodedPatch)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, fmt.Errorf("%w: %s", ErrInvalidAppPatch, err.Error()), "error decoding patch")
+	}
+
+	patchedDocument, err := patch.Apply(encodedDocument)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, fmt.Errorf("%w: %s", ErrInvalidAppPatch, err.Error()), "error applying patch")
+	}
+
+	document = make(map[string]json.RawMessage)
+	err = json.Unmarshal(patchedDocument, &document)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, fmt.Errorf("%w: %s", ErrInvalidAppPatch, err.Error()), "patched app is not a json object")
+	}
+
+	patchedEnv := make(map[string]string)
+	if encodedEnv, ok := document["env"]; ok && string(encodedEnv) != "null" {
+		err = json.Unmarshal(encodedEnv, &patchedEnv)
+		if err != nil {
+			return patchedApp, telemetry.Error(ctx, span, fmt.Errorf("%w: env must be a map of strings", ErrInvalidAppPatch), "patched env is not a map of strings")
+		}
+	}
+	delete(document, "env")
+
+	encodedApp, err = json.Marshal(document)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, err, "error marshalling patched app document")
+	}
+
+	patched := &porterv1.PorterApp{}
+	err = helpers.UnmarshalContractObject(encodedApp, patched)
+	if err != nil {
+		return patchedApp, telemetry.Error(ctx, span, fmt.Errorf("%w: %s", ErrInvalidAppPatch, err.Error()), "patched app is not a valid app config")
+	}
+
+	// guards against operations such as replacing the whole document, which do not name the immutable field directly
+	if patched.Name != original.Name {
+		return patchedApp, telemetry.Error(ctx, span, ErrImmutableAppField, "patch changes the app name")
+	}
+
+	serviceMap := make(map[string]*porterv1.Service, len(patched.ServiceList))
+	for _, service := range patched.ServiceList {
+		if service != nil {
+			serviceMap[service.Name] = service
+		}
+	}
+	patched.Services = serviceMap // nolint:staticcheck
+
+	appEnv := &porterv1.EnvGroupVariables{
+		Normal: make(map[string]string),
+		Secret: make(map[string]string),
+	}
+	for key, value := range patchedEnv {
+		if _, ok := inp.Env.SecretVariables[key]; ok {
+			appEnv.Secret[key] = value
+			continue
+		}
+		appEnv.Normal[key] = value
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "env-variable-count", Value: len(appEnv.Normal)},
+		telemetry.AttributeKV{Key: "env-secret-count", Value: len(appEnv.Secret)},
+	)
+
+	patchedApp = PatchedApp{
+		App: patched,
+		Env: appEnv,
+	}
+
+	return patchedApp, nil
+}
+
+// touchesImmutableAppField returns true if the json pointer refers to an immutable field, a child of one, or the whole document
+func touchesImmutableAppField(path string) bool {
+	if path == "" || path == "/" {
+		return true
+	}
+
+	for _, immutable := range immutableAppConfigPaths {
+		if path == immutable || strings.HasPrefix(path, immutable+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizedServiceList returns a copy of the app with services only in the service list, populated from the deprecated service map
+// if necessary, so that each service has a single path in the patched document
+func normalizedServiceList(app *porterv1.PorterApp) *porterv1.PorterApp {
+	normalized, _ := proto.Clone(app).(*porterv1.PorterApp)
+
+	if len(normalized.ServiceList) == 0 {
+		names := make([]string, 0, len(normalized.Services)) // nolint:staticcheck
+		for name := range normalized.Services {              // nolint:staticcheck
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			service := normalized.Services[name] // nolint:staticcheck
+			if service == nil {
+				continue
+			}
+			if service.Name == "" {
+				service.Name = name
+			}
+			normalized.ServiceList = append(normalized.ServiceList, service)
+		}
+	}
+	normalized.Services = nil // nolint:staticcheck
+
+	return normalized
+}
@@ -0,0 +1,33 @@
+package porter_app
+
+import (
+	"strings"
+	"time"
+)
+
+// PodLogLine is a single line of a container's logs
+type PodLogLine struct {
+	// Timestamp is the time the line was written. This is the zero time if the line had no timestamp
+	Timestamp time.Time `json:"timestamp"`
+	// Line is the log line, without its timestamp
+	Line string `json:"line"`
+}
+
+// PodLogLineFromK8s parses a log line returned by kubernetes with timestamps enabled, e.g. "2024-01-02T15:04:05.123456789Z starting server".
+// If the line does not start with a timestamp, the whole line is returned with a zero timestamp.
+func PodLogLineFromK8s(raw string) PodLogLine {
+	prefix, rest, found := strings.Cut(raw, " ")
+	if !found {
+		rest = ""
+	}
+
+	timestamp, err := time.Parse(time.RFC3339Nano, prefix)
+	if err != nil {
+		return PodLogLine{Line: raw}
+	}
+
+	return PodLogLine{
+		Timestamp: timestamp,
+		Line:      rest,
+	}
+}
@@ -0,0 +1,547 @@
+package porter_app
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ContainerState is the current state of a container in a pod
+type ContainerState string
+
+const (
+	// ContainerState_Running indicates that the container is running
+	ContainerState_Running ContainerState = "running"
+	// ContainerState_Waiting indicates that the container is waiting to start, e.g. due to a CrashLoopBackOff
+	ContainerState_Waiting ContainerState = "waiting"
+	// ContainerState_Terminated indicates that the container has terminated
+	ContainerState_Terminated ContainerState = "terminated"
+	// ContainerState_Unknown indicates that the container state has not been reported yet
+	ContainerState_Unknown ContainerState = "unknown"
+)
+
+// ImagePullErrorKind is the likely cause of a container failing to pull its image
+type ImagePullErrorKind string
+
+const (
+	// ImagePullErrorKind_Unauthorized indicates that the registry rejected the pull credentials, e.g. because a registry token expired
+	ImagePullErrorKind_Unauthorized ImagePullErrorKind = "unauthorized"
+	// ImagePullErrorKind_NotFound indicates that the image repository or tag does not exist
+	ImagePullErrorKind_NotFound ImagePullErrorKind = "not_found"
+	// ImagePullErrorKind_Unknown indicates that the image could not be pulled for another reason, or that the cause could not be determined
+	ImagePullErrorKind_Unknown ImagePullErrorKind = "unknown"
+)
+
+// containerWaitingReasonsImagePull are the container waiting reasons set by the kubelet when an image cannot be pulled
+var containerWaitingReasonsImagePull = map[string]bool{
+	"ErrImagePull":     true,
+	"ImagePullBackOff": true,
+}
+
+// ContainerStatus is a summary of the status of a single container in a pod
+type ContainerStatus struct {
+	// Name is the name of the container
+	Name string `json:"name"`
+	// Ready is true if the container is passing its readiness checks
+	Ready bool `json:"ready"`
+	// RestartCount is the number of times the container has been restarted
+	RestartCount int32 `json:"restart_count"`
+	// State is the current state of the container
+	State ContainerState `json:"state"`
+	// Reason is the reason the container is in its current state, e.g. CrashLoopBackOff
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message about the container's current state
+	Message string `json:"message,omitempty"`
+	// StartedAt is the time the container's current or most recent run started. This is null if the container has not started
+	StartedAt *time.Time `json:"started_at"`
+	// LastTermination is how the container's previous run ended, e.g. OOMKilled with exit code 137. This is null if the container has never terminated
+	LastTermination *ContainerTermination `json:"last_termination"`
+	// PullErrorKind is the likely cause of the image pull failure if the container is waiting because its image could not be pulled
+	PullErrorKind ImagePullErrorKind `json:"pull_error_kind,omitempty"`
+	// Usage is the current resource usage of the container. Only set when requested and the metrics API is available
+	Usage *ContainerUsage `json:"usage,omitempty"`
+}
+
+// ContainerTermination describes how a run of a container ended
+type ContainerTermination struct {
+	// Reason is the reason the container terminated, e.g. OOMKilled, Error or Completed
+	Reason string `json:"reason"`
+	// ExitCode is the exit code of the container's process
+	ExitCode int32 `json:"exit_code"`
+	// FinishedAt is the time the container terminated
+	FinishedAt *time.Time `json:"finished_at"`
+	// Message is a human-readable message about why the container terminated, e.g. the tail of its termination log
+	Message string `json:"message,omitempty"`
+}
+
+// ContainerUsage is the current resource usage of a container
+type ContainerUsage struct {
+	// CPUMillicores is the current cpu usage in millicores
+	CPUMillicores int64 `json:"cpu_millicores"`
+	// MemoryBytes is the current memory usage in bytes
+	MemoryBytes int64 `json:"memory_bytes"`
+}
+
+// podReasonEvicted is the pod status reason set by the kubelet when a pod is evicted from its node
+const podReasonEvicted = "Evicted"
+
+// podAnnotationHelmRevision is the annotation set on pods with the revision of the helm release which created them
+const podAnnotationHelmRevision = "helm.sh/revision"
+
+// PodStatus is a summary of the status of a pod, intended to be lighter weight than the full pod spec
+type PodStatus struct {
+	// Name is the name of the pod
+	Name string `json:"name"`
+	// Namespace is the namespace of the pod
+	Namespace string `json:"namespace"`
+	// Phase is the phase of the pod
+	Phase v1.PodPhase `json:"phase"`
+	// NodeName is the name of the node the pod is scheduled on. This is empty if the pod has not been scheduled yet
+	NodeName string `json:"node_name"`
+	// Ready is true if the pod is running and passing its readiness checks
+	Ready bool `json:"ready"`
+	// Evicted is true if the pod was evicted from its node, e.g. due to node memory pressure
+	Evicted bool `json:"evicted"`
+	// Reason is a brief reason the pod is in its current phase, e.g. Evicted
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message about why the pod is in its current phase
+	Message string `json:"message,omitempty"`
+	// CreatedAt is the time the pod was created
+	CreatedAt time.Time `json:"created_at"`
+	// StartedAt is the time the pod was acknowledged by the kubelet, before its images were pulled. This is null if the pod has not started
+	StartedAt *time.Time `json:"started_at"`
+	// ReadyContainers is the number of containers in the pod which are ready
+	ReadyContainers int `json:"ready_containers"`
+	// TotalContainers is the number of containers in the pod, excluding init containers
+	TotalContainers int `json:"total_containers"`
+	// Containers are the statuses of the pod's containers
+	Containers []ContainerStatus `json:"containers"`
+	// InitContainers are the statuses of the pod's init containers, reported separately so that startup failures can be distinguished from runtime failures
+	InitContainers []ContainerStatus `json:"init_containers"`
+	// Events are the most recent kubernetes events for the pod. Only set when requested
+	Events []PodEvent `json:"events,omitempty"`
+	// ControllerKind is the kind of the top-level workload which owns the pod, e.g. Deployment, StatefulSet or Job. Empty if the pod has no controller
+	ControllerKind string `json:"controller_kind,omitempty"`
+	// ControllerName is the name of the top-level workload which owns the pod. Empty if the pod has no controller
+	ControllerName string `json:"controller_name,omitempty"`
+	// ReplicaSet is the name of the replicaset which owns the pod, which differs between the old and new pods of a rollout. Empty if the pod is not owned by a replicaset
+	ReplicaSet string `json:"replica_set,omitempty"`
+	// RevisionID is the id of the app revision which created the pod. Empty if the pod is not labeled with a revision
+	RevisionID string `json:"revision_id,omitempty"`
+	// HelmRevision is the revision of the helm release which created the pod. Empty if the pod is not annotated with a helm revision
+	HelmRevision string `json:"helm_revision,omitempty"`
+}
+
+// TotalRestarts is the number of restarts of all of the pod's containers, including init containers
+func (p PodStatus) TotalRestarts() int32 {
+	var restarts int32
+	for _, container := range p.Containers {
+		restarts += container.RestartCount
+	}
+	for _, container := range p.InitContainers {
+		restarts += container.RestartCount
+	}
+	return restarts
+}
+
+// podStatusFields are the json names of the fields of PodStatus, which may be selected with ParsePodStatusFields
+var podStatusFields = jsonFieldNames(reflect.TypeOf(PodStatus{}))
+
+// ParsePodStatusFields parses a comma-separated list of PodStatus fields by their json names, e.g. "name,phase,ready".
+// An error listing the valid fields is returned if any field is unknown
+func ParsePodStatusFields(fields string) ([]string, error) {
+	var parsed []string
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !podStatusFields[field] {
+			valid := make([]string, 0, len(podStatusFields))
+			for name := range podStatusFields {
+				valid = append(valid, name)
+			}
+			sort.Strings(valid)
+			return nil, fmt.Errorf("unknown pod status field %q, valid fields are: %s", field, strings.Join(valid, ", "))
+		}
+		parsed = append(parsed, field)
+	}
+	return parsed, nil
+}
+
+// Project returns only the given fields of the pod status, keyed by their json names. Fields which are omitted from the json
+// encoding of the pod status when empty, e.g. events, are also omitted from the projection
+func (p PodStatus) Project(fields []string) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding pod status: %w", err)
+	}
+
+	all := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, fmt.Errorf("error decoding pod status: %w", err)
+	}
+
+	projected := make(map[string]json.RawMessage, len(fields))
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			projected[field] = value
+		}
+	}
+	return projected, nil
+}
+
+// jsonFieldNames returns the json names of the exported fields of a struct
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names[name] = true
+	}
+	return names
+}
+
+// SortPodStatusesByRestarts sorts pods by their total container restart count, in descending order if descending is true.
+// The sort is stable, so pods with the same restart count keep their relative order.
+func SortPodStatusesByRestarts(pods []PodStatus, descending bool) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		if descending {
+			return pods[i].TotalRestarts() > pods[j].TotalRestarts()
+		}
+		return pods[i].TotalRestarts() < pods[j].TotalRestarts()
+	})
+}
+
+// PodCounts are the number of pods of a service in each phase, for views which do not need the status of each pod
+type PodCounts struct {
+	// Total is the number of pods of the service
+	Total int `json:"total"`
+	// Ready is the number of pods which are running and passing their readiness checks
+	Ready int `json:"ready"`
+	// Running is the number of pods in the Running phase, whether or not they are ready
+	Running int `json:"running"`
+	// Pending is the number of pods in the Pending phase
+	Pending int `json:"pending"`
+	// Failed is the number of pods in the Failed phase
+	Failed int `json:"failed"`
+}
+
+// PodCountsByService counts pods by the service they belong to, keyed by the value of the given service name label, e.g. porter.run/service-name
+func PodCountsByService(pods []v1.Pod, serviceNameLabelKey string) map[string]PodCounts {
+	counts := make(map[string]PodCounts)
+
+	for _, pod := range pods {
+		serviceName := pod.Labels[serviceNameLabelKey]
+		serviceCounts := counts[serviceName]
+
+		serviceCounts.Total++
+		if IsPodReady(pod) {
+			serviceCounts.Ready++
+		}
+		switch pod.Status.Phase {
+		case v1.PodRunning:
+			serviceCounts.Running++
+		case v1.PodPending:
+			serviceCounts.Pending++
+		case v1.PodFailed:
+			serviceCounts.Failed++
+		}
+
+		counts[serviceName] = serviceCounts
+	}
+
+	return counts
+}
+
+// IsCompletedJobPod returns true if the pod ran to completion and is owned by a Job, e.g. a pod of a cron job run.
+// Pods which are not owned by a Job are never considered completed job pods, even if they succeeded.
+func IsCompletedJobPod(pod v1.Pod) bool {
+	if pod.Status.Phase != v1.PodSucceeded {
+		return false
+	}
+
+	for _, owner := range pod.OwnerReferences {
+		if owner.Kind == "Job" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReplicaSetOwners returns the controller of each replicaset, keyed by replicaset name. Replicasets without a controller are omitted
+func ReplicaSetOwners(replicaSets []appsv1.ReplicaSet) map[string]metav1.OwnerReference {
+	owners := make(map[string]metav1.OwnerReference, len(replicaSets))
+	for _, replicaSet := range replicaSets {
+		if owner := metav1.GetControllerOf(&replicaSet); owner != nil {
+			owners[replicaSet.Name] = *owner
+		}
+	}
+	return owners
+}
+
+// AttachController sets the top-level workload which owns the pod. Pods owned by a replicaset are attributed to the replicaset's
+// deployment, so that pods of the old and new replicasets of a rollout are grouped under the same deployment. replicaSetOwners
+// maps replicaset name to its controller, as returned by ReplicaSetOwners. A pod whose replicaset is not in replicaSetOwners
+// is attributed to the replicaset.
+func (p *PodStatus) AttachController(pod v1.Pod, replicaSetOwners map[string]metav1.OwnerReference) {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return
+	}
+
+	p.ControllerKind = owner.Kind
+	p.ControllerName = owner.Name
+
+	if owner.Kind != "ReplicaSet" {
+		return
+	}
+	if replicaSetOwner, ok := replicaSetOwners[owner.Name]; ok {
+		p.ControllerKind = replicaSetOwner.Kind
+		p.ControllerName = replicaSetOwner.Name
+	}
+}
+
+// PodEvent is a summary of a kubernetes event involving a pod
+type PodEvent struct {
+	// Reason is the short, machine-readable reason for the event, e.g. FailedScheduling
+	Reason string `json:"reason"`
+	// Message is a human-readable description of the event
+	Message string `json:"message"`
+	// Timestamp is the last time the event occurred
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PodStatusFromPod summarizes a kubernetes pod into a PodStatus. The revision id is read from the label revisionIDLabelKey, e.g. porter.run/app-revision-id
+func PodStatusFromPod(pod v1.Pod, revisionIDLabelKey string) PodStatus {
+	status := PodStatus{
+		Name:            pod.Name,
+		Namespace:       pod.Namespace,
+		Phase:           pod.Status.Phase,
+		NodeName:        pod.Spec.NodeName,
+		Ready:           IsPodReady(pod),
+		Evicted:         pod.Status.Reason == podReasonEvicted,
+		Reason:          pod.Status.Reason,
+		Message:         pod.Status.Message,
+		CreatedAt:       pod.CreationTimestamp.Time,
+		StartedAt:       timeFromK8s(pod.Status.StartTime),
+		TotalContainers: len(pod.Spec.Containers),
+		Containers:      containerStatusesFromK8s(pod.Status.ContainerStatuses),
+		InitContainers:  containerStatusesFromK8s(pod.Status.InitContainerStatuses),
+		RevisionID:      pod.Labels[revisionIDLabelKey],
+		HelmRevision:    pod.Annotations[podAnnotationHelmRevision],
+	}
+
+	if owner := metav1.GetControllerOf(&pod); owner != nil && owner.Kind == "ReplicaSet" {
+		status.ReplicaSet = owner.Name
+	}
+
+	for _, container := range status.Containers {
+		if container.Ready {
+			status.ReadyContainers++
+		}
+	}
+
+	return status
+}
+
+func containerStatusesFromK8s(k8sStatuses []v1.ContainerStatus) []ContainerStatus {
+	statuses := make([]ContainerStatus, 0, len(k8sStatuses))
+
+	for _, k8sStatus := range k8sStatuses {
+		status := ContainerStatus{
+			Name:         k8sStatus.Name,
+			Ready:        k8sStatus.Ready,
+			RestartCount: k8sStatus.RestartCount,
+			State:        ContainerState_Unknown,
+		}
+
+		switch {
+		case k8sStatus.State.Running != nil:
+			status.State = ContainerState_Running
+			status.StartedAt = timeFromK8s(&k8sStatus.State.Running.StartedAt)
+		case k8sStatus.State.Waiting != nil:
+			status.State = ContainerState_Waiting
+			status.Reason = k8sStatus.State.Waiting.Reason
+			status.Message = k8sStatus.State.Waiting.Message
+			if containerWaitingReasonsImagePull[status.Reason] {
+				status.PullErrorKind = classifyImagePullError(status.Message)
+			}
+		case k8sStatus.State.Terminated != nil:
+			status.State = ContainerState_Terminated
+			status.Reason = k8sStatus.State.Terminated.Reason
+			status.Message = k8sStatus.State.Terminated.Message
+			status.StartedAt = timeFromK8s(&k8sStatus.State.Terminated.StartedAt)
+		}
+
+		if terminated := k8sStatus.LastTerminationState.Terminated; terminated != nil {
+			status.LastTermination = &ContainerTermination{
+				Reason:     terminated.Reason,
+				ExitCode:   terminated.ExitCode,
+				FinishedAt: timeFromK8s(&terminated.FinishedAt),
+				Message:    terminated.Message,
+			}
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses
+}
+
+// HasImagePullErrors returns true if any of the pod's containers are waiting because their image could not be pulled
+func (p PodStatus) HasImagePullErrors() bool {
+	for _, containers := range [][]ContainerStatus{p.Containers, p.InitContainers} {
+		for _, container := range containers {
+			if container.PullErrorKind != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AttachImagePullErrors refines the pull error of each container which could not pull its image using the pod's events.
+// The container waiting message is often only "Back-off pulling image", while the "Failed" event for the container says whether the
+// registry rejected the credentials or the image does not exist. Containers are left unchanged if there is no matching event.
+func (p *PodStatus) AttachImagePullErrors(events []v1.Event) {
+	attach := func(containers []ContainerStatus, fieldPathFormat string) {
+		for i := range containers {
+			if containers[i].PullErrorKind == "" {
+				continue
+			}
+
+			event, ok := latestImagePullFailure(events, fmt.Sprintf(fieldPathFormat, containers[i].Name))
+			if !ok {
+				continue
+			}
+
+			containers[i].Message = event.Message
+			containers[i].PullErrorKind = classifyImagePullError(event.Message)
+		}
+	}
+
+	attach(p.Containers, "spec.containers{%s}")
+	attach(p.InitContainers, "spec.initContainers{%s}")
+}
+
+// latestImagePullFailure returns the most recent event reporting that the container at fieldPath failed to pull its image
+func latestImagePullFailure(events []v1.Event, fieldPath string) (v1.Event, bool) {
+	var latest v1.Event
+	var found bool
+
+	for _, event := range events {
+		if event.Reason != "Failed" || event.InvolvedObject.FieldPath != fieldPath || !strings.Contains(event.Message, "Failed to pull image") {
+			continue
+		}
+		if !found || eventTimestamp(event).After(eventTimestamp(latest)) {
+			latest = event
+			found = true
+		}
+	}
+
+	return latest, found
+}
+
+// classifyImagePullError guesses the cause of an image pull failure from the error message returned by the container runtime.
+// Messages vary by registry, so credential errors are checked first as some registries also report them as a missing repository.
+func classifyImagePullError(message string) ImagePullErrorKind {
+	message = strings.ToLower(message)
+
+	for _, s := range []string{"unauthorized", "authentication required", "no basic auth credentials", "denied", "403 forbidden"} {
+		if strings.Contains(message, s) {
+			return ImagePullErrorKind_Unauthorized
+		}
+	}
+
+	for _, s := range []string{"not found", "manifest unknown", "does not exist", "name unknown"} {
+		if strings.Contains(message, s) {
+			return ImagePullErrorKind_NotFound
+		}
+	}
+
+	return ImagePullErrorKind_Unknown
+}
+
+// timeFromK8s returns nil for unset kubernetes timestamps, so that they are serialized as null rather than the zero time
+func timeFromK8s(t *metav1.Time) *time.Time {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	res := t.Time
+	return &res
+}
+
+// AttachContainerUsage sets the resource usage of each of the pod's containers from the metrics reported for the pod
+func (p *PodStatus) AttachContainerUsage(podMetrics kubernetes.PodMetrics) {
+	usageByContainer := make(map[string]ContainerUsage, len(podMetrics.Containers))
+	for _, container := range podMetrics.Containers {
+		usageByContainer[container.Name] = ContainerUsage{
+			CPUMillicores: container.Usage.Cpu().MilliValue(),
+			MemoryBytes:   container.Usage.Memory().Value(),
+		}
+	}
+
+	for i := range p.Containers {
+		if usage, ok := usageByContainer[p.Containers[i].Name]; ok {
+			usage := usage
+			p.Containers[i].Usage = &usage
+		}
+	}
+}
+
+// RecentPodEvents summarizes kubernetes events into PodEvents, returning at most limit events with the most recent first
+func RecentPodEvents(events []v1.Event, limit int) []PodEvent {
+	podEvents := make([]PodEvent, 0, len(events))
+
+	for _, event := range events {
+		podEvents = append(podEvents, PodEvent{
+			Reason:    event.Reason,
+			Message:   event.Message,
+			Timestamp: eventTimestamp(event),
+		})
+	}
+
+	sort.SliceStable(podEvents, func(i, j int) bool {
+		return podEvents[i].Timestamp.After(podEvents[j].Timestamp)
+	})
+
+	if limit > 0 && len(podEvents) > limit {
+		podEvents = podEvents[:limit]
+	}
+
+	return podEvents
+}
+
+// eventTimestamp returns the last time an event occurred, falling back through the timestamps which may not be set depending on the event source
+func eventTimestamp(event v1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"encoding/base64"
 	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
@@ -39,6 +41,55 @@ type Revision struct {
 	Env environment_groups.EnvironmentGroup `json:"env,omitempty"`
 	// AppInstanceID is the id of the app instance the revision is associated with
 	AppInstanceID uuid.UUID `json:"app_instance_id"`
+	// CommitSHA is the git commit the revision was built from. This is empty if the revision was not built from git, e.g. an image deploy
+	CommitSHA string `json:"commit_sha,omitempty"`
+	// Images maps service name to the image the service runs, so that clients do not need to decode the app proto to show it
+	Images map[string]ServiceImage `json:"images,omitempty"`
+	// Probes maps service name to the health check probes configured for the service
+	Probes map[string]ServiceProbes `json:"probes,omitempty"`
+	// Networking maps service name to how the service is exposed. Only web services are exposed, so other services have an empty networking section
+	Networking map[string]ServiceNetworking `json:"networking,omitempty"`
+	// IsCurrent is true if this is the revision the cluster control plane currently considers deployed to the deployment target. During a rollout,
+	// this may be an older revision than the latest. This is false for endpoints which do not check the current revision
+	IsCurrent bool `json:"is_current"`
+}
+
+// ServiceProbes are the health check probes configured for a service
+type ServiceProbes struct {
+	// Liveness is the liveness probe, or nil if the service has no liveness probe configured
+	Liveness *ProbeConfig `json:"liveness"`
+	// Readiness is the readiness probe, or nil if the service has no readiness probe configured
+	Readiness *ProbeConfig `json:"readiness"`
+}
+
+// ProbeConfig is the configuration of an http health check probe
+type ProbeConfig struct {
+	// Path is the http path requested by the probe
+	Path string `json:"path"`
+	// Port is the container port requested by the probe
+	Port int32 `json:"port"`
+	// InitialDelaySeconds is the delay before the first probe. This is nil if the revision does not set it, in which case the cluster default applies
+	InitialDelaySeconds *int32 `json:"initial_delay_seconds"`
+	// PeriodSeconds is the interval between probes. This is nil if the revision does not set it, in which case the cluster default applies
+	PeriodSeconds *int32 `json:"period_seconds"`
+}
+
+// ServiceNetworking describes how a service is exposed
+type ServiceNetworking struct {
+	// Port is the container port the service listens on. This is zero for services which are not web services
+	Port int32 `json:"port,omitempty"`
+	// Public is true if the service is exposed outside the cluster through an ingress. Private web services are only reachable within the cluster
+	Public bool `json:"public"`
+	// Hostnames are the custom domains routed to the service by its ingress. Domains generated by porter are not part of the revision, so are not included
+	Hostnames []string `json:"hostnames,omitempty"`
+}
+
+// ServiceImage is the image a service runs
+type ServiceImage struct {
+	// Repository is the image repository
+	Repository string `json:"repository"`
+	// Tag is the image tag
+	Tag string `json:"tag"`
 }
 
 // GetAppRevisionInput is the input struct for GetAppRevisions
@@ -86,6 +137,78 @@ func GetAppRevision(ctx context.Context, inp GetAppRevisionInput) (Revision, err
 	return revision, nil
 }
 
+// ErrRevisionNotFound is returned when a revision with the requested number does not exist
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ListAppRevisionsInput is the input struct for ListAppRevisions
+type ListAppRevisionsInput struct {
+	ProjectID          uint
+	AppID              uint
+	DeploymentTargetID string
+
+	CCPClient porterv1connect.ClusterControlPlaneServiceClient
+}
+
+// ListAppRevisions returns all revisions of an app in a deployment target
+func ListAppRevisions(ctx context.Context, inp ListAppRevisionsInput) ([]*porterv1.AppRevision, error) {
+	ctx, span := telemetry.NewSpan(ctx, "list-app-revisions")
+	defer span.End()
+
+	if inp.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "must provide a project id")
+	}
+	if inp.AppID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "must provide an app id")
+	}
+	if inp.DeploymentTargetID == "" {
+		return nil, telemetry.Error(ctx, span, nil, "must provide a deployment target id")
+	}
+
+	listAppRevisionsReq := connect.NewRequest(&porterv1.ListAppRevisionsRequest{
+		ProjectId:          int64(inp.ProjectID),
+		AppId:              int64(inp.AppID),
+		DeploymentTargetId: inp.DeploymentTargetID,
+	})
+
+	listAppRevisionsResp, err := inp.CCPClient.ListAppRevisions(ctx, listAppRevisionsReq)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing app revisions")
+	}
+	if listAppRevisionsResp == nil || listAppRevisionsResp.Msg == nil {
+		return nil, telemetry.Error(ctx, span, nil, "list app revisions response is nil")
+	}
+
+	appRevisions := listAppRevisionsResp.Msg.AppRevisions
+	if appRevisions == nil {
+		appRevisions = []*porterv1.AppRevision{}
+	}
+
+	return appRevisions, nil
+}
+
+// RevisionByNumber returns the revision with the given revision number, or ErrRevisionNotFound if none match
+func RevisionByNumber(appRevisions []*porterv1.AppRevision, revisionNumber uint64) (*porterv1.AppRevision, error) {
+	for _, revision := range appRevisions {
+		if revision != nil && revision.RevisionNumber == revisionNumber {
+			return revision, nil
+		}
+	}
+
+	return nil, ErrRevisionNotFound
+}
+
+// LatestRevisionNumber returns the highest revision number in the list, or 0 if the list is empty
+func LatestRevisionNumber(appRevisions []*porterv1.AppRevision) uint64 {
+	var latest uint64
+	for _, revision := range appRevisions {
+		if revision != nil && revision.RevisionNumber > latest {
+			latest = revision.RevisionNumber
+		}
+	}
+
+	return latest
+}
+
 // EncodedRevisionFromProto converts an AppRevision proto object into a Revision object
 func EncodedRevisionFromProto(ctx context.Context, appRevision *porterv1.AppRevision) (Revision, error) {
 	ctx, span := telemetry.NewSpan(ctx, "encoded-revision-from-proto")
@@ -115,10 +238,13 @@ func EncodedRevisionFromProto(ctx context.Context, appRevision *porterv1.AppRevi
 		_ = telemetry.Error(ctx, span, nil, "unknown revision type") // flagged as an error for visibility
 	}
 
-	appInstanceIdStr := appRevision.AppInstanceId
-	appInstanceId, err := uuid.Parse(appInstanceIdStr)
-	if err != nil {
-		return revision, telemetry.Error(ctx, span, err, "error parsing app instance id")
+	// freshly created revisions may not be associated with an app instance yet, in which case the app instance id is left as uuid.Nil
+	var appInstanceId uuid.UUID
+	if appInstanceIdStr := appRevision.AppInstanceId; appInstanceIdStr != "" {
+		appInstanceId, err = uuid.Parse(appInstanceIdStr)
+		if err != nil {
+			return revision, telemetry.Error(ctx, span, err, "error parsing app instance id")
+		}
 	}
 
 	revision = Revision{
@@ -130,11 +256,106 @@ func EncodedRevisionFromProto(ctx context.Context, appRevision *porterv1.AppRevi
 		UpdatedAt:          appRevision.UpdatedAt.AsTime(),
 		DeploymentTargetID: appRevision.DeploymentTargetId,
 		AppInstanceID:      appInstanceId,
+		CommitSHA:          commitSHAFromAppProto(appProto),
+		Images:             serviceImagesFromAppProto(appProto),
+		Probes:             serviceProbesFromAppProto(appProto),
+		Networking:         serviceNetworkingFromAppProto(appProto),
 	}
 
 	return revision, nil
 }
 
+// serviceImagesFromAppProto returns the image of each service in the app. The app contract currently defines a single image shared by
+// all services, but images are keyed by service so that services with their own images can be represented without a breaking change.
+func serviceImagesFromAppProto(appProto *porterv1.PorterApp) map[string]ServiceImage {
+	if appProto == nil || appProto.Image == nil {
+		return nil
+	}
+
+	appImage := ServiceImage{
+		Repository: appProto.Image.Repository,
+		Tag:        appProto.Image.Tag,
+	}
+
+	images := make(map[string]ServiceImage)
+	for name := range servicesByName(appProto) {
+		images[name] = appImage
+	}
+
+	return images
+}
+
+// serviceProbesFromAppProto returns the probes of each service in the app. Only web services can configure a health check, which the
+// app contract uses for both the liveness and readiness probes. The contract does not carry probe timings, so those are left nil.
+func serviceProbesFromAppProto(appProto *porterv1.PorterApp) map[string]ServiceProbes {
+	if appProto == nil {
+		return nil
+	}
+
+	probes := make(map[string]ServiceProbes)
+	for name, service := range servicesByName(appProto) {
+		healthCheck := service.GetWebConfig().GetHealthCheck()
+		if healthCheck == nil || !healthCheck.Enabled {
+			probes[name] = ServiceProbes{}
+			continue
+		}
+
+		probe := ProbeConfig{
+			Path: healthCheck.HttpPath,
+			Port: service.Port,
+		}
+		liveness, readiness := probe, probe
+		probes[name] = ServiceProbes{
+			Liveness:  &liveness,
+			Readiness: &readiness,
+		}
+	}
+
+	return probes
+}
+
+// serviceNetworkingFromAppProto returns how each service in the app is exposed. Services which are not web services are not exposed, so
+// they have an empty networking section even if the service sets a port
+func serviceNetworkingFromAppProto(appProto *porterv1.PorterApp) map[string]ServiceNetworking {
+	if appProto == nil {
+		return nil
+	}
+
+	networking := make(map[string]ServiceNetworking)
+	for name, service := range servicesByName(appProto) {
+		webConfig := service.GetWebConfig()
+		if webConfig == nil && service.Type != porterv1.ServiceType_SERVICE_TYPE_WEB {
+			networking[name] = ServiceNetworking{}
+			continue
+		}
+
+		// web services are public unless explicitly marked private
+		serviceNetworking := ServiceNetworking{
+			Port:   service.Port,
+			Public: !webConfig.GetPrivate(),
+		}
+		if serviceNetworking.Public {
+			for _, domain := range webConfig.GetDomains() {
+				if domain.GetName() != "" {
+					serviceNetworking.Hostnames = append(serviceNetworking.Hostnames, domain.GetName())
+				}
+			}
+		}
+
+		networking[name] = serviceNetworking
+	}
+
+	return networking
+}
+
+// commitSHAFromAppProto returns the commit sha the app was built from, or an empty string if the app is not built from git.
+func commitSHAFromAppProto(appProto *porterv1.PorterApp) string {
+	if appProto == nil || appProto.Build == nil {
+		return ""
+	}
+	return appProto.Build.CommitSha
+}
+
 // AttachEnvToRevisionInput is the input struct for AttachEnvToRevision
 type AttachEnvToRevisionInput struct {
 	ProjectID           uint
@@ -202,6 +423,20 @@ func AttachEnvToRevision(ctx context.Context, inp AttachEnvToRevisionInput) (Rev
 	return revision, nil
 }
 
+// ParseAppRevisionStatus returns the app revision status with the given name, or an error if the status is not known
+func ParseAppRevisionStatus(status string) (models.AppRevisionStatus, error) {
+	if status == string(models.AppRevisionStatus_Unknown) {
+		return models.AppRevisionStatus_Unknown, nil
+	}
+	return appRevisionStatusFromProto(status)
+}
+
+// RevisionStatusFromProto returns the status of a revision proto, or AppRevisionStatus_Unknown if the status is not known
+func RevisionStatusFromProto(appRevision *porterv1.AppRevision) models.AppRevisionStatus {
+	status, _ := appRevisionStatusFromProto(appRevision.GetStatus())
+	return status
+}
+
 func appRevisionStatusFromProto(status string) (models.AppRevisionStatus, error) {
 	appRevisionStatus := models.AppRevisionStatus_Unknown
 	switch status {
@@ -244,3 +479,128 @@ func appRevisionStatusFromProto(status string) (models.AppRevisionStatus, error)
 
 	return appRevisionStatus, nil
 }
+
+// imageDigestPrefix is the prefix of an image digest, as opposed to a tag
+const imageDigestPrefix = "sha256:"
+
+// RevisionsByImageTag returns the revisions whose app image matches the given tag, newest first.
+// Digests (sha256:...) must match exactly, while tags match if the image tag contains the query, so that e.g. a short commit sha finds the revision.
+func RevisionsByImageTag(appRevisions []*porterv1.AppRevision, imageTag string) []*porterv1.AppRevision {
+	matches := make([]*porterv1.AppRevision, 0)
+	if imageTag == "" {
+		return matches
+	}
+
+	for _, revision := range appRevisions {
+		if revision == nil || revision.App == nil || revision.App.Image == nil {
+			continue
+		}
+
+		if imageTagMatches(revision.App.Image, imageTag) {
+			matches = append(matches, revision)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].RevisionNumber > matches[j].RevisionNumber
+	})
+
+	return matches
+}
+
+func imageTagMatches(image *porterv1.AppImage, imageTag string) bool {
+	if !strings.HasPrefix(imageTag, imageDigestPrefix) {
+		return strings.Contains(image.Tag, imageTag)
+	}
+
+	// digests may be stored as the tag itself or pinned on the repository, e.g. repo@sha256:...
+	if image.Tag == imageTag {
+		return true
+	}
+	_, repositoryDigest, found := strings.Cut(image.Repository, "@")
+	return found && repositoryDigest == imageTag
+}
+
+// RevisionsCreatedBetween returns the revisions created within the inclusive range [after, before], preserving their order.
+// A zero after or before leaves that end of the range unbounded.
+func RevisionsCreatedBetween(appRevisions []*porterv1.AppRevision, after, before time.Time) []*porterv1.AppRevision {
+	matches := make([]*porterv1.AppRevision, 0, len(appRevisions))
+
+	for _, revision := range appRevisions {
+		if revision == nil {
+			continue
+		}
+
+		createdAt := revision.CreatedAt.AsTime()
+		if !after.IsZero() && createdAt.Before(after) {
+			continue
+		}
+		if !before.IsZero() && createdAt.After(before) {
+			continue
+		}
+
+		matches = append(matches, revision)
+	}
+
+	return matches
+}
+
+// ServiceImageChange is a revision in which the image of a service changed
+type ServiceImageChange struct {
+	// AppRevisionID is the id of the revision
+	AppRevisionID string `json:"app_revision_id"`
+	// RevisionNumber is the number of the revision
+	RevisionNumber uint64 `json:"revision_number"`
+	// Image is the image the service runs as of the revision
+	Image ServiceImage `json:"image"`
+	// PreviousImage is the image the service ran in the revision before. This is nil if the service was added in the revision
+	PreviousImage *ServiceImage `json:"previous_image,omitempty"`
+	// DeployedAt is the time the revision was created
+	DeployedAt time.Time `json:"deployed_at"`
+}
+
+// ServiceImageHistory returns the revisions in which the image of the given service changed, newest first. A revision which adds the
+// service, including one which adds it back after it was removed, counts as a change. found is false if no revision contains the service.
+func ServiceImageHistory(appRevisions []*porterv1.AppRevision, serviceName string) (history []ServiceImageChange, found bool) {
+	sorted := make([]*porterv1.AppRevision, 0, len(appRevisions))
+	for _, revision := range appRevisions {
+		if revision != nil {
+			sorted = append(sorted, revision)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].RevisionNumber < sorted[j].RevisionNumber
+	})
+
+	history = make([]ServiceImageChange, 0)
+
+	var previous *ServiceImage
+	for _, revision := range sorted {
+		image, ok := serviceImagesFromAppProto(revision.App)[serviceName]
+		if !ok {
+			previous = nil
+			continue
+		}
+		found = true
+
+		if previous == nil || *previous != image {
+			history = append(history, ServiceImageChange{
+				AppRevisionID:  revision.Id,
+				RevisionNumber: revision.RevisionNumber,
+				Image:          image,
+				PreviousImage:  previous,
+				DeployedAt:     revision.CreatedAt.AsTime(),
+			})
+		}
+
+		current := image
+		previous = &current
+	}
+
+	// reverse so that the most recent change is first
+	for i, j := 0, len(history)-1; i < j; i, j = i+1, j-1 {
+		history[i], history[j] = history[j], history[i]
+	}
+
+	return history, found
+}
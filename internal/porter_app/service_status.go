@@ -0,0 +1,277 @@
+package porter_app
+
+import (
+	"sort"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ServiceReplicaStatus is a summary of the replicas of a single service
+type ServiceReplicaStatus struct {
+	// Workloads is the number of deployments running the service. It is 0 until the service's deployment has been created
+	Workloads int `json:"workloads"`
+	// DesiredReplicas is the number of replicas the service's deployments are scaled to
+	DesiredReplicas int `json:"desired_replicas"`
+	// ReadyReplicas is the number of the service's pods which are running and passing readiness checks
+	ReadyReplicas int `json:"ready_replicas"`
+}
+
+// IsReady returns true if the service has been deployed and all of its desired replicas are ready.
+// A service without workloads is not ready, since its deployment has not been created yet
+func (s ServiceReplicaStatus) IsReady() bool {
+	return s.Workloads > 0 && s.ReadyReplicas >= s.DesiredReplicas
+}
+
+// ServiceReplicaStatusFromK8s returns the replica status of a service from its deployments and pods
+func ServiceReplicaStatusFromK8s(deployments []appsv1.Deployment, pods []v1.Pod) ServiceReplicaStatus {
+	status := ServiceReplicaStatus{
+		Workloads: len(deployments),
+	}
+
+	for _, deployment := range deployments {
+		if deployment.Spec.Replicas == nil {
+			// kubernetes defaults an unset replica count to 1
+			status.DesiredReplicas++
+			continue
+		}
+		status.DesiredReplicas += int(*deployment.Spec.Replicas)
+	}
+
+	for _, pod := range pods {
+		if IsPodReady(pod) {
+			status.ReadyReplicas++
+		}
+	}
+
+	return status
+}
+
+// IsPodReady returns true if the pod is running, not being deleted, and passing its readiness checks
+func IsPodReady(pod v1.Pod) bool {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != v1.PodRunning {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+// LongRunningServiceNames returns the sorted names of the app's services which run continuously, i.e. all services except jobs
+func LongRunningServiceNames(app *porterv1.PorterApp) []string {
+	names := make([]string, 0)
+	if app == nil {
+		return names
+	}
+
+	for name, service := range servicesByName(app) {
+		if service.Type == porterv1.ServiceType_SERVICE_TYPE_JOB {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// RolloutStatus is the overall health of a rollout
+type RolloutStatus string
+
+const (
+	// RolloutStatus_Healthy indicates that all desired replicas are ready
+	RolloutStatus_Healthy RolloutStatus = "healthy"
+	// RolloutStatus_Progressing indicates that replicas are still becoming ready
+	RolloutStatus_Progressing RolloutStatus = "progressing"
+	// RolloutStatus_Failed indicates that replicas are failing to start, e.g. due to a crash loop or an image pull error
+	RolloutStatus_Failed RolloutStatus = "failed"
+)
+
+// failedContainerReasons are the container waiting reasons which indicate that a rollout will not succeed without intervention
+var failedContainerReasons = map[string]bool{
+	"CrashLoopBackOff":  true,
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// ServiceRolloutStatus is the rollout status of a single service
+type ServiceRolloutStatus struct {
+	ServiceReplicaStatus
+	// Status is the rollout status of the service
+	Status RolloutStatus `json:"status"`
+	// FailureReason is the container waiting reason which caused the service to be marked as failed, e.g. CrashLoopBackOff
+	FailureReason string `json:"failure_reason,omitempty"`
+	// Progress is the progress of the service's workloads towards their latest spec
+	Progress RolloutProgress `json:"progress"`
+}
+
+// ServiceRolloutStatusFromK8s returns the rollout status of a service from its deployments and pods.
+// A service is failed if any of its pods has a container waiting on a crash loop or image pull error.
+func ServiceRolloutStatusFromK8s(deployments []appsv1.Deployment, pods []v1.Pod) ServiceRolloutStatus {
+	status := ServiceRolloutStatus{
+		ServiceReplicaStatus: ServiceReplicaStatusFromK8s(deployments, pods),
+		Status:               RolloutStatus_Progressing,
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, containers := range [][]v1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+			for _, container := range containers {
+				if container.State.Waiting != nil && failedContainerReasons[container.State.Waiting.Reason] {
+					status.Status = RolloutStatus_Failed
+					status.FailureReason = container.State.Waiting.Reason
+					return status
+				}
+			}
+		}
+	}
+
+	if status.IsReady() {
+		status.Status = RolloutStatus_Healthy
+	}
+
+	return status
+}
+
+// OverallRolloutStatus returns the rollout status of an app from the status of its services. The app is failed if any service
+// is failed, healthy if every service is healthy, and progressing otherwise.
+func OverallRolloutStatus(services map[string]ServiceRolloutStatus) RolloutStatus {
+	overall := RolloutStatus_Healthy
+
+	for _, service := range services {
+		switch service.Status {
+		case RolloutStatus_Failed:
+			return RolloutStatus_Failed
+		case RolloutStatus_Progressing:
+			overall = RolloutStatus_Progressing
+		}
+	}
+
+	return overall
+}
+
+// RolloutPhase is the phase of a rollout of a single service
+type RolloutPhase string
+
+const (
+	// RolloutPhase_Waiting indicates that none of the service's replicas have been updated yet
+	RolloutPhase_Waiting RolloutPhase = "waiting"
+	// RolloutPhase_Progressing indicates that the service's replicas are being updated
+	RolloutPhase_Progressing RolloutPhase = "progressing"
+	// RolloutPhase_Complete indicates that all desired replicas are updated and available, and no old replicas remain
+	RolloutPhase_Complete RolloutPhase = "complete"
+)
+
+// RolloutProgress is the progress of a rollout of a single service, for display as a progress bar
+type RolloutProgress struct {
+	// Percent is the percentage of desired replicas which have been updated, from 0 to 100.
+	// It is only 100 once the rollout is complete, so that a rollout waiting on old replicas to terminate is not shown as finished
+	Percent int `json:"percent"`
+	// Phase is the phase of the rollout
+	Phase RolloutPhase `json:"phase"`
+	// UpdatedReplicas is the number of replicas running the latest spec of the service's workloads
+	UpdatedReplicas int `json:"updated_replicas"`
+	// DesiredReplicas is the number of replicas the service's workloads are scaled to
+	DesiredReplicas int `json:"desired_replicas"`
+}
+
+// workloadRollout is the rollout state of a single deployment or statefulset
+type workloadRollout struct {
+	desired   int
+	updated   int
+	available int
+	total     int
+	// observed is false if the controller has not yet observed the latest generation of the workload, in which case the status describes the previous spec
+	observed bool
+}
+
+// RolloutProgressFromK8s returns the rollout progress of a service from the status of its deployments and statefulsets.
+// If a controller has not yet observed the latest generation of one of the workloads, its replicas are counted as not updated
+// and the rollout is reported as progressing, since the status still describes the previous spec.
+func RolloutProgressFromK8s(deployments []appsv1.Deployment, statefulSets []appsv1.StatefulSet) RolloutProgress {
+	var rollouts []workloadRollout
+	for _, deployment := range deployments {
+		rollouts = append(rollouts, workloadRollout{
+			desired:   desiredReplicas(deployment.Spec.Replicas),
+			updated:   int(deployment.Status.UpdatedReplicas),
+			available: int(deployment.Status.AvailableReplicas),
+			total:     int(deployment.Status.Replicas),
+			observed:  deployment.Status.ObservedGeneration >= deployment.Generation,
+		})
+	}
+	for _, statefulSet := range statefulSets {
+		rollouts = append(rollouts, workloadRollout{
+			desired:   desiredReplicas(statefulSet.Spec.Replicas),
+			updated:   int(statefulSet.Status.UpdatedReplicas),
+			available: int(statefulSet.Status.AvailableReplicas),
+			total:     int(statefulSet.Status.Replicas),
+			observed:  statefulSet.Status.ObservedGeneration >= statefulSet.Generation,
+		})
+	}
+
+	progress := RolloutProgress{Phase: RolloutPhase_Waiting}
+	if len(rollouts) == 0 {
+		return progress
+	}
+
+	complete := true
+	unobserved := false
+	for _, rollout := range rollouts {
+		progress.DesiredReplicas += rollout.desired
+
+		if !rollout.observed {
+			unobserved = true
+			complete = false
+			continue
+		}
+
+		// replicas beyond the desired count, e.g. surge replicas, do not count towards progress
+		if rollout.updated > rollout.desired {
+			rollout.updated = rollout.desired
+		}
+		progress.UpdatedReplicas += rollout.updated
+
+		if rollout.updated < rollout.desired || rollout.available < rollout.desired || rollout.total > rollout.updated {
+			complete = false
+		}
+	}
+
+	switch {
+	case complete:
+		progress.Phase = RolloutPhase_Complete
+		progress.Percent = 100
+		return progress
+	case unobserved || progress.UpdatedReplicas > 0:
+		progress.Phase = RolloutPhase_Progressing
+	}
+
+	if progress.DesiredReplicas > 0 {
+		progress.Percent = progress.UpdatedReplicas * 100 / progress.DesiredReplicas
+	}
+	if progress.Percent > 99 {
+		progress.Percent = 99
+	}
+	if unobserved {
+		progress.Percent = 0
+	}
+
+	return progress
+}
+
+// desiredReplicas returns the replica count of a workload spec, which kubernetes defaults to 1 if unset
+func desiredReplicas(replicas *int32) int {
+	if replicas == nil {
+		return 1
+	}
+	return int(*replicas)
+}
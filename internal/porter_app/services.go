@@ -0,0 +1,78 @@
+package porter_app
+
+import (
+	"sort"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+)
+
+// ServiceSummary is a lightweight description of a service of an app, for clients which do not need the full app config
+type ServiceSummary struct {
+	// Name is the name of the service
+	Name string `json:"name"`
+	// Type is the type of the service, one of web, worker or job
+	Type string `json:"type"`
+	// Instances is the configured number of replicas of the service. This is 0 for jobs
+	Instances int32 `json:"instances"`
+	// Autoscaling is the replica range of the service if autoscaling is enabled, in which case the number of replicas may differ from Instances
+	Autoscaling *ServiceAutoscaling `json:"autoscaling,omitempty"`
+}
+
+// ServiceAutoscaling is the replica range of an autoscaled service
+type ServiceAutoscaling struct {
+	// MinInstances is the minimum number of replicas
+	MinInstances int32 `json:"min_instances"`
+	// MaxInstances is the maximum number of replicas
+	MaxInstances int32 `json:"max_instances"`
+}
+
+// serviceTypeNames are the names of service types, matching the types used in porter.yaml
+var serviceTypeNames = map[porterv1.ServiceType]string{
+	porterv1.ServiceType_SERVICE_TYPE_WEB:    "web",
+	porterv1.ServiceType_SERVICE_TYPE_WORKER: "worker",
+	porterv1.ServiceType_SERVICE_TYPE_JOB:    "job",
+}
+
+// ServiceSummariesFromAppProto returns a summary of each service of an app, sorted by name
+func ServiceSummariesFromAppProto(app *porterv1.PorterApp) []ServiceSummary {
+	summaries := make([]ServiceSummary, 0)
+	if app == nil {
+		return summaries
+	}
+
+	for name, service := range servicesByName(app) {
+		summary := ServiceSummary{
+			Name: name,
+			Type: serviceTypeNames[service.Type],
+		}
+
+		if service.Type != porterv1.ServiceType_SERVICE_TYPE_JOB {
+			summary.Instances = service.Instances // nolint:staticcheck // older revisions only set the deprecated field
+			if service.InstancesOptional != nil {
+				summary.Instances = *service.InstancesOptional
+			}
+		}
+
+		var autoscaling *porterv1.Autoscaling
+		switch service.Type {
+		case porterv1.ServiceType_SERVICE_TYPE_WEB:
+			autoscaling = service.GetWebConfig().GetAutoscaling()
+		case porterv1.ServiceType_SERVICE_TYPE_WORKER:
+			autoscaling = service.GetWorkerConfig().GetAutoscaling()
+		}
+		if autoscaling != nil && autoscaling.Enabled {
+			summary.Autoscaling = &ServiceAutoscaling{
+				MinInstances: autoscaling.MinInstances,
+				MaxInstances: autoscaling.MaxInstances,
+			}
+		}
+
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
@@ -0,0 +1,32 @@
+package test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestBuildLogLinesFromEventMetadata(t *testing.T) {
+	is := is.New(t)
+
+	metadata := map[string]any{
+		"errors": map[string]any{
+			"build-error":    "exit status 1",
+			"b64-build-logs": base64.StdEncoding.EncodeToString([]byte("step 1/2\r\nstep 2/2\nerror: npm ci failed\n")),
+		},
+	}
+
+	lines, err := porter_app.BuildLogLinesFromEventMetadata(metadata)
+	is.NoErr(err)
+	is.Equal(lines, []string{"step 1/2", "step 2/2", "error: npm ci failed"})
+
+	// successful builds do not report logs
+	lines, err = porter_app.BuildLogLinesFromEventMetadata(map[string]any{"end_time": "2024-01-01T00:00:00Z"})
+	is.NoErr(err)
+	is.Equal(len(lines), 0)
+
+	_, err = porter_app.BuildLogLinesFromEventMetadata(map[string]any{"errors": map[string]any{"b64-build-logs": "not base64!"}})
+	is.True(err != nil)
+}
@@ -0,0 +1,147 @@
+package test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	oldApp := &porterv1.PorterApp{
+		Name:  "test-app",
+		Image: &porterv1.AppImage{Repository: "nginx", Tag: "1.0.0"},
+		ServiceList: []*porterv1.Service{
+			{Name: "web", Port: 8080, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+			{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+		},
+	}
+	newApp := &porterv1.PorterApp{
+		Name:  "test-app",
+		Image: &porterv1.AppImage{Repository: "nginx", Tag: "1.1.0"},
+		ServiceList: []*porterv1.Service{
+			{Name: "web", Port: 3000, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+			{Name: "cron", Type: porterv1.ServiceType_SERVICE_TYPE_JOB},
+		},
+	}
+
+	oldRevision := revisionFromApp(t, oldApp)
+	oldRevision.Env = environment_groups.EnvironmentGroup{
+		Variables:       map[string]string{"PORT": "8080", "REMOVED": "true"},
+		SecretVariables: map[string]string{"API_KEY": environment_groups.EnvGroupSecretDummyValue},
+	}
+	newRevision := revisionFromApp(t, newApp)
+	newRevision.Env = environment_groups.EnvironmentGroup{
+		Variables:       map[string]string{"PORT": "3000"},
+		SecretVariables: map[string]string{"API_KEY": environment_groups.EnvGroupSecretDummyValue, "DB_PASSWORD": environment_groups.EnvGroupSecretDummyValue},
+	}
+
+	diff, err := porter_app.DiffRevisions(ctx, oldRevision, newRevision)
+	is.NoErr(err)
+
+	is.Equal(diff.Env, []porter_app.KeyDiff{
+		{Key: "PORT", Type: porter_app.DiffType_Changed, OldValue: "8080", NewValue: "3000"},
+		{Key: "REMOVED", Type: porter_app.DiffType_Removed, OldValue: "true"},
+		{Key: "DB_PASSWORD", Type: porter_app.DiffType_Added, Redacted: true},
+	})
+
+	is.Equal(len(diff.Services), 3)
+	is.Equal(diff.Services[0].Name, "cron")
+	is.Equal(diff.Services[0].Type, porter_app.DiffType_Added)
+	is.Equal(diff.Services[1].Name, "web")
+	is.Equal(diff.Services[1].Type, porter_app.DiffType_Changed)
+	is.Equal(diff.Services[1].Fields, []porter_app.KeyDiff{{Key: "port", Type: porter_app.DiffType_Changed, OldValue: "8080", NewValue: "3000"}})
+	is.Equal(diff.Services[2].Name, "worker")
+	is.Equal(diff.Services[2].Type, porter_app.DiffType_Removed)
+
+	is.Equal(diff.Image, []porter_app.KeyDiff{{Key: "tag", Type: porter_app.DiffType_Changed, OldValue: "1.0.0", NewValue: "1.1.0"}})
+
+	is.Equal(diff.Summary(1), porter_app.RevisionChangeSummary{
+		HasPreviousRevision:    true,
+		PreviousRevisionNumber: 1,
+		ImagesChanged:          true,
+		EnvChanged:             true,
+		ServicesChanged:        true,
+	})
+
+	noop, err := porter_app.DiffRevisions(ctx, newRevision, newRevision)
+	is.NoErr(err)
+	is.True(noop.IsEmpty())
+	is.Equal(noop.Summary(1), porter_app.RevisionChangeSummary{HasPreviousRevision: true, PreviousRevisionNumber: 1})
+}
+
+func TestRevisionDiffOnlyImageTagDiffers(t *testing.T) {
+	is := is.New(t)
+
+	tagOnly := porter_app.RevisionDiff{Image: []porter_app.KeyDiff{{Key: "tag", Type: porter_app.DiffType_Changed, OldValue: "staging", NewValue: "production"}}}
+	is.True(tagOnly.OnlyImageTagDiffers())
+
+	repository := porter_app.RevisionDiff{Image: []porter_app.KeyDiff{{Key: "repository", Type: porter_app.DiffType_Changed, OldValue: "nginx", NewValue: "httpd"}}}
+	is.True(!repository.OnlyImageTagDiffers())
+
+	tagAndEnv := tagOnly
+	tagAndEnv.Env = []porter_app.KeyDiff{{Key: "PORT", Type: porter_app.DiffType_Changed, OldValue: "8080", NewValue: "3000"}}
+	is.True(!tagAndEnv.OnlyImageTagDiffers())
+
+	is.True(!porter_app.RevisionDiff{}.OnlyImageTagDiffers())
+}
+
+func TestChangedServiceNames(t *testing.T) {
+	is := is.New(t)
+
+	oldApp := &porterv1.PorterApp{
+		Name:      "test-app",
+		Image:     &porterv1.AppImage{Repository: "nginx", Tag: "1.0.0"},
+		EnvGroups: []*porterv1.EnvGroup{{Name: "shared", Version: 1}},
+		ServiceList: []*porterv1.Service{
+			{Name: "web", Port: 8080, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+			{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			{Name: "removed", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+		},
+	}
+	newApp := &porterv1.PorterApp{
+		Name:      "test-app",
+		Image:     &porterv1.AppImage{Repository: "nginx", Tag: "1.0.0"},
+		EnvGroups: []*porterv1.EnvGroup{{Name: "shared", Version: 1}},
+		ServiceList: []*porterv1.Service{
+			{Name: "web", Port: 3000, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+			{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			{Name: "added", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+		},
+	}
+
+	changed, err := porter_app.ChangedServiceNames(oldApp, newApp)
+	is.NoErr(err)
+	is.Equal(changed, []string{"added", "web"})
+
+	unchanged, err := porter_app.ChangedServiceNames(newApp, newApp)
+	is.NoErr(err)
+	is.Equal(unchanged, []string{})
+
+	newApp.EnvGroups = []*porterv1.EnvGroup{{Name: "shared", Version: 2}}
+	envGroupChanged, err := porter_app.ChangedServiceNames(oldApp, newApp)
+	is.NoErr(err)
+	is.Equal(envGroupChanged, []string{"added", "web", "worker"})
+
+	_, err = porter_app.ChangedServiceNames(nil, newApp)
+	is.True(err != nil)
+}
+
+func revisionFromApp(t *testing.T, app *porterv1.PorterApp) porter_app.Revision {
+	t.Helper()
+
+	by, err := helpers.MarshalContractObject(context.Background(), app)
+	if err != nil {
+		t.Fatalf("error marshalling app: %s", err)
+	}
+
+	return porter_app.Revision{B64AppProto: base64.StdEncoding.EncodeToString(by)}
+}
@@ -0,0 +1,25 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestMaskedEnvVariables(t *testing.T) {
+	is := is.New(t)
+
+	variables := porter_app.MaskedEnvVariables(environment_groups.EnvironmentGroup{
+		Variables:       map[string]string{"PORT": "8080", "LOG_LEVEL": "info", "API_KEY": "placeholder"},
+		SecretVariables: map[string]string{"DATABASE_URL": "postgres://user:password@db", "API_KEY": "secret"},
+	})
+
+	is.Equal(variables, []porter_app.EnvVariable{
+		{Key: "API_KEY", Value: porter_app.MaskedSecretValue, IsSecret: true},
+		{Key: "DATABASE_URL", Value: porter_app.MaskedSecretValue, IsSecret: true},
+		{Key: "LOG_LEVEL", Value: "info"},
+		{Key: "PORT", Value: "8080"},
+	})
+}
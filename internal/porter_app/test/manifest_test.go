@@ -0,0 +1,72 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+const testManifest = `---
+# Source: app/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: web-env
+data:
+  API_KEY: c2VjcmV0
+stringData:
+  DATABASE_URL: postgres://user:password@db
+---
+# Source: app/templates/empty.yaml
+---
+# Source: app/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 2
+  template:
+    metadata:
+      labels:
+        porter.run/app-revision-id: 4f3c2a1b
+`
+
+func TestManifestObjects(t *testing.T) {
+	is := is.New(t)
+
+	objects, err := porter_app.ManifestObjects(testManifest, false)
+	is.NoErr(err)
+	is.Equal(len(objects), 2) // the document with only a comment is skipped
+
+	is.Equal(objects[0]["kind"], "Secret")
+	is.Equal(objects[0]["data"], map[string]interface{}{"API_KEY": porter_app.RedactedSecretValue})
+	is.Equal(objects[0]["stringData"], map[string]interface{}{"DATABASE_URL": porter_app.RedactedSecretValue})
+	is.Equal(objects[1]["kind"], "Deployment")
+
+	objects, err = porter_app.ManifestObjects(testManifest, true)
+	is.NoErr(err)
+	is.Equal(objects[0]["data"], map[string]interface{}{"API_KEY": "c2VjcmV0"})
+
+	encoded, err := porter_app.ManifestYAML(objects)
+	is.NoErr(err)
+	is.Equal(strings.Count(string(encoded), "---\n"), 2)
+	is.True(strings.Contains(string(encoded), "replicas: 2"))
+
+	_, err = porter_app.ManifestObjects("kind: [", false)
+	is.True(err != nil)
+}
+
+func TestManifestHasLabel(t *testing.T) {
+	is := is.New(t)
+
+	objects, err := porter_app.ManifestObjects(testManifest, false)
+	is.NoErr(err)
+
+	is.True(porter_app.ManifestHasLabel(objects, "porter.run/app-revision-id", "4f3c2a1b"))  // label of the pod template
+	is.True(!porter_app.ManifestHasLabel(objects, "porter.run/app-revision-id", "9e8d7c6b")) // label of another revision
+	is.True(!porter_app.ManifestHasLabel(objects, "porter.run/app-name", "4f3c2a1b"))
+	is.True(!porter_app.ManifestHasLabel(nil, "porter.run/app-revision-id", "4f3c2a1b"))
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app/notifications"
+)
+
+func TestResolveNotifications(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	crash := func(serviceName string, at time.Time, resolved bool) notifications.Notification {
+		return notifications.Notification{
+			Scope:         notifications.Scope_Service,
+			AppRevisionID: "revision",
+			Timestamp:     at,
+			Metadata:      notifications.Metadata{ServiceName: serviceName},
+			Resolved:      resolved,
+		}
+	}
+
+	// most recent first, as returned by the repository
+	res := []notifications.Notification{
+		crash("web", now.Add(3*time.Minute), false),
+		crash("web", now.Add(2*time.Minute), true),
+		crash("web", now.Add(time.Minute), false),
+		crash("worker", now, false),
+	}
+	notifications.ResolveNotifications(res)
+
+	is.True(!res[0].Resolved) // web crashed again after recovering
+	is.True(res[1].Resolved)
+	is.True(res[2].Resolved)  // cleared by the later recovery of web
+	is.True(!res[3].Resolved) // the recovery of web does not clear other services
+}
+
+func TestDedupNotifications(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	probeFailure := func(serviceName string, at time.Time, severity notifications.Severity) notifications.Notification {
+		return notifications.Notification{
+			Scope:         notifications.Scope_Service,
+			AppRevisionID: "revision",
+			Timestamp:     at,
+			Metadata:      notifications.Metadata{ServiceName: serviceName},
+			Error:         notifications.PorterError{Code: 1},
+			Severity:      severity,
+		}
+	}
+
+	// most recent first, as returned by the repository
+	res := notifications.DedupNotifications([]notifications.Notification{
+		probeFailure("web", now.Add(40*time.Minute), notifications.Severity_Warning),
+		probeFailure("web", now.Add(10*time.Minute), notifications.Severity_Warning),
+		probeFailure("worker", now.Add(6*time.Minute), notifications.Severity_Warning),
+		probeFailure("web", now.Add(5*time.Minute), notifications.Severity_Critical),
+		probeFailure("web", now, notifications.Severity_Warning),
+	}, 10*time.Minute)
+
+	is.Equal(len(res), 3)
+
+	is.Equal(res[0].OccurrenceCount, 1) // too long after the previous failure of web to be collapsed
+	is.True(res[0].FirstSeen.Equal(now.Add(40 * time.Minute)))
+
+	is.Equal(res[1].OccurrenceCount, 3)
+	is.Equal(res[1].Metadata.ServiceName, "web")
+	is.True(res[1].FirstSeen.Equal(now))
+	is.True(res[1].LastSeen.Equal(now.Add(10 * time.Minute)))
+	is.Equal(res[1].Severity, notifications.Severity_Critical)
+
+	is.Equal(res[2].Metadata.ServiceName, "worker") // other services are not collapsed
+	is.Equal(res[2].OccurrenceCount, 1)
+}
+
+func TestPaginateNotifications(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	probeFailure := func(serviceName string, at time.Time) notifications.Notification {
+		return notifications.Notification{
+			Scope:         notifications.Scope_Service,
+			AppRevisionID: "revision",
+			Timestamp:     at,
+			Metadata:      notifications.Metadata{ServiceName: serviceName},
+			Error:         notifications.PorterError{Code: 1},
+		}
+	}
+
+	// repeated failures of web would straddle the first two pages if they were paginated before being collapsed
+	collapsed := notifications.DedupNotifications([]notifications.Notification{
+		probeFailure("worker", now.Add(4*time.Minute)),
+		probeFailure("web", now.Add(3*time.Minute)),
+		probeFailure("web", now.Add(2*time.Minute)),
+		probeFailure("web", now.Add(time.Minute)),
+		probeFailure("cron", now),
+	}, 10*time.Minute)
+	is.Equal(len(collapsed), 3)
+
+	page := notifications.PaginateNotifications(collapsed, 2, 0)
+	is.Equal(len(page), 2)
+	is.Equal(page[0].Metadata.ServiceName, "worker")
+	is.Equal(page[1].Metadata.ServiceName, "web")
+	is.Equal(page[1].OccurrenceCount, 3)
+
+	page = notifications.PaginateNotifications(collapsed, 2, 2)
+	is.Equal(len(page), 1)
+	is.Equal(page[0].Metadata.ServiceName, "cron")
+
+	is.Equal(len(notifications.PaginateNotifications(collapsed, 0, 1)), 2)
+	is.Equal(len(notifications.PaginateNotifications(collapsed, 2, 3)), 0)
+}
+
+func TestNotificationsFromPorterAppEventsResolvesAcrossPages(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	event := func(serviceName string, at time.Time, status types.PorterAppEventStatus, code int) *models.PorterAppEvent {
+		return &models.PorterAppEvent{
+			Status: string(status),
+			Metadata: models.JSONB{
+				"scope":           string(notifications.Scope_Service),
+				"app_revision_id": "revision",
+				"timestamp":       at.Format(time.RFC3339Nano),
+				"metadata":        map[string]interface{}{"service_name": serviceName},
+				"error":           map[string]interface{}{"code": code},
+			},
+		}
+	}
+
+	// most recent first, as returned by the repository. The recovery of web is newer than the crash of web, which would be on the second page
+	res := notifications.NotificationsFromPorterAppEvents(context.Background(), []*models.PorterAppEvent{
+		event("web", now.Add(2*time.Hour), types.PorterAppEventStatus_Success, 2),
+		event("worker", now.Add(time.Hour), types.PorterAppEventStatus_Failed, 1),
+		event("web", now, types.PorterAppEventStatus_Failed, 1),
+	})
+	is.Equal(len(res), 3)
+
+	secondPage := notifications.PaginateNotifications(res, 2, 2)
+	is.Equal(len(secondPage), 1)
+	is.Equal(secondPage[0].Metadata.ServiceName, "web")
+	is.True(secondPage[0].Resolved) // cleared by the recovery of web on the first page
+	is.True(!res[1].Resolved)       // the recovery of web does not clear other services
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/matryer/is"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestPatchApp(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	app := &porterv1.PorterApp{
+		Name: "my-app",
+		Services: map[string]*porterv1.Service{ // nolint:staticcheck
+			"web": {Port: 8080, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+		},
+	}
+	env := environment_groups.EnvironmentGroup{
+		Variables:       map[string]string{"LOG_LEVEL": "info"},
+		SecretVariables: map[string]string{"API_KEY": "secret"},
+	}
+
+	patched, err := porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App: app,
+		Env: env,
+		Operations: []porter_app.AppConfigPatchOperation{
+			{Op: "replace", Path: "/env/LOG_LEVEL", Value: json.RawMessage(`"debug"`)},
+			{Op: "replace", Path: "/serviceList/0/port", Value: json.RawMessage(`3000`)},
+		},
+	})
+	is.NoErr(err)
+	is.Equal(patched.App.Name, "my-app")
+	is.Equal(len(patched.App.Env), 0)
+	is.Equal(len(patched.App.ServiceList), 1)
+	is.Equal(patched.App.ServiceList[0].Name, "web")
+	is.Equal(patched.App.ServiceList[0].Port, int32(3000))
+	is.Equal(patched.App.Services["web"].Port, int32(3000)) // nolint:staticcheck
+
+	// the original app and env are left unchanged
+	is.Equal(env.Variables["LOG_LEVEL"], "info")
+	is.Equal(len(app.ServiceList), 0)
+
+	_, err = porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App:        app,
+		Operations: []porter_app.AppConfigPatchOperation{{Op: "replace", Path: "/name", Value: json.RawMessage(`"other-app"`)}},
+	})
+	is.True(errors.Is(err, porter_app.ErrImmutableAppField))
+
+	_, err = porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App:        app,
+		Operations: []porter_app.AppConfigPatchOperation{{Op: "replace", Path: "", Value: json.RawMessage(`{"name": "other-app"}`)}},
+	})
+	is.True(errors.Is(err, porter_app.ErrImmutableAppField))
+
+	_, err = porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App:        app,
+		Operations: []porter_app.AppConfigPatchOperation{{Op: "remove", Path: "/serviceList/5"}},
+	})
+	is.True(errors.Is(err, porter_app.ErrInvalidAppPatch))
+
+	_, err = porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App:        app,
+		Operations: []porter_app.AppConfigPatchOperation{{Op: "add", Path: "/env/PORT", Value: json.RawMessage(`8080`)}},
+	})
+	is.True(errors.Is(err, porter_app.ErrInvalidAppPatch))
+}
+
+func TestPatchAppEnvApplyRequest(t *testing.T) {
+	is := is.New(t)
+	ctx := context.Background()
+
+	patched, err := porter_app.PatchApp(ctx, porter_app.PatchAppInput{
+		App: &porterv1.PorterApp{Name: "my-app"},
+		Env: environment_groups.EnvironmentGroup{
+			Variables:       map[string]string{"LOG_LEVEL": "info", "UNUSED": "true"},
+			SecretVariables: map[string]string{"API_KEY": "old-secret"},
+		},
+		Operations: []porter_app.AppConfigPatchOperation{
+			{Op: "replace", Path: "/env/LOG_LEVEL", Value: json.RawMessage(`"debug"`)},
+			{Op: "replace", Path: "/env/API_KEY", Value: json.RawMessage(`"new-secret"`)},
+			{Op: "add", Path: "/env/FEATURE_FLAG", Value: json.RawMessage(`"on"`)},
+			{Op: "remove", Path: "/env/UNUSED"},
+		},
+	})
+	is.NoErr(err)
+
+	applyReq := patched.ApplyRequest(1, "dt-id")
+	is.Equal(applyReq.ProjectId, int64(1))
+	is.Equal(applyReq.DeploymentTargetId, "dt-id")
+	is.Equal(applyReq.App.Name, "my-app")
+	// the complete env is submitted as a hard update so that removed variables are removed from the app
+	is.True(applyReq.IsHardEnvUpdate)
+	is.Equal(applyReq.AppEnv.Normal, map[string]string{"LOG_LEVEL": "debug", "FEATURE_FLAG": "on"})
+	is.Equal(applyReq.AppEnv.Secret, map[string]string{"API_KEY": "new-secret"})
+}
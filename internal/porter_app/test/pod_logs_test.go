@@ -0,0 +1,24 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestPodLogLineFromK8s(t *testing.T) {
+	is := is.New(t)
+
+	line := porter_app.PodLogLineFromK8s("2024-01-02T15:04:05.123456789Z starting server on :8080")
+	is.Equal(line.Timestamp, time.Date(2024, 1, 2, 15, 4, 5, 123456789, time.UTC))
+	is.Equal(line.Line, "starting server on :8080")
+
+	noTimestamp := porter_app.PodLogLineFromK8s("starting server")
+	is.True(noTimestamp.Timestamp.IsZero())
+	is.Equal(noTimestamp.Line, "starting server")
+
+	empty := porter_app.PodLogLineFromK8s("2024-01-02T15:04:05Z")
+	is.Equal(empty.Line, "")
+}
@@ -0,0 +1,376 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/porter_app"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testRevisionIDLabelKey = "porter.run/app-revision-id"
+
+func TestPodStatusFromPod(t *testing.T) {
+	is := is.New(t)
+
+	controlled := true
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-abc123",
+			Namespace:       "default",
+			Labels:          map[string]string{testRevisionIDLabelKey: "4f3c2a1b"},
+			Annotations:     map[string]string{"helm.sh/revision": "7"},
+			OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d4f8", Controller: &controlled}},
+		},
+		Spec: v1.PodSpec{
+			NodeName:   "node-1",
+			Containers: []v1.Container{{Name: "web"}, {Name: "sidecar"}},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:         "web",
+					Ready:        false,
+					RestartCount: 14,
+					State: v1.ContainerState{
+						Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting failed container"},
+					},
+				},
+				{
+					Name:  "sidecar",
+					Ready: true,
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+			},
+			InitContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "migrate",
+					Ready: true,
+					State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Completed"}},
+				},
+			},
+		},
+	}
+
+	got := porter_app.PodStatusFromPod(pod, testRevisionIDLabelKey)
+
+	is.Equal(got.Name, "web-abc123")
+	is.Equal(got.Phase, v1.PodRunning)
+	is.Equal(got.ReadyContainers, 1)
+	is.Equal(got.TotalContainers, 2)
+	is.Equal(got.NodeName, "node-1")
+	is.True(!got.Ready)
+	is.True(!got.Evicted)
+	is.Equal(got.ReplicaSet, "web-5d4f8")
+	is.Equal(got.RevisionID, "4f3c2a1b")
+	is.Equal(got.HelmRevision, "7")
+
+	is.Equal(len(got.Containers), 2)
+	is.Equal(got.Containers[0].State, porter_app.ContainerState_Waiting)
+	is.Equal(got.Containers[0].Reason, "CrashLoopBackOff")
+	is.Equal(got.Containers[0].RestartCount, int32(14))
+	is.Equal(got.Containers[1].State, porter_app.ContainerState_Running)
+
+	is.Equal(len(got.InitContainers), 1)
+	is.Equal(got.InitContainers[0].State, porter_app.ContainerState_Terminated)
+	is.Equal(got.InitContainers[0].Reason, "Completed")
+}
+
+func TestPodStatusFromPodEvicted(t *testing.T) {
+	is := is.New(t)
+
+	evicted := porter_app.PodStatusFromPod(v1.Pod{
+		Spec: v1.PodSpec{NodeName: "node-1"},
+		Status: v1.PodStatus{
+			Phase:   v1.PodFailed,
+			Reason:  "Evicted",
+			Message: "The node was low on resource: memory.",
+		},
+	}, testRevisionIDLabelKey)
+	is.True(evicted.Evicted)
+	is.Equal(evicted.NodeName, "node-1")
+	is.Equal(evicted.Reason, "Evicted")
+
+	unscheduled := porter_app.PodStatusFromPod(v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending}}, testRevisionIDLabelKey)
+	is.Equal(unscheduled.NodeName, "")
+	is.True(!unscheduled.Evicted)
+}
+
+func TestPodStatusFromPodStartTimes(t *testing.T) {
+	is := is.New(t)
+
+	created := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+	started := created.Add(5 * time.Second)
+	containerStarted := created.Add(30 * time.Second)
+
+	running := porter_app.PodStatusFromPod(v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+		Status: v1.PodStatus{
+			Phase:     v1.PodRunning,
+			StartTime: &metav1.Time{Time: started},
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", State: v1.ContainerState{Running: &v1.ContainerStateRunning{StartedAt: metav1.NewTime(containerStarted)}}},
+				{Name: "sidecar", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ContainerCreating"}}},
+			},
+		},
+	}, testRevisionIDLabelKey)
+	is.Equal(running.CreatedAt, created)
+	is.True(running.StartedAt != nil)
+	is.Equal(*running.StartedAt, started)
+	is.True(running.Containers[0].StartedAt != nil)
+	is.Equal(*running.Containers[0].StartedAt, containerStarted)
+	is.True(running.Containers[1].StartedAt == nil)
+
+	pending := porter_app.PodStatusFromPod(v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending}}, testRevisionIDLabelKey)
+	is.True(pending.StartedAt == nil)
+}
+
+func TestPodStatusFromPodLastTermination(t *testing.T) {
+	is := is.New(t)
+
+	finished := time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC)
+
+	status := porter_app.PodStatusFromPod(v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:                 "web",
+					RestartCount:         14,
+					State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+					LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137, FinishedAt: metav1.NewTime(finished), Message: "out of memory"}},
+				},
+				{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}, testRevisionIDLabelKey)
+
+	is.True(status.Containers[0].LastTermination != nil)
+	is.Equal(status.Containers[0].LastTermination.Reason, "OOMKilled")
+	is.Equal(status.Containers[0].LastTermination.ExitCode, int32(137))
+	is.Equal(*status.Containers[0].LastTermination.FinishedAt, finished)
+	is.Equal(status.Containers[0].LastTermination.Message, "out of memory")
+	is.True(status.Containers[1].LastTermination == nil)
+}
+
+func TestRecentPodEvents(t *testing.T) {
+	is := is.New(t)
+
+	now := time.Now()
+	events := []v1.Event{
+		{Reason: "Scheduled", LastTimestamp: metav1.NewTime(now.Add(-3 * time.Minute))},
+		{Reason: "FailedScheduling", LastTimestamp: metav1.NewTime(now.Add(-1 * time.Minute))},
+		{Reason: "Pulling", EventTime: metav1.NewMicroTime(now.Add(-2 * time.Minute))},
+	}
+
+	got := porter_app.RecentPodEvents(events, 2)
+
+	is.Equal(len(got), 2)
+	is.Equal(got[0].Reason, "FailedScheduling")
+	is.Equal(got[1].Reason, "Pulling")
+}
+
+func TestAttachContainerUsage(t *testing.T) {
+	is := is.New(t)
+
+	podStatus := porter_app.PodStatus{
+		Containers: []porter_app.ContainerStatus{{Name: "web"}, {Name: "sidecar"}},
+	}
+
+	podStatus.AttachContainerUsage(kubernetes.PodMetrics{
+		Containers: []kubernetes.ContainerMetrics{
+			{
+				Name: "web",
+				Usage: v1.ResourceList{
+					v1.ResourceCPU:    resource.MustParse("250m"),
+					v1.ResourceMemory: resource.MustParse("128Mi"),
+				},
+			},
+		},
+	})
+
+	is.True(podStatus.Containers[0].Usage != nil)
+	is.Equal(podStatus.Containers[0].Usage.CPUMillicores, int64(250))
+	is.Equal(podStatus.Containers[0].Usage.MemoryBytes, int64(128*1024*1024))
+	is.True(podStatus.Containers[1].Usage == nil)
+}
+
+func TestPodCountsByService(t *testing.T) {
+	is := is.New(t)
+
+	podFor := func(serviceName string, phase v1.PodPhase, ready bool) v1.Pod {
+		readyStatus := v1.ConditionFalse
+		if ready {
+			readyStatus = v1.ConditionTrue
+		}
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"porter.run/service-name": serviceName}},
+			Status: v1.PodStatus{
+				Phase:      phase,
+				Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: readyStatus}},
+			},
+		}
+	}
+
+	counts := porter_app.PodCountsByService([]v1.Pod{
+		podFor("web", v1.PodRunning, true),
+		podFor("web", v1.PodRunning, false),
+		podFor("web", v1.PodPending, false),
+		podFor("worker", v1.PodFailed, false),
+	}, "porter.run/service-name")
+
+	is.Equal(counts["web"], porter_app.PodCounts{Total: 3, Ready: 1, Running: 2, Pending: 1})
+	is.Equal(counts["worker"], porter_app.PodCounts{Total: 1, Failed: 1})
+}
+
+func TestSortPodStatusesByRestarts(t *testing.T) {
+	is := is.New(t)
+
+	podWithRestarts := func(name string, restarts ...int32) porter_app.PodStatus {
+		pod := porter_app.PodStatus{Name: name}
+		for _, count := range restarts {
+			pod.Containers = append(pod.Containers, porter_app.ContainerStatus{RestartCount: count})
+		}
+		return pod
+	}
+	names := func(pods []porter_app.PodStatus) []string {
+		var res []string
+		for _, pod := range pods {
+			res = append(res, pod.Name)
+		}
+		return res
+	}
+
+	flapping := podWithRestarts("flapping", 3, 4)
+	flapping.InitContainers = []porter_app.ContainerStatus{{RestartCount: 1}}
+	is.Equal(flapping.TotalRestarts(), int32(8))
+
+	pods := []porter_app.PodStatus{podWithRestarts("healthy", 0), flapping, podWithRestarts("restarted", 2), podWithRestarts("also-healthy", 0)}
+
+	porter_app.SortPodStatusesByRestarts(pods, true)
+	is.Equal(names(pods), []string{"flapping", "restarted", "healthy", "also-healthy"})
+
+	porter_app.SortPodStatusesByRestarts(pods, false)
+	is.Equal(names(pods), []string{"healthy", "also-healthy", "restarted", "flapping"})
+}
+
+func TestAttachController(t *testing.T) {
+	is := is.New(t)
+
+	controlled := true
+	ownedBy := func(name, ownerKind, ownerName string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{{Kind: ownerKind, Name: ownerName, Controller: &controlled}},
+		}
+	}
+
+	replicaSetOwners := porter_app.ReplicaSetOwners([]appsv1.ReplicaSet{
+		{ObjectMeta: ownedBy("web-5d4f8", "Deployment", "web")},
+		{ObjectMeta: metav1.ObjectMeta{Name: "orphan"}},
+	})
+	is.Equal(len(replicaSetOwners), 1)
+
+	tests := []struct {
+		pod  v1.Pod
+		kind string
+		name string
+	}{
+		{pod: v1.Pod{ObjectMeta: ownedBy("web-5d4f8-abcde", "ReplicaSet", "web-5d4f8")}, kind: "Deployment", name: "web"},
+		{pod: v1.Pod{ObjectMeta: ownedBy("web-7c9b2-fghij", "ReplicaSet", "web-7c9b2")}, kind: "ReplicaSet", name: "web-7c9b2"},
+		{pod: v1.Pod{ObjectMeta: ownedBy("db-0", "StatefulSet", "db")}, kind: "StatefulSet", name: "db"},
+		{pod: v1.Pod{ObjectMeta: ownedBy("migrate-x1y2z", "Job", "migrate")}, kind: "Job", name: "migrate"},
+		{pod: v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "bare"}}, kind: "", name: ""},
+	}
+
+	for _, test := range tests {
+		status := porter_app.PodStatusFromPod(test.pod, testRevisionIDLabelKey)
+		status.AttachController(test.pod, replicaSetOwners)
+		is.Equal(status.ControllerKind, test.kind)
+		is.Equal(status.ControllerName, test.name)
+	}
+}
+
+func TestIsCompletedJobPod(t *testing.T) {
+	is := is.New(t)
+
+	podFor := func(phase v1.PodPhase, ownerKind string) v1.Pod {
+		pod := v1.Pod{Status: v1.PodStatus{Phase: phase}}
+		if ownerKind != "" {
+			pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "owner"}}
+		}
+		return pod
+	}
+
+	is.True(porter_app.IsCompletedJobPod(podFor(v1.PodSucceeded, "Job")))
+	is.True(!porter_app.IsCompletedJobPod(podFor(v1.PodRunning, "Job")))
+	is.True(!porter_app.IsCompletedJobPod(podFor(v1.PodFailed, "Job")))
+	is.True(!porter_app.IsCompletedJobPod(podFor(v1.PodSucceeded, "ReplicaSet")))
+	is.True(!porter_app.IsCompletedJobPod(podFor(v1.PodSucceeded, "")))
+}
+
+func TestAttachImagePullErrors(t *testing.T) {
+	is := is.New(t)
+
+	podStatus := porter_app.PodStatusFromPod(v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: `Back-off pulling image "registry/app:v1"`}}},
+				{Name: "sidecar", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "manifest for registry/sidecar:v2 not found: manifest unknown"}}},
+				{Name: "proxy", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+			},
+		},
+	}, testRevisionIDLabelKey)
+	is.True(podStatus.HasImagePullErrors())
+	is.Equal(podStatus.Containers[0].PullErrorKind, porter_app.ImagePullErrorKind_Unknown)
+	is.Equal(podStatus.Containers[1].PullErrorKind, porter_app.ImagePullErrorKind_NotFound)
+	is.Equal(podStatus.Containers[2].PullErrorKind, porter_app.ImagePullErrorKind(""))
+
+	now := time.Now()
+	podStatus.AttachImagePullErrors([]v1.Event{
+		{
+			Reason:         "Failed",
+			Message:        `Failed to pull image "registry/app:v1": rpc error: code = Unknown desc = failed to resolve reference: 401 Unauthorized`,
+			InvolvedObject: v1.ObjectReference{FieldPath: "spec.containers{web}"},
+			LastTimestamp:  metav1.NewTime(now),
+		},
+		{
+			Reason:         "Failed",
+			Message:        "Error: ErrImagePull",
+			InvolvedObject: v1.ObjectReference{FieldPath: "spec.containers{web}"},
+			LastTimestamp:  metav1.NewTime(now.Add(time.Second)),
+		},
+	})
+	is.Equal(podStatus.Containers[0].PullErrorKind, porter_app.ImagePullErrorKind_Unauthorized)
+	is.True(strings.Contains(podStatus.Containers[0].Message, "401 Unauthorized"))
+	// containers without a matching event keep the classification from their waiting message
+	is.Equal(podStatus.Containers[1].PullErrorKind, porter_app.ImagePullErrorKind_NotFound)
+}
+
+func TestPodStatusProject(t *testing.T) {
+	is := is.New(t)
+
+	fields, err := porter_app.ParsePodStatusFields("name, phase,ready")
+	is.NoErr(err)
+	is.Equal(fields, []string{"name", "phase", "ready"})
+
+	_, err = porter_app.ParsePodStatusFields("name,restarts")
+	is.True(err != nil)
+	is.True(strings.Contains(err.Error(), "valid fields are"))
+	is.True(strings.Contains(err.Error(), "node_name"))
+
+	projected, err := porter_app.PodStatus{Name: "web-abc123", Phase: v1.PodRunning, NodeName: "node-1"}.Project([]string{"name", "phase", "ready", "events"})
+	is.NoErr(err)
+	is.Equal(len(projected), 3)
+	is.Equal(string(projected["name"]), `"web-abc123"`)
+	is.Equal(string(projected["phase"]), `"Running"`)
+	is.Equal(string(projected["ready"]), "false")
+}
@@ -0,0 +1,245 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/matryer/is"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestRevisionsByImageTag(t *testing.T) {
+	is := is.New(t)
+
+	revisionWithImage := func(number uint64, repository, tag string) *porterv1.AppRevision {
+		return &porterv1.AppRevision{
+			RevisionNumber: number,
+			App:            &porterv1.PorterApp{Image: &porterv1.AppImage{Repository: repository, Tag: tag}},
+		}
+	}
+	digest := "sha256:4f53cda18c2baa0c0354bb5f9a3ecbe5ed12ab4d8e11ba873c2f11161202b945"
+
+	revisions := []*porterv1.AppRevision{
+		revisionWithImage(1, "registry/app", "abc1234def"),
+		revisionWithImage(2, "registry/app", "v1.2.0"),
+		revisionWithImage(3, "registry/app", "abc1234def"),
+		revisionWithImage(4, "registry/app@"+digest, ""),
+		{RevisionNumber: 5},
+	}
+
+	tagMatches := porter_app.RevisionsByImageTag(revisions, "abc1234")
+	is.Equal(len(tagMatches), 2)
+	is.Equal(tagMatches[0].RevisionNumber, uint64(3))
+	is.Equal(tagMatches[1].RevisionNumber, uint64(1))
+
+	digestMatches := porter_app.RevisionsByImageTag(revisions, digest)
+	is.Equal(len(digestMatches), 1)
+	is.Equal(digestMatches[0].RevisionNumber, uint64(4))
+
+	is.Equal(len(porter_app.RevisionsByImageTag(revisions, digest[:20])), 0)
+}
+
+func TestParseAppRevisionStatus(t *testing.T) {
+	is := is.New(t)
+
+	status, err := porter_app.ParseAppRevisionStatus("DEPLOY_FAILED")
+	is.NoErr(err)
+	is.Equal(status, models.AppRevisionStatus_DeployFailed)
+
+	status, err = porter_app.ParseAppRevisionStatus("UNKNOWN")
+	is.NoErr(err)
+	is.Equal(status, models.AppRevisionStatus_Unknown)
+
+	_, err = porter_app.ParseAppRevisionStatus("deploy_failed")
+	is.True(err != nil)
+}
+
+func TestEncodedRevisionFromProtoImages(t *testing.T) {
+	is := is.New(t)
+
+	appRevision := &porterv1.AppRevision{
+		Id:            "a6b1f5b6-4a2e-4a4b-8a57-1f9a3c2b7d10",
+		AppInstanceId: "0f2d6a3c-9a64-4b8e-bf0e-5c3f1e2d4a6b",
+		App: &porterv1.PorterApp{
+			Name:  "test-app",
+			Image: &porterv1.AppImage{Repository: "registry/app", Tag: "v1.2.0"},
+			ServiceList: []*porterv1.Service{
+				{Name: "web", Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+				{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			},
+		},
+	}
+
+	revision, err := porter_app.EncodedRevisionFromProto(context.Background(), appRevision)
+	is.NoErr(err)
+	is.Equal(revision.Images, map[string]porter_app.ServiceImage{
+		"web":    {Repository: "registry/app", Tag: "v1.2.0"},
+		"worker": {Repository: "registry/app", Tag: "v1.2.0"},
+	})
+}
+
+func TestEncodedRevisionFromProtoWithoutAppInstance(t *testing.T) {
+	is := is.New(t)
+
+	revision, err := porter_app.EncodedRevisionFromProto(context.Background(), &porterv1.AppRevision{
+		Id:  "a6b1f5b6-4a2e-4a4b-8a57-1f9a3c2b7d10",
+		App: &porterv1.PorterApp{Name: "test-app"},
+	})
+	is.NoErr(err)
+	is.Equal(revision.AppInstanceID, uuid.Nil)
+}
+
+func TestEncodedRevisionFromProtoProbes(t *testing.T) {
+	is := is.New(t)
+
+	revision, err := porter_app.EncodedRevisionFromProto(context.Background(), &porterv1.AppRevision{
+		Id: "a6b1f5b6-4a2e-4a4b-8a57-1f9a3c2b7d10",
+		App: &porterv1.PorterApp{
+			Name: "test-app",
+			ServiceList: []*porterv1.Service{
+				{
+					Name: "web",
+					Port: 8080,
+					Type: porterv1.ServiceType_SERVICE_TYPE_WEB,
+					Config: &porterv1.Service_WebConfig{WebConfig: &porterv1.WebServiceConfig{
+						HealthCheck: &porterv1.HealthCheck{Enabled: true, HttpPath: "/healthz"},
+					}},
+				},
+				{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			},
+		},
+	})
+	is.NoErr(err)
+
+	web := revision.Probes["web"]
+	is.True(web.Liveness != nil)
+	is.Equal(*web.Liveness, porter_app.ProbeConfig{Path: "/healthz", Port: 8080})
+	is.Equal(*web.Readiness, porter_app.ProbeConfig{Path: "/healthz", Port: 8080})
+
+	worker, ok := revision.Probes["worker"]
+	is.True(ok)
+	is.True(worker.Liveness == nil)
+	is.True(worker.Readiness == nil)
+}
+
+func TestEncodedRevisionFromProtoNetworking(t *testing.T) {
+	is := is.New(t)
+
+	private := true
+	revision, err := porter_app.EncodedRevisionFromProto(context.Background(), &porterv1.AppRevision{
+		Id: "a6b1f5b6-4a2e-4a4b-8a57-1f9a3c2b7d10",
+		App: &porterv1.PorterApp{
+			Name: "test-app",
+			ServiceList: []*porterv1.Service{
+				{
+					Name: "web",
+					Port: 8080,
+					Type: porterv1.ServiceType_SERVICE_TYPE_WEB,
+					Config: &porterv1.Service_WebConfig{WebConfig: &porterv1.WebServiceConfig{
+						Domains: []*porterv1.Domain{{Name: "app.example.com"}, {Name: "www.example.com"}},
+					}},
+				},
+				{
+					Name: "internal-api",
+					Port: 3000,
+					Type: porterv1.ServiceType_SERVICE_TYPE_WEB,
+					Config: &porterv1.Service_WebConfig{WebConfig: &porterv1.WebServiceConfig{
+						Private: &private,
+					}},
+				},
+				{Name: "worker", Port: 9000, Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			},
+		},
+	})
+	is.NoErr(err)
+
+	is.Equal(revision.Networking["web"], porter_app.ServiceNetworking{Port: 8080, Public: true, Hostnames: []string{"app.example.com", "www.example.com"}})
+	is.Equal(revision.Networking["internal-api"], porter_app.ServiceNetworking{Port: 3000})
+
+	worker, ok := revision.Networking["worker"]
+	is.True(ok)
+	is.Equal(worker, porter_app.ServiceNetworking{})
+}
+
+func TestRevisionsCreatedBetween(t *testing.T) {
+	is := is.New(t)
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	revisionAt := func(number uint64, createdAt time.Time) *porterv1.AppRevision {
+		return &porterv1.AppRevision{RevisionNumber: number, CreatedAt: timestamppb.New(createdAt)}
+	}
+	revisions := []*porterv1.AppRevision{
+		revisionAt(1, start.Add(-time.Hour)),
+		revisionAt(2, start),
+		revisionAt(3, start.Add(30*time.Minute)),
+		revisionAt(4, start.Add(2*time.Hour)),
+	}
+
+	numbers := func(revisions []*porterv1.AppRevision) []uint64 {
+		var res []uint64
+		for _, revision := range revisions {
+			res = append(res, revision.RevisionNumber)
+		}
+		return res
+	}
+
+	is.Equal(numbers(porter_app.RevisionsCreatedBetween(revisions, start, start.Add(time.Hour))), []uint64{2, 3})
+	is.Equal(numbers(porter_app.RevisionsCreatedBetween(revisions, start, time.Time{})), []uint64{2, 3, 4})
+	is.Equal(numbers(porter_app.RevisionsCreatedBetween(revisions, time.Time{}, start)), []uint64{1, 2})
+	is.Equal(len(porter_app.RevisionsCreatedBetween(revisions, time.Time{}, time.Time{})), 4)
+}
+
+func TestServiceImageHistory(t *testing.T) {
+	is := is.New(t)
+
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	revision := func(number uint64, tag string, services ...string) *porterv1.AppRevision {
+		app := &porterv1.PorterApp{Image: &porterv1.AppImage{Repository: "registry/app", Tag: tag}}
+		for _, service := range services {
+			app.ServiceList = append(app.ServiceList, &porterv1.Service{Name: service, Type: porterv1.ServiceType_SERVICE_TYPE_WEB})
+		}
+		return &porterv1.AppRevision{
+			Id:             uuid.NewString(),
+			RevisionNumber: number,
+			App:            app,
+			CreatedAt:      timestamppb.New(start.Add(time.Duration(number) * time.Hour)),
+		}
+	}
+
+	// revisions are given out of order to check that they are sorted by number
+	revisions := []*porterv1.AppRevision{
+		revision(3, "v2", "web", "worker"),
+		revision(1, "v1", "web"),
+		revision(2, "v1", "web", "worker"),
+		revision(4, "v2", "web"),
+		revision(5, "v2", "web", "worker"),
+	}
+
+	history, found := porter_app.ServiceImageHistory(revisions, "web")
+	is.True(found)
+	is.Equal(len(history), 2)
+	is.Equal(history[0].RevisionNumber, uint64(3))
+	is.Equal(history[0].Image.Tag, "v2")
+	is.Equal(history[0].PreviousImage.Tag, "v1")
+	is.Equal(history[0].DeployedAt, start.Add(3*time.Hour))
+	is.Equal(history[1].RevisionNumber, uint64(1))
+	is.Equal(history[1].PreviousImage, nil)
+
+	// the worker was removed in revision 4, so adding it back in revision 5 counts as a change
+	history, found = porter_app.ServiceImageHistory(revisions, "worker")
+	is.True(found)
+	is.Equal(len(history), 3)
+	is.Equal(history[0].RevisionNumber, uint64(5))
+	is.Equal(history[0].PreviousImage, nil)
+	is.Equal(history[1].RevisionNumber, uint64(3))
+	is.Equal(history[2].RevisionNumber, uint64(2))
+
+	history, found = porter_app.ServiceImageHistory(revisions, "missing")
+	is.True(!found)
+	is.Equal(len(history), 0)
+}
@@ -0,0 +1,131 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/porter_app"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestServiceReplicaStatusFromK8s(t *testing.T) {
+	is := is.New(t)
+
+	replicas := int32(3)
+	deployments := []appsv1.Deployment{
+		{Spec: appsv1.DeploymentSpec{Replicas: &replicas}},
+	}
+
+	readyPod := v1.Pod{
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+	notReadyPod := v1.Pod{
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionFalse}},
+		},
+	}
+	terminatingPod := *readyPod.DeepCopy()
+	terminatingPod.DeletionTimestamp = &metav1.Time{}
+
+	status := porter_app.ServiceReplicaStatusFromK8s(deployments, []v1.Pod{readyPod, readyPod, notReadyPod, terminatingPod})
+
+	is.Equal(status.DesiredReplicas, 3)
+	is.Equal(status.ReadyReplicas, 2)
+	is.True(!status.IsReady())
+
+	ready := porter_app.ServiceReplicaStatusFromK8s(deployments, []v1.Pod{readyPod, readyPod, readyPod})
+	is.True(ready.IsReady())
+
+	// the service's deployment has not been created yet
+	undeployed := porter_app.ServiceReplicaStatusFromK8s(nil, nil)
+	is.Equal(undeployed.Workloads, 0)
+	is.True(!undeployed.IsReady())
+}
+
+func TestServiceRolloutStatusFromK8s(t *testing.T) {
+	is := is.New(t)
+
+	replicas := int32(1)
+	deployments := []appsv1.Deployment{{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}}
+
+	crashLooping := v1.Pod{
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			ContainerStatuses: []v1.ContainerStatus{
+				{Name: "web", State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+	ready := v1.Pod{
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}},
+		},
+	}
+
+	failed := porter_app.ServiceRolloutStatusFromK8s(deployments, []v1.Pod{crashLooping})
+	is.Equal(failed.Status, porter_app.RolloutStatus_Failed)
+	is.Equal(failed.FailureReason, "CrashLoopBackOff")
+
+	progressing := porter_app.ServiceRolloutStatusFromK8s(deployments, nil)
+	is.Equal(progressing.Status, porter_app.RolloutStatus_Progressing)
+
+	healthy := porter_app.ServiceRolloutStatusFromK8s(deployments, []v1.Pod{ready})
+	is.Equal(healthy.Status, porter_app.RolloutStatus_Healthy)
+
+	is.Equal(porter_app.OverallRolloutStatus(map[string]porter_app.ServiceRolloutStatus{"web": healthy, "worker": progressing}), porter_app.RolloutStatus_Progressing)
+	is.Equal(porter_app.OverallRolloutStatus(map[string]porter_app.ServiceRolloutStatus{"web": healthy, "worker": failed}), porter_app.RolloutStatus_Failed)
+	is.Equal(porter_app.OverallRolloutStatus(map[string]porter_app.ServiceRolloutStatus{"web": healthy}), porter_app.RolloutStatus_Healthy)
+}
+
+func TestRolloutProgressFromK8s(t *testing.T) {
+	is := is.New(t)
+
+	replicas := int32(4)
+	deployment := func(generation int64, observedGeneration int64, updated int32, available int32, total int32) appsv1.Deployment {
+		return appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status: appsv1.DeploymentStatus{
+				ObservedGeneration: observedGeneration,
+				UpdatedReplicas:    updated,
+				AvailableReplicas:  available,
+				Replicas:           total,
+			},
+		}
+	}
+
+	waiting := porter_app.RolloutProgressFromK8s([]appsv1.Deployment{deployment(2, 2, 0, 4, 4)}, nil)
+	is.Equal(waiting.Phase, porter_app.RolloutPhase_Waiting)
+	is.Equal(waiting.Percent, 0)
+
+	progressing := porter_app.RolloutProgressFromK8s([]appsv1.Deployment{deployment(2, 2, 2, 4, 6)}, nil)
+	is.Equal(progressing.Phase, porter_app.RolloutPhase_Progressing)
+	is.Equal(progressing.Percent, 50)
+
+	// all replicas are updated, but old replicas are still terminating
+	terminating := porter_app.RolloutProgressFromK8s([]appsv1.Deployment{deployment(2, 2, 4, 4, 5)}, nil)
+	is.Equal(terminating.Phase, porter_app.RolloutPhase_Progressing)
+	is.Equal(terminating.Percent, 99)
+
+	// the status describes the previous generation, so its updated replicas do not count
+	unobserved := porter_app.RolloutProgressFromK8s([]appsv1.Deployment{deployment(3, 2, 4, 4, 4)}, nil)
+	is.Equal(unobserved.Phase, porter_app.RolloutPhase_Progressing)
+	is.Equal(unobserved.Percent, 0)
+
+	statefulSet := appsv1.StatefulSet{
+		Spec:   appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{UpdatedReplicas: 4, AvailableReplicas: 4, Replicas: 4},
+	}
+	complete := porter_app.RolloutProgressFromK8s([]appsv1.Deployment{deployment(2, 2, 4, 4, 4)}, []appsv1.StatefulSet{statefulSet})
+	is.Equal(complete.Phase, porter_app.RolloutPhase_Complete)
+	is.Equal(complete.Percent, 100)
+	is.Equal(complete.DesiredReplicas, 8)
+	is.Equal(complete.UpdatedReplicas, 8)
+}
@@ -0,0 +1,39 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestServiceSummariesFromAppProto(t *testing.T) {
+	is := is.New(t)
+
+	webInstances := int32(2)
+	summaries := porter_app.ServiceSummariesFromAppProto(&porterv1.PorterApp{
+		ServiceList: []*porterv1.Service{
+			{
+				Name:              "web",
+				Type:              porterv1.ServiceType_SERVICE_TYPE_WEB,
+				InstancesOptional: &webInstances,
+				Config: &porterv1.Service_WebConfig{WebConfig: &porterv1.WebServiceConfig{
+					Autoscaling: &porterv1.Autoscaling{Enabled: true, MinInstances: 1, MaxInstances: 5},
+				}},
+			},
+			{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER, Instances: 1}, // nolint:staticcheck // older revisions only set the deprecated field
+			{Name: "migrate", Type: porterv1.ServiceType_SERVICE_TYPE_JOB},
+		},
+	})
+
+	is.Equal(len(summaries), 3)
+	is.Equal(summaries[0], porter_app.ServiceSummary{Name: "migrate", Type: "job"})
+	is.Equal(summaries[1].Name, "web")
+	is.Equal(summaries[1].Type, "web")
+	is.Equal(summaries[1].Instances, int32(2))
+	is.Equal(*summaries[1].Autoscaling, porter_app.ServiceAutoscaling{MinInstances: 1, MaxInstances: 5})
+	is.Equal(summaries[2], porter_app.ServiceSummary{Name: "worker", Type: "worker", Instances: 1})
+
+	is.Equal(len(porter_app.ServiceSummariesFromAppProto(nil)), 0)
+}
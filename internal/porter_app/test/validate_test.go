@@ -0,0 +1,60 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/matryer/is"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/internal/porter_app"
+)
+
+func TestValidateApp(t *testing.T) {
+	is := is.New(t)
+
+	webService := func(name string, port int32, domains ...string) *porterv1.Service {
+		config := &porterv1.WebServiceConfig{}
+		for _, domain := range domains {
+			config.Domains = append(config.Domains, &porterv1.Domain{Name: domain})
+		}
+		return &porterv1.Service{
+			Name:   name,
+			Port:   port,
+			Type:   porterv1.ServiceType_SERVICE_TYPE_WEB,
+			Config: &porterv1.Service_WebConfig{WebConfig: config},
+		}
+	}
+
+	valid := &porterv1.PorterApp{
+		Name: "test-app",
+		ServiceList: []*porterv1.Service{
+			webService("web", 8080, "example.com"),
+			webService("api", 8080, "api.example.com"),
+			{Name: "worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+		},
+		Predeploy: &porterv1.Service{Name: "pre-deploy", Type: porterv1.ServiceType_SERVICE_TYPE_JOB},
+	}
+	is.Equal(len(porter_app.ValidateApp(valid)), 0) // services may listen on the same port on different domains
+
+	invalid := &porterv1.PorterApp{
+		ServiceList: []*porterv1.Service{
+			webService("web", 80, "example.com"),
+			webService("web-2", 0, "Example.com"),
+			{Name: "web", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+			{Name: "Cron_Job"},
+		},
+	}
+	problems := porter_app.ValidateApp(invalid)
+
+	codes := make(map[string]porter_app.ValidationProblemCode)
+	for _, problem := range problems {
+		codes[problem.Field] = problem.Code
+	}
+	is.Equal(len(problems), 7)
+	is.Equal(codes["name"], porter_app.ValidationProblemCode_Required)
+	is.Equal(codes["services.web.port"], porter_app.ValidationProblemCode_InvalidPort)
+	is.Equal(codes["services.web-2.port"], porter_app.ValidationProblemCode_Required)
+	is.Equal(codes["services.Cron_Job.name"], porter_app.ValidationProblemCode_InvalidName)
+	is.Equal(codes["services.Cron_Job.type"], porter_app.ValidationProblemCode_Required)
+	is.Equal(codes["services.web"], porter_app.ValidationProblemCode_DuplicateServiceName)
+	is.Equal(codes["services.web.domains"], porter_app.ValidationProblemCode_PortConflict) // domains are case insensitive
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/matryer/is"
+	"github.com/porter-dev/porter/internal/porter_app/webhooks"
+)
+
+func TestDeliverDeployEvent(t *testing.T) {
+	is := is.New(t)
+
+	secret := []byte("shh")
+	var attempts int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		is.NoErr(err)
+		is.Equal(r.Header.Get(webhooks.SignatureHeader), webhooks.Sign(secret, body))
+		is.Equal(r.Header.Get(webhooks.EventHeader), string(webhooks.EventType_DeploySucceeded))
+
+		// fail the first attempt, so that the delivery is retried
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := webhooks.Deliver(context.Background(), webhooks.DeliverInput{
+		Client:         server.Client(),
+		URL:            server.URL,
+		SigningSecret:  secret,
+		Event:          webhooks.Event{Event: webhooks.EventType_DeploySucceeded, AppName: "web"},
+		InitialBackoff: time.Millisecond,
+	})
+	is.NoErr(err)
+	is.Equal(atomic.LoadInt32(&attempts), int32(2))
+}
+
+func TestDeliverDeployEventGivesUp(t *testing.T) {
+	is := is.New(t)
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		is.Equal(r.Header.Get(webhooks.SignatureHeader), "") // no secret, so the payload is not signed
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := webhooks.Deliver(context.Background(), webhooks.DeliverInput{
+		Client:         server.Client(),
+		URL:            server.URL,
+		Event:          webhooks.Event{Event: webhooks.EventType_DeployFailed},
+		InitialBackoff: time.Millisecond,
+	})
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&attempts), int32(3))
+}
+
+func TestDeliverRefusesPrivateDestinations(t *testing.T) {
+	is := is.New(t)
+
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// the test server listens on loopback, which the webhook client must refuse to connect to
+	err := webhooks.Deliver(context.Background(), webhooks.DeliverInput{
+		Client:         webhooks.NewClient(time.Second),
+		URL:            server.URL,
+		Event:          webhooks.Event{Event: webhooks.EventType_DeployFailed},
+		InitialBackoff: time.Millisecond,
+	})
+	is.True(err != nil)
+	is.Equal(atomic.LoadInt32(&attempts), int32(0))
+}
+
+func TestValidateURL(t *testing.T) {
+	is := is.New(t)
+
+	ctx := context.Background()
+
+	is.NoErr(webhooks.ValidateURL(ctx, "https://93.184.216.34/hooks"))
+	is.True(webhooks.ValidateURL(ctx, "http://93.184.216.34/hooks") != nil) // not https
+
+	for _, blocked := range []string{
+		"https://127.0.0.1/hooks",
+		"https://localhost/hooks",
+		"https://10.0.0.8/hooks",
+		"https://192.168.1.1/hooks",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.64.0.1/hooks",
+		"https://[::1]/hooks",
+		"https://[fd00::1]/hooks",
+		"https://[::ffff:127.0.0.1]/hooks",
+		"https://0.0.0.0/hooks",
+	} {
+		is.Equal(webhooks.ValidateURL(ctx, blocked), webhooks.ErrBlockedDestination) // blocked
+	}
+}
+
+func TestEventTypeForAppEvent(t *testing.T) {
+	is := is.New(t)
+
+	eventType, ok := webhooks.EventTypeForAppEvent("DEPLOY", "SUCCESS")
+	is.True(ok)
+	is.Equal(eventType, webhooks.EventType_DeploySucceeded)
+
+	eventType, ok = webhooks.EventTypeForAppEvent("BUILD", "FAILED")
+	is.True(ok)
+	is.Equal(eventType, webhooks.EventType_BuildFailed)
+
+	_, ok = webhooks.EventTypeForAppEvent("DEPLOY", "PROGRESSING")
+	is.True(!ok)
+	_, ok = webhooks.EventTypeForAppEvent("APP_EVENT", "FAILED")
+	is.True(!ok)
+}
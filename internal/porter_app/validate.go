@@ -0,0 +1,216 @@
+package porter_app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ValidationProblemCode identifies the kind of problem found when validating an app
+type ValidationProblemCode string
+
+const (
+	// ValidationProblemCode_Required indicates that a required field is missing
+	ValidationProblemCode_Required ValidationProblemCode = "required"
+	// ValidationProblemCode_InvalidName indicates that a name is not a valid DNS-1123 label
+	ValidationProblemCode_InvalidName ValidationProblemCode = "invalid_name"
+	// ValidationProblemCode_NameMismatch indicates that the app name does not match the app being validated
+	ValidationProblemCode_NameMismatch ValidationProblemCode = "name_mismatch"
+	// ValidationProblemCode_DuplicateServiceName indicates that more than one service, including the predeploy job, has the same name
+	ValidationProblemCode_DuplicateServiceName ValidationProblemCode = "duplicate_service_name"
+	// ValidationProblemCode_InvalidPort indicates that a service port is out of range
+	ValidationProblemCode_InvalidPort ValidationProblemCode = "invalid_port"
+	// ValidationProblemCode_PortConflict indicates that more than one web service is exposed on the same domain, so that their ports would
+	// conflict at the ingress
+	ValidationProblemCode_PortConflict ValidationProblemCode = "port_conflict"
+)
+
+const (
+	// minServicePort is the lowest port a service may listen on, since lower ports require the container to run as root
+	minServicePort = 1024
+	// maxServicePort is the highest valid port
+	maxServicePort = 65535
+)
+
+// ValidationProblem is a single problem found when validating an app
+type ValidationProblem struct {
+	// Field is the path to the offending field, e.g. services.web.port
+	Field string `json:"field"`
+	// Code identifies the kind of problem
+	Code ValidationProblemCode `json:"code"`
+	// Message is a human readable description of the problem
+	Message string `json:"message"`
+}
+
+// ValidateApp checks an app for problems which would cause an apply to fail: missing required fields, invalid or duplicate service names,
+// ports out of range and web services conflicting on a domain. It does not call the cluster control plane, so problems which depend on the
+// state of the cluster, e.g. missing env groups, are not reported. An empty slice is returned if no problems are found
+func ValidateApp(app *porterv1.PorterApp) []ValidationProblem {
+	problems := make([]ValidationProblem, 0)
+
+	if app == nil {
+		return append(problems, ValidationProblem{Field: "app", Code: ValidationProblemCode_Required, Message: "app is required"})
+	}
+
+	if app.Name == "" {
+		problems = append(problems, ValidationProblem{Field: "name", Code: ValidationProblemCode_Required, Message: "app name is required"})
+	} else if errs := validation.IsDNS1123Label(app.Name); len(errs) > 0 {
+		problems = append(problems, ValidationProblem{
+			Field:   "name",
+			Code:    ValidationProblemCode_InvalidName,
+			Message: fmt.Sprintf("invalid app name %q: %s", app.Name, strings.Join(errs, "; ")),
+		})
+	}
+
+	services := namedServices(app)
+	if len(services) == 0 {
+		problems = append(problems, ValidationProblem{Field: "services", Code: ValidationProblemCode_Required, Message: "app must have at least one service"})
+	}
+
+	serviceCounts := make(map[string]int)
+	for i, service := range services {
+		if service.service == nil {
+			problems = append(problems, ValidationProblem{Field: fmt.Sprintf("services[%d]", i), Code: ValidationProblemCode_Required, Message: "service is empty"})
+			continue
+		}
+		problems = append(problems, validateService(fmt.Sprintf("services[%d]", i), service.name, service.service)...)
+		if service.name != "" {
+			serviceCounts[service.name]++
+		}
+	}
+
+	if predeployName := app.Predeploy.GetName(); predeployName != "" {
+		if errs := validation.IsDNS1123Label(predeployName); len(errs) > 0 {
+			problems = append(problems, ValidationProblem{
+				Field:   "predeploy.name",
+				Code:    ValidationProblemCode_InvalidName,
+				Message: fmt.Sprintf("invalid predeploy name %q: %s", predeployName, strings.Join(errs, "; ")),
+			})
+		}
+		serviceCounts[predeployName]++
+	}
+
+	duplicateNames := make([]string, 0)
+	for name, count := range serviceCounts {
+		if count > 1 {
+			duplicateNames = append(duplicateNames, name)
+		}
+	}
+	sort.Strings(duplicateNames)
+	for _, name := range duplicateNames {
+		problems = append(problems, ValidationProblem{
+			Field:   fmt.Sprintf("services.%s", name),
+			Code:    ValidationProblemCode_DuplicateServiceName,
+			Message: fmt.Sprintf("service name %q is used by %d services", name, serviceCounts[name]),
+		})
+	}
+
+	problems = append(problems, domainConflicts(services)...)
+
+	return problems
+}
+
+// namedService is a service of an app along with its name, which for services in the deprecated map is the map key
+type namedService struct {
+	name    string
+	service *porterv1.Service
+}
+
+// namedServices returns the services of an app, from the service list if set and otherwise from the deprecated service map
+func namedServices(app *porterv1.PorterApp) []namedService {
+	var services []namedService
+
+	if app.ServiceList != nil {
+		for _, service := range app.ServiceList {
+			services = append(services, namedService{name: service.GetName(), service: service})
+		}
+		return services
+	}
+
+	for name, service := range app.Services { // nolint:staticcheck // temporarily using deprecated field for backwards compatibility
+		services = append(services, namedService{name: name, service: service})
+	}
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].name < services[j].name
+	})
+
+	return services
+}
+
+// validateService checks the fields of a single service. field is the path used for the service if it has no name
+func validateService(field string, name string, service *porterv1.Service) []ValidationProblem {
+	var problems []ValidationProblem
+
+	if name == "" {
+		problems = append(problems, ValidationProblem{Field: fmt.Sprintf("%s.name", field), Code: ValidationProblemCode_Required, Message: "service name is required"})
+	} else {
+		field = fmt.Sprintf("services.%s", name)
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			problems = append(problems, ValidationProblem{
+				Field:   fmt.Sprintf("%s.name", field),
+				Code:    ValidationProblemCode_InvalidName,
+				Message: fmt.Sprintf("invalid service name %q: %s", name, strings.Join(errs, "; ")),
+			})
+		}
+	}
+
+	switch service.Type {
+	case porterv1.ServiceType_SERVICE_TYPE_WEB:
+		if service.Port == 0 {
+			problems = append(problems, ValidationProblem{Field: fmt.Sprintf("%s.port", field), Code: ValidationProblemCode_Required, Message: "port must be specified for web services"})
+		}
+	case porterv1.ServiceType_SERVICE_TYPE_WORKER, porterv1.ServiceType_SERVICE_TYPE_JOB:
+	default:
+		problems = append(problems, ValidationProblem{Field: fmt.Sprintf("%s.type", field), Code: ValidationProblemCode_Required, Message: "service type must be one of web, worker or job"})
+	}
+
+	if service.Port != 0 && (service.Port < minServicePort || service.Port > maxServicePort) {
+		problems = append(problems, ValidationProblem{
+			Field:   fmt.Sprintf("%s.port", field),
+			Code:    ValidationProblemCode_InvalidPort,
+			Message: fmt.Sprintf("port must be a number between %d and %d", minServicePort, maxServicePort),
+		})
+	}
+
+	return problems
+}
+
+// domainConflicts returns a problem for each custom domain which is claimed by more than one web service. Services listen on their own ports
+// inside the cluster, but the ingress can only route a domain to a single service port
+func domainConflicts(services []namedService) []ValidationProblem {
+	servicesByDomain := make(map[string][]string)
+	for _, service := range services {
+		if service.service.GetType() != porterv1.ServiceType_SERVICE_TYPE_WEB {
+			continue
+		}
+		for _, domain := range service.service.GetWebConfig().GetDomains() {
+			name := strings.ToLower(domain.GetName())
+			if name == "" {
+				continue
+			}
+			servicesByDomain[name] = append(servicesByDomain[name], service.name)
+		}
+	}
+
+	domains := make([]string, 0, len(servicesByDomain))
+	for domain, serviceNames := range servicesByDomain {
+		if len(serviceNames) > 1 {
+			domains = append(domains, domain)
+		}
+	}
+	sort.Strings(domains)
+
+	var problems []ValidationProblem
+	for _, domain := range domains {
+		problems = append(problems, ValidationProblem{
+			Field:   fmt.Sprintf("services.%s.domains", servicesByDomain[domain][0]),
+			Code:    ValidationProblemCode_PortConflict,
+			Message: fmt.Sprintf("domain %q is used by multiple web services: %s", domain, strings.Join(servicesByDomain[domain], ", ")),
+		})
+	}
+
+	return problems
+}
@@ -0,0 +1,135 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+const (
+	// SignatureHeader is the header containing the HMAC-SHA256 signature of the payload, in the format sha256=<hex digest>
+	SignatureHeader = "X-Porter-Signature"
+	// EventHeader is the header containing the type of the event
+	EventHeader = "X-Porter-Event"
+
+	// maxDeliveryAttempts is the number of times a payload is posted before giving up
+	maxDeliveryAttempts = 3
+	// defaultInitialBackoff is the delay before the first retry, which doubles after each attempt
+	defaultInitialBackoff = time.Second
+)
+
+// Sign returns the signature of a payload, in the format sent in the X-Porter-Signature header
+func Sign(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload) // nolint:errcheck // hash writes never return an error
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliverInput is the input to Deliver
+type DeliverInput struct {
+	// Client is the http client used to post the payload
+	Client *http.Client
+	// URL is the endpoint of the webhook
+	URL string
+	// SigningSecret is the shared secret used to sign the payload. The payload is not signed if this is empty
+	SigningSecret []byte
+	// Event is the payload to deliver
+	Event Event
+	// InitialBackoff is the delay before the first retry. Defaults to one second
+	InitialBackoff time.Duration
+}
+
+// Deliver posts an event to a webhook, retrying with exponential backoff if the request fails or a non-2xx status is returned
+func Deliver(ctx context.Context, inp DeliverInput) error {
+	ctx, span := telemetry.NewSpan(ctx, "deliver-project-webhook")
+	defer span.End()
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "event", Value: string(inp.Event.Event)})
+
+	if inp.Client == nil {
+		return telemetry.Error(ctx, span, nil, "http client is nil")
+	}
+	if inp.URL == "" {
+		return telemetry.Error(ctx, span, nil, "webhook url is empty")
+	}
+
+	payload, err := json.Marshal(inp.Event)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error marshaling webhook event")
+	}
+
+	var signature string
+	if len(inp.SigningSecret) > 0 {
+		signature = Sign(inp.SigningSecret, payload)
+	}
+
+	backoff := inp.InitialBackoff
+	if backoff == 0 {
+		backoff = defaultInitialBackoff
+	}
+
+	var deliveryErr error
+	for attempt := 1; attempt <= maxDeliveryAttempts; attempt++ {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "attempts", Value: attempt})
+
+		deliveryErr = post(ctx, inp.Client, inp.URL, inp.Event.Event, signature, payload)
+		if deliveryErr == nil {
+			return nil
+		}
+		// a blocked destination will not become reachable by retrying
+		if errors.Is(deliveryErr, ErrBlockedDestination) {
+			break
+		}
+
+		if attempt == maxDeliveryAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return telemetry.Error(ctx, span, ctx.Err(), "context done before webhook was delivered")
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+
+	return telemetry.Error(ctx, span, deliveryErr, "error delivering webhook")
+}
+
+func post(ctx context.Context, client *http.Client, url string, event EventType, signature string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event))
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting webhook: %w", err)
+	}
+	defer resp.Body.Close() // nolint:errcheck
+
+	// drain the body so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
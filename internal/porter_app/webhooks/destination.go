@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedDestination is returned when a webhook url resolves to an address inside a private network, e.g. loopback, private or link-local,
+// which the server must not be made to send requests to
+var ErrBlockedDestination = errors.New("webhook destination is not a public address")
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not covered by netip.Addr.IsPrivate
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isBlockedAddr returns true if the address is not routable on the public internet
+func isBlockedAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return !addr.IsValid() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() ||
+		addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr)
+}
+
+// ValidateURL checks that a webhook url uses https and that its host only resolves to public addresses. Delivery also checks the address
+// each connection is made to, since the host may resolve differently by then
+func ValidateURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("error parsing webhook url: %w", err)
+	}
+	if parsed.Scheme != "https" {
+		return errors.New("webhook url must use https")
+	}
+
+	host := parsed.Hostname()
+	if host == "" {
+		return errors.New("webhook url must have a host")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if isBlockedAddr(addr) {
+			return ErrBlockedDestination
+		}
+		return nil
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("error resolving webhook host: %w", err)
+	}
+	for _, addr := range addrs {
+		if isBlockedAddr(addr) {
+			return ErrBlockedDestination
+		}
+	}
+
+	return nil
+}
+
+// NewClient returns an http client for delivering webhooks which refuses to connect to addresses inside a private network. The check is made on the
+// address of each connection rather than on the url, so that it also applies to redirects and to hosts which resolve to a different address after registration
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("error parsing webhook destination: %w", err)
+			}
+			if isBlockedAddr(addrPort.Addr()) {
+				return ErrBlockedDestination
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			// a proxy would be dialed instead of the destination, bypassing the address check
+			Proxy:               nil,
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
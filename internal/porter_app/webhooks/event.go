@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// EventType is the type of event delivered to a project webhook
+type EventType string
+
+const (
+	// EventType_DeploySucceeded is sent when all services in a deploy have rolled out successfully
+	EventType_DeploySucceeded EventType = "deploy.succeeded"
+	// EventType_DeployFailed is sent when a deploy has finished and at least one service failed to roll out
+	EventType_DeployFailed EventType = "deploy.failed"
+	// EventType_BuildFailed is sent when the build of an app fails
+	EventType_BuildFailed EventType = "build.failed"
+	// EventType_NotificationCreated is sent when a notification is raised for an app, e.g. because a service is crash looping
+	EventType_NotificationCreated EventType = "notification.created"
+)
+
+// Event is the payload posted to project webhooks
+type Event struct {
+	// Event is the type of the event
+	Event EventType `json:"event"`
+	// ProjectID is the ID of the project the app belongs to
+	ProjectID uint `json:"project_id"`
+	// ClusterID is the ID of the cluster the app is deployed to
+	ClusterID uint `json:"cluster_id"`
+	// AppName is the name of the app
+	AppName string `json:"app_name"`
+	// DeploymentTargetID is the ID of the deployment target of the app. Empty for apps which do not use deployment targets
+	DeploymentTargetID string `json:"deployment_target_id,omitempty"`
+	// AppRevisionID is the ID of the app revision the event belongs to. Empty for apps which do not use deployment targets
+	AppRevisionID string `json:"app_revision_id,omitempty"`
+	// EventID is the ID of the porter app event for deploy and build events
+	EventID string `json:"event_id,omitempty"`
+	// Status is the final status of a deploy or build, e.g. SUCCESS or FAILED
+	Status string `json:"status,omitempty"`
+	// Notification is the notification which was raised, for notification events
+	Notification *Notification `json:"notification,omitempty"`
+	// Timestamp is the time that the event occurred
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notification is a notification raised for an app
+type Notification struct {
+	// ServiceName is the name of the service the notification is about
+	ServiceName string `json:"service_name"`
+	// Summary is a short summary of the notification
+	Summary string `json:"summary"`
+	// Detail is the full detail of the notification
+	Detail string `json:"detail"`
+}
+
+// EventTypeForAppEvent returns the webhook event type for a porter app event which reached the given status. False is returned
+// if the event is not delivered to webhooks, e.g. because it is not a deploy or build event or its status is not final
+func EventTypeForAppEvent(eventType string, status string) (EventType, bool) {
+	switch {
+	case eventType == string(types.PorterAppEventType_Deploy) && status == string(types.PorterAppEventStatus_Success):
+		return EventType_DeploySucceeded, true
+	case eventType == string(types.PorterAppEventType_Deploy) && status == string(types.PorterAppEventStatus_Failed):
+		return EventType_DeployFailed, true
+	case eventType == string(types.PorterAppEventType_Build) && status == string(types.PorterAppEventStatus_Failed):
+		return EventType_BuildFailed, true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditLogFilter restricts the audit log entries returned by ListByProjectID
+type AuditLogFilter struct {
+	// Since is the time at or after which entries were recorded. Ignored if zero
+	Since time.Time
+	// Until is the time before which entries were recorded. Ignored if zero
+	Until time.Time
+	// Actions are the actions to return. If empty, entries of all actions are returned
+	Actions []string
+	// Limit is the maximum number of entries to return. If 0, all matching entries are returned
+	Limit int
+}
+
+// AuditLogRepository represents the set of queries on the AuditLogEntry model
+type AuditLogRepository interface {
+	Insert(ctx context.Context, entry *models.AuditLogEntry) (*models.AuditLogEntry, error)
+	// ListByProjectID returns the audit log entries of a project matching the filter, most recent first
+	ListByProjectID(ctx context.Context, projectID uint, filter AuditLogFilter) ([]*models.AuditLogEntry, error)
+}
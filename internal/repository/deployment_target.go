@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -10,6 +11,13 @@ type DeploymentTargetRepository interface {
 	DeploymentTargetBySelectorAndSelectorType(projectID uint, clusterID uint, selector, selectorType string) (*models.DeploymentTarget, error)
 	// List returns all deployment targets for a project
 	List(projectID uint, clusterID uint, preview bool) ([]*models.DeploymentTarget, error)
+	// ListForProject returns all deployment targets for a project. If clusterID is 0, targets in all clusters are returned.
+	// If previewOnly is true, only preview targets are returned; otherwise both preview and non-preview targets are returned
+	ListForProject(projectID uint, clusterID uint, previewOnly bool) ([]*models.DeploymentTarget, error)
 	// CreateDeploymentTarget creates a new deployment target
 	CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error)
+	// DeploymentTarget finds a deployment target in a project by id. If no target is found, a target with a nil id is returned
+	DeploymentTarget(projectID uint, id uuid.UUID) (*models.DeploymentTarget, error)
+	// UpdateDeploymentTargetMetadata saves the metadata of a deployment target
+	UpdateDeploymentTargetMetadata(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error)
 }
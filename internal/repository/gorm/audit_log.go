@@ -0,0 +1,73 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository uses gorm.DB for querying the database
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository returns an AuditLogRepository which uses
+// gorm.DB for querying the database
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &AuditLogRepository{db}
+}
+
+// Insert inserts a new AuditLogEntry into the db
+func (repo *AuditLogRepository) Insert(ctx context.Context, entry *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-insert-audit-log-entry")
+	defer span.End()
+
+	if entry == nil {
+		return nil, telemetry.Error(ctx, span, nil, "audit log entry is nil")
+	}
+	if entry.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+	if entry.Action == "" {
+		return nil, telemetry.Error(ctx, span, nil, "action is empty")
+	}
+
+	if err := repo.db.Create(entry).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error saving audit log entry")
+	}
+
+	return entry, nil
+}
+
+// ListByProjectID returns the AuditLogEntries of a project matching the filter, most recent first
+func (repo *AuditLogRepository) ListByProjectID(ctx context.Context, projectID uint, filter repository.AuditLogFilter) ([]*models.AuditLogEntry, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-audit-log-entries")
+	defer span.End()
+
+	entries := []*models.AuditLogEntry{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+	if len(filter.Actions) > 0 {
+		query = query.Where("action IN ?", filter.Actions)
+	}
+
+	query = query.Order("created_at DESC").Order("id DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	if err := query.Find(&entries).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing audit log entries")
+	}
+
+	return entries, nil
+}
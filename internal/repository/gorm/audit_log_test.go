@@ -0,0 +1,61 @@
+package gorm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+func TestListAuditLogEntriesByProjectID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_log_entries.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	ctx := context.Background()
+
+	for _, entry := range []*models.AuditLogEntry{
+		{ProjectID: 1, Action: "deploy", AppName: "first"},
+		{ProjectID: 1, Action: "rollback", AppName: "second"},
+		{ProjectID: 1, Action: "cancel", AppName: "third"},
+		{ProjectID: 2, Action: "deploy", AppName: "other-project"},
+	} {
+		if _, err := tester.repo.AuditLog().Insert(ctx, entry); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	entries, err := tester.repo.AuditLog().ListByProjectID(ctx, 1, repository.AuditLogFilter{})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d\n", len(entries))
+	}
+	// entries are listed most recent first
+	if entries[0].AppName != "third" || entries[2].AppName != "first" {
+		t.Fatalf("expected entries in reverse order of insertion, got %s, %s, %s\n", entries[0].AppName, entries[1].AppName, entries[2].AppName)
+	}
+
+	entries, err = tester.repo.AuditLog().ListByProjectID(ctx, 1, repository.AuditLogFilter{Actions: []string{"deploy", "cancel"}, Limit: 1})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(entries) != 1 || entries[0].AppName != "third" {
+		t.Fatalf("expected only the most recent matching entry, got %v\n", entries)
+	}
+
+	entries, err = tester.repo.AuditLog().ListByProjectID(ctx, 1, repository.AuditLogFilter{Since: time.Now().Add(time.Hour)})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected no entries after since, got %d\n", len(entries))
+	}
+}
@@ -42,6 +42,25 @@ func (repo *DeploymentTargetRepository) List(projectID uint, clusterID uint, pre
 	return deploymentTargets, nil
 }
 
+// ListForProject finds all deployment targets for a given project, optionally filtered by cluster and to preview targets
+func (repo *DeploymentTargetRepository) ListForProject(projectID uint, clusterID uint, previewOnly bool) ([]*models.DeploymentTarget, error) {
+	deploymentTargets := []*models.DeploymentTarget{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+	if clusterID != 0 {
+		query = query.Where("cluster_id = ?", clusterID)
+	}
+	if previewOnly {
+		query = query.Where("preview = ?", true)
+	}
+
+	if err := query.Order("created_at ASC").Find(&deploymentTargets).Error; err != nil {
+		return nil, err
+	}
+
+	return deploymentTargets, nil
+}
+
 // CreateDeploymentTarget creates a new deployment target
 func (repo *DeploymentTargetRepository) CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
 	if deploymentTarget == nil {
@@ -76,3 +95,33 @@ func (repo *DeploymentTargetRepository) CreateDeploymentTarget(deploymentTarget
 
 	return deploymentTarget, nil
 }
+
+// DeploymentTarget finds a deployment target in a project by id. If no target is found, a target with a nil id is returned
+func (repo *DeploymentTargetRepository) DeploymentTarget(projectID uint, id uuid.UUID) (*models.DeploymentTarget, error) {
+	deploymentTarget := &models.DeploymentTarget{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).Limit(1).Find(&deploymentTarget).Error; err != nil {
+		return nil, err
+	}
+
+	return deploymentTarget, nil
+}
+
+// UpdateDeploymentTargetMetadata saves the metadata of a deployment target
+func (repo *DeploymentTargetRepository) UpdateDeploymentTargetMetadata(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	if deploymentTarget == nil {
+		return nil, errors.New("deployment target is nil")
+	}
+	if deploymentTarget.ID == uuid.Nil {
+		return nil, errors.New("deployment target id is empty")
+	}
+
+	if err := repo.db.Model(&models.DeploymentTarget{}).Where("id = ?", deploymentTarget.ID).Updates(map[string]any{
+		"metadata":   deploymentTarget.Metadata,
+		"updated_at": time.Now().UTC(),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	return deploymentTarget, nil
+}
@@ -81,6 +81,8 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.Allowlist{},
 		&models.Tag{},
 		&models.APIToken{},
+		&models.PorterApp{},
+		&models.AuditLogEntry{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -67,6 +67,8 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.DeploymentTarget{},
 		&models.AppTemplate{},
 		&models.GithubWebhook{},
+		&models.ProjectWebhook{},
+		&models.AuditLogEntry{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -58,14 +58,24 @@ func (repo *PorterAppRepository) ReadPorterAppByName(clusterID uint, name string
 }
 
 // ReadPorterAppsByProjectIDAndName returns a list of PorterApps by project ID and name. Multiple apps can have the same name and project id
-// if they are in different clusters.
+// if they are in different clusters. Names are matched case-insensitively, but if any app matches the name exactly, only exact matches are returned.
 func (repo *PorterAppRepository) ReadPorterAppsByProjectIDAndName(projectID uint, name string) ([]*models.PorterApp, error) {
 	apps := []*models.PorterApp{}
 
-	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).Find(&apps).Error; err != nil {
+	if err := repo.db.Where("project_id = ? AND LOWER(name) = LOWER(?)", projectID, name).Find(&apps).Error; err != nil {
 		return nil, err
 	}
 
+	exactMatches := []*models.PorterApp{}
+	for _, app := range apps {
+		if app.Name == name {
+			exactMatches = append(exactMatches, app)
+		}
+	}
+	if len(exactMatches) > 0 {
+		return exactMatches, nil
+	}
+
 	return apps, nil
 }
 
@@ -169,8 +169,9 @@ func (repo *PorterAppEventRepository) ReadEvent(ctx context.Context, id uuid.UUI
 	return appEvent, nil
 }
 
-// ReadNotificationsByAppRevisionID returns a list of notifications for a given porter app instance id and app revision ID
-func (repo *PorterAppEventRepository) ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceId uuid.UUID, appRevisionId string) ([]*models.PorterAppEvent, error) {
+// ReadNotificationsByAppRevisionID returns up to limit of the most recent notifications for a given porter app instance id and app revision ID,
+// most recent first. If limit is 0, all notifications are returned. If since is set, only notifications created after it are returned
+func (repo *PorterAppEventRepository) ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceId uuid.UUID, appRevisionId string, since time.Time, limit int) ([]*models.PorterAppEvent, error) {
 	notifications := []*models.PorterAppEvent{}
 
 	if appRevisionId == "" {
@@ -181,14 +182,102 @@ func (repo *PorterAppEventRepository) ReadNotificationsByAppRevisionID(ctx conte
 		return notifications, errors.New("invalid porter app instance ID supplied")
 	}
 
+	if limit < 0 {
+		return notifications, errors.New("limit must not be negative")
+	}
+
 	// TODO: make app_revision_id a column in porter_app_event table: https://linear.app/porter/issue/POR-2096/add-app-revision-id-column-to-porter-app-events-table
-	if err := repo.db.Where("app_instance_id = ? AND type = 'NOTIFICATION' AND metadata->>'app_revision_id' = ?", porterAppInstanceId, appRevisionId).Find(&notifications).Error; err != nil {
+	query := repo.db.Model(&models.PorterAppEvent{}).Where("app_instance_id = ? AND type = 'NOTIFICATION' AND metadata->>'app_revision_id' = ?", porterAppInstanceId, appRevisionId)
+	if !since.IsZero() {
+		query = query.Where("created_at > ?", since)
+	}
+
+	query = query.Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&notifications).Error; err != nil {
+		return notifications, err
+	}
+
+	return notifications, nil
+}
+
+// ListNotificationsByDeploymentTargetID returns up to limit of the most recent notifications of the given app revisions in a deployment target, across apps, most recent first
+func (repo *PorterAppEventRepository) ListNotificationsByDeploymentTargetID(ctx context.Context, deploymentTargetID uuid.UUID, appRevisionIDs []string, since time.Time, limit int) ([]*models.PorterAppEvent, error) {
+	notifications := []*models.PorterAppEvent{}
+
+	if deploymentTargetID == uuid.Nil {
+		return notifications, errors.New("invalid deployment target id supplied")
+	}
+
+	if limit < 0 {
+		return notifications, errors.New("limit must not be negative")
+	}
+
+	if len(appRevisionIDs) == 0 {
+		return notifications, nil
+	}
+
+	query := repo.db.Model(&models.PorterAppEvent{}).
+		Where("deployment_target_id = ? AND type = 'NOTIFICATION' AND metadata->>'app_revision_id' IN ?", deploymentTargetID, appRevisionIDs)
+	if !since.IsZero() {
+		query = query.Where("created_at > ?", since)
+	}
+
+	query = query.Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&notifications).Error; err != nil {
 		return notifications, err
 	}
 
 	return notifications, nil
 }
 
+// AcknowledgeNotification marks a notification event as acknowledged by setting the acknowledged key in its metadata
+func (repo *PorterAppEventRepository) AcknowledgeNotification(ctx context.Context, id uuid.UUID) error {
+	if id == uuid.Nil {
+		return errors.New("invalid porter app event id supplied")
+	}
+
+	if err := repo.db.Model(&models.PorterAppEvent{}).
+		Where("id = ? AND type = 'NOTIFICATION'", id.String()).
+		Update("metadata", gorm.Expr("jsonb_set(COALESCE(metadata, '{}'::jsonb), '{acknowledged}', 'true'::jsonb)")).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AcknowledgeNotifications marks all unacknowledged notifications of an app in a deployment target as acknowledged.
+// Notifications which are already acknowledged are not updated, so that the returned count only includes newly acknowledged notifications
+func (repo *PorterAppEventRepository) AcknowledgeNotifications(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, before time.Time) (int64, error) {
+	if porterAppID == 0 {
+		return 0, errors.New("invalid porter app id supplied")
+	}
+	if deploymentTargetID == uuid.Nil {
+		return 0, errors.New("invalid deployment target id supplied")
+	}
+
+	query := repo.db.Model(&models.PorterAppEvent{}).
+		Where("porter_app_id = ? AND deployment_target_id = ? AND type = 'NOTIFICATION'", porterAppID, deploymentTargetID).
+		Where("COALESCE(metadata->>'acknowledged', 'false') <> 'true'")
+	if !before.IsZero() {
+		query = query.Where("created_at <= ?", before)
+	}
+
+	result := query.Update("metadata", gorm.Expr("jsonb_set(COALESCE(metadata, '{}'::jsonb), '{acknowledged}', 'true'::jsonb)"))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
 func (repo *PorterAppEventRepository) ReadDeployEventByRevision(ctx context.Context, porterAppID uint, revision float64) (models.PorterAppEvent, error) {
 	appEvent := models.PorterAppEvent{}
 
@@ -234,3 +323,34 @@ func (repo *PorterAppEventRepository) ReadDeployEventByAppRevisionID(ctx context
 
 	return appEvent, nil
 }
+
+// ReadBuildEventByAppRevision returns the most recent build event of an app revision in a deployment target. Build events which were not
+// reported with an app revision id are matched by the commit sha the revision was built from
+func (repo *PorterAppEventRepository) ReadBuildEventByAppRevision(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, appRevisionID string, commitSHA string) (models.PorterAppEvent, error) {
+	appEvent := models.PorterAppEvent{}
+
+	if porterAppID == 0 {
+		return appEvent, errors.New("invalid porter app ID supplied")
+	}
+
+	if deploymentTargetID == uuid.Nil {
+		return appEvent, errors.New("invalid deployment target ID supplied")
+	}
+
+	if appRevisionID == "" {
+		return appEvent, errors.New("no app revision ID supplied")
+	}
+
+	query := repo.db.Where("porter_app_id = ? AND deployment_target_id = ? AND type = 'BUILD'", porterAppID, deploymentTargetID)
+	if commitSHA == "" {
+		query = query.Where("metadata->>'app_revision_id' = ?", appRevisionID)
+	} else {
+		query = query.Where("(metadata->>'app_revision_id' = ? OR (COALESCE(metadata->>'app_revision_id', '') = '' AND metadata->>'commit_sha' = ?))", appRevisionID, commitSHA)
+	}
+
+	if err := query.Order("created_at DESC").First(&appEvent).Error; err != nil {
+		return appEvent, err
+	}
+
+	return appEvent, nil
+}
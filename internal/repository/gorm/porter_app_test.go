@@ -0,0 +1,92 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestReadPorterAppsByProjectIDAndNameIgnoresCase(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_read_porter_apps_by_name.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	for _, app := range []*models.PorterApp{
+		{ProjectID: 1, ClusterID: 1, Name: "myapp"},
+		{ProjectID: 1, ClusterID: 1, Name: "other"},
+		{ProjectID: 1, ClusterID: 2, Name: "Other"},
+		{ProjectID: 2, ClusterID: 3, Name: "myapp"},
+	} {
+		if _, err := tester.repo.PorterApp().CreatePorterApp(app); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	apps, err := tester.repo.PorterApp().ReadPorterAppsByProjectIDAndName(1, "MyApp")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(apps) != 1 || apps[0].Name != "myapp" || apps[0].ProjectID != 1 {
+		t.Fatalf("expected only myapp in project 1, got %v\n", apps)
+	}
+
+	// an exact match is preferred over apps which differ only by case
+	apps, err = tester.repo.PorterApp().ReadPorterAppsByProjectIDAndName(1, "Other")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(apps) != 1 || apps[0].Name != "Other" {
+		t.Fatalf("expected only the exact match Other, got %v\n", apps)
+	}
+
+	// without an exact match, all apps differing only by case are returned, so that callers report the name as ambiguous
+	apps, err = tester.repo.PorterApp().ReadPorterAppsByProjectIDAndName(1, "OTHER")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if len(apps) != 2 {
+		t.Fatalf("expected 2 apps, got %d\n", len(apps))
+	}
+}
+
+func TestPorterAppLabels(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_porter_app_labels.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	if _, err := tester.repo.PorterApp().CreatePorterApp(&models.PorterApp{
+		ProjectID: 1,
+		ClusterID: 1,
+		Name:      "labeled",
+		Labels:    models.JSONB{"team": "payments"},
+	}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if _, err := tester.repo.PorterApp().CreatePorterApp(&models.PorterApp{ProjectID: 1, ClusterID: 1, Name: "unlabeled"}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	app, err := tester.repo.PorterApp().ReadPorterAppByName(1, "labeled")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if labels := app.ToPorterAppType().Labels; labels["team"] != "payments" {
+		t.Fatalf("expected team label payments, got %v\n", labels)
+	}
+
+	app, err = tester.repo.PorterApp().ReadPorterAppByName(1, "unlabeled")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+	if labels := app.ToPorterAppType().Labels; len(labels) != 0 {
+		t.Fatalf("expected no labels, got %v\n", labels)
+	}
+}
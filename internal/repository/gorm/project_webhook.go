@@ -0,0 +1,144 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// ProjectWebhookRepository uses gorm.DB for querying the database
+type ProjectWebhookRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewProjectWebhookRepository returns a ProjectWebhookRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// signing secrets
+func NewProjectWebhookRepository(db *gorm.DB, key *[32]byte) repository.ProjectWebhookRepository {
+	return &ProjectWebhookRepository{db, key}
+}
+
+// Insert inserts a new ProjectWebhook into the db
+func (repo *ProjectWebhookRepository) Insert(ctx context.Context, webhook *models.ProjectWebhook) (*models.ProjectWebhook, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-insert-project-webhook")
+	defer span.End()
+
+	if webhook == nil {
+		return nil, telemetry.Error(ctx, span, nil, "project webhook is nil")
+	}
+	if webhook.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "project id is empty")
+	}
+	if webhook.URL == "" {
+		return nil, telemetry.Error(ctx, span, nil, "url is empty")
+	}
+
+	if webhook.ID == uuid.Nil {
+		webhook.ID = uuid.New()
+	}
+	if webhook.CreatedAt.IsZero() {
+		webhook.CreatedAt = time.Now().UTC()
+	}
+	if webhook.UpdatedAt.IsZero() {
+		webhook.UpdatedAt = time.Now().UTC()
+	}
+
+	plaintextSecret := webhook.SigningSecret
+	if len(plaintextSecret) > 0 {
+		cipherData, err := encryption.Encrypt(plaintextSecret, repo.key)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error encrypting signing secret")
+		}
+		webhook.SigningSecret = cipherData
+	}
+
+	err := repo.db.Create(webhook).Error
+
+	// the caller's copy is left holding the plaintext secret, as with webhooks read from the db
+	webhook.SigningSecret = plaintextSecret
+
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error saving project webhook")
+	}
+
+	return webhook, nil
+}
+
+// Get finds a ProjectWebhook by project id and webhook id
+func (repo *ProjectWebhookRepository) Get(ctx context.Context, projectID uint, id uuid.UUID) (*models.ProjectWebhook, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-get-project-webhook")
+	defer span.End()
+
+	webhook := &models.ProjectWebhook{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(webhook).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		return nil, telemetry.Error(ctx, span, err, "error finding project webhook")
+	}
+
+	if err := repo.decryptSigningSecret(webhook); err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error decrypting signing secret")
+	}
+
+	return webhook, nil
+}
+
+// ListByProjectID returns all ProjectWebhooks in a project
+func (repo *ProjectWebhookRepository) ListByProjectID(ctx context.Context, projectID uint) ([]*models.ProjectWebhook, error) {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-list-project-webhooks")
+	defer span.End()
+
+	webhooks := []*models.ProjectWebhook{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("created_at ASC").Find(&webhooks).Error; err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing project webhooks")
+	}
+
+	for _, webhook := range webhooks {
+		if err := repo.decryptSigningSecret(webhook); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error decrypting signing secret")
+		}
+	}
+
+	return webhooks, nil
+}
+
+// Delete deletes a ProjectWebhook
+func (repo *ProjectWebhookRepository) Delete(ctx context.Context, webhook *models.ProjectWebhook) error {
+	ctx, span := telemetry.NewSpan(ctx, "gorm-delete-project-webhook")
+	defer span.End()
+
+	if webhook == nil || webhook.ID == uuid.Nil {
+		return telemetry.Error(ctx, span, nil, "project webhook id is empty")
+	}
+
+	if err := repo.db.Where("id = ?", webhook.ID).Delete(&models.ProjectWebhook{}).Error; err != nil {
+		return telemetry.Error(ctx, span, err, "error deleting project webhook")
+	}
+
+	return nil
+}
+
+func (repo *ProjectWebhookRepository) decryptSigningSecret(webhook *models.ProjectWebhook) error {
+	if len(webhook.SigningSecret) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(webhook.SigningSecret, repo.key)
+	if err != nil {
+		return err
+	}
+	webhook.SigningSecret = plaintext
+
+	return nil
+}
@@ -56,6 +56,8 @@ type GormRepository struct {
 	deploymentTarget          repository.DeploymentTargetRepository
 	appTemplate               repository.AppTemplateRepository
 	githubWebhook             repository.GithubWebhookRepository
+	projectWebhook            repository.ProjectWebhookRepository
+	auditLog                  repository.AuditLogRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -257,6 +259,16 @@ func (t *GormRepository) GithubWebhook() repository.GithubWebhookRepository {
 	return t.githubWebhook
 }
 
+// ProjectWebhook returns the ProjectWebhookRepository interface implemented by gorm
+func (t *GormRepository) ProjectWebhook() repository.ProjectWebhookRepository {
+	return t.projectWebhook
+}
+
+// AuditLog returns the AuditLogRepository interface implemented by gorm
+func (t *GormRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
@@ -310,5 +322,7 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		deploymentTarget:          NewDeploymentTargetRepository(db),
 		appTemplate:               NewAppTemplateRepository(db),
 		githubWebhook:             NewGithubWebhookRepository(db),
+		projectWebhook:            NewProjectWebhookRepository(db, key),
+		auditLog:                  NewAuditLogRepository(db),
 	}
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
@@ -20,5 +21,18 @@ type PorterAppEventRepository interface {
 	ReadDeployEventByRevision(ctx context.Context, porterAppID uint, revision float64) (models.PorterAppEvent, error)
 	// ReadDeployEventByAppRevisionID returns a deploy event for a given porter app id and app revision ID
 	ReadDeployEventByAppRevisionID(ctx context.Context, porterAppID uint, appRevisionID string) (models.PorterAppEvent, error)
-	ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceID uuid.UUID, appRevisionID string) ([]*models.PorterAppEvent, error)
+	// ReadBuildEventByAppRevision returns the most recent build event of an app revision in a deployment target. Build events which were not
+	// reported with an app revision id are matched by the commit sha the revision was built from
+	ReadBuildEventByAppRevision(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, appRevisionID string, commitSHA string) (models.PorterAppEvent, error)
+	// ReadNotificationsByAppRevisionID returns up to limit of the most recent notifications for a given app instance id and app revision id, most recent first.
+	// If limit is 0, all notifications are returned. If since is not the zero time, only notifications created after it are returned.
+	ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceID uuid.UUID, appRevisionID string, since time.Time, limit int) ([]*models.PorterAppEvent, error)
+	// ListNotificationsByDeploymentTargetID returns up to limit of the most recent notifications of the given app revisions in a deployment target, across apps,
+	// most recent first. If limit is 0, all notifications are returned. If since is not the zero time, only notifications created after it are returned.
+	ListNotificationsByDeploymentTargetID(ctx context.Context, deploymentTargetID uuid.UUID, appRevisionIDs []string, since time.Time, limit int) ([]*models.PorterAppEvent, error)
+	// AcknowledgeNotification marks a notification event as acknowledged. Acknowledging an already acknowledged notification is a no-op
+	AcknowledgeNotification(ctx context.Context, id uuid.UUID) error
+	// AcknowledgeNotifications marks all unacknowledged notifications of an app in a deployment target as acknowledged, returning the number of
+	// notifications which were acknowledged. If before is not zero, only notifications created at or before that time are acknowledged
+	AcknowledgeNotifications(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, before time.Time) (int64, error)
 }
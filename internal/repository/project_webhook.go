@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ProjectWebhookRepository represents the set of queries on the ProjectWebhook model
+type ProjectWebhookRepository interface {
+	Insert(ctx context.Context, webhook *models.ProjectWebhook) (*models.ProjectWebhook, error)
+	Get(ctx context.Context, projectID uint, id uuid.UUID) (*models.ProjectWebhook, error)
+	ListByProjectID(ctx context.Context, projectID uint) ([]*models.ProjectWebhook, error)
+	Delete(ctx context.Context, webhook *models.ProjectWebhook) error
+}
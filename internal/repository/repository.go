@@ -50,4 +50,6 @@ type Repository interface {
 	DeploymentTarget() DeploymentTargetRepository
 	AppTemplate() AppTemplateRepository
 	GithubWebhook() GithubWebhookRepository
+	ProjectWebhook() ProjectWebhookRepository
+	AuditLog() AuditLogRepository
 }
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AuditLogRepository is a test repository that implements repository.AuditLogRepository
+type AuditLogRepository struct {
+	canQuery bool
+	entries  []*models.AuditLogEntry
+}
+
+// NewAuditLogRepository returns the test AuditLogRepository, which will return errors if canQuery is false
+func NewAuditLogRepository(canQuery bool) repository.AuditLogRepository {
+	return &AuditLogRepository{canQuery: canQuery}
+}
+
+// Insert inserts a new AuditLogEntry into the db
+func (repo *AuditLogRepository) Insert(ctx context.Context, entry *models.AuditLogEntry) (*models.AuditLogEntry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot write database")
+	}
+
+	repo.entries = append(repo.entries, entry)
+	entry.ID = uint(len(repo.entries))
+
+	return entry, nil
+}
+
+// ListByProjectID returns the AuditLogEntries of a project matching the filter, most recent first
+func (repo *AuditLogRepository) ListByProjectID(ctx context.Context, projectID uint, filter repository.AuditLogFilter) ([]*models.AuditLogEntry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	actions := make(map[string]bool, len(filter.Actions))
+	for _, action := range filter.Actions {
+		actions[action] = true
+	}
+
+	res := make([]*models.AuditLogEntry, 0)
+	for i := len(repo.entries) - 1; i >= 0; i-- {
+		entry := repo.entries[i]
+		if entry.ProjectID != projectID || (len(actions) > 0 && !actions[entry.Action]) {
+			continue
+		}
+		res = append(res, entry)
+		if filter.Limit > 0 && len(res) == filter.Limit {
+			break
+		}
+	}
+
+	return res, nil
+}
@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -27,7 +28,22 @@ func (repo *DeploymentTargetRepository) List(projectID uint, clusterID uint, pre
 	return nil, errors.New("cannot read database")
 }
 
+// ListForProject returns all deployment targets for a project
+func (repo *DeploymentTargetRepository) ListForProject(projectID uint, clusterID uint, previewOnly bool) ([]*models.DeploymentTarget, error) {
+	return nil, errors.New("cannot read database")
+}
+
 // CreateDeploymentTarget creates a new deployment target
 func (repo *DeploymentTargetRepository) CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
 	return nil, errors.New("cannot write database")
 }
+
+// DeploymentTarget finds a deployment target in a project by id
+func (repo *DeploymentTargetRepository) DeploymentTarget(projectID uint, id uuid.UUID) (*models.DeploymentTarget, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// UpdateDeploymentTargetMetadata saves the metadata of a deployment target
+func (repo *DeploymentTargetRepository) UpdateDeploymentTargetMetadata(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	return nil, errors.New("cannot write database")
+}
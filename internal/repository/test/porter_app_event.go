@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
@@ -53,7 +54,27 @@ func (repo *PorterAppEventRepository) ReadDeployEventByAppRevisionID(ctx context
 	return models.PorterAppEvent{}, errors.New("cannot read database")
 }
 
+// ReadBuildEventByAppRevision is a test method
+func (repo *PorterAppEventRepository) ReadBuildEventByAppRevision(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, appRevisionID string, commitSHA string) (models.PorterAppEvent, error) {
+	return models.PorterAppEvent{}, errors.New("cannot read database")
+}
+
 // ReadNotificationsByAppRevisionID is a test method
-func (repo *PorterAppEventRepository) ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceID uuid.UUID, appRevisionID string) ([]*models.PorterAppEvent, error) {
+func (repo *PorterAppEventRepository) ReadNotificationsByAppRevisionID(ctx context.Context, porterAppInstanceID uuid.UUID, appRevisionID string, since time.Time, limit int) ([]*models.PorterAppEvent, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListNotificationsByDeploymentTargetID is a test method
+func (repo *PorterAppEventRepository) ListNotificationsByDeploymentTargetID(ctx context.Context, deploymentTargetID uuid.UUID, appRevisionIDs []string, since time.Time, limit int) ([]*models.PorterAppEvent, error) {
 	return nil, errors.New("cannot read database")
 }
+
+// AcknowledgeNotification is a test method
+func (repo *PorterAppEventRepository) AcknowledgeNotification(ctx context.Context, id uuid.UUID) error {
+	return errors.New("cannot update database")
+}
+
+// AcknowledgeNotifications is a test method
+func (repo *PorterAppEventRepository) AcknowledgeNotifications(ctx context.Context, porterAppID uint, deploymentTargetID uuid.UUID, before time.Time) (int64, error) {
+	return 0, errors.New("cannot update database")
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ProjectWebhookRepository is a test repository that implements repository.ProjectWebhookRepository
+type ProjectWebhookRepository struct {
+	canQuery bool
+}
+
+// NewProjectWebhookRepository returns the test ProjectWebhookRepository
+func NewProjectWebhookRepository() repository.ProjectWebhookRepository {
+	return &ProjectWebhookRepository{canQuery: false}
+}
+
+// Insert inserts a new ProjectWebhook into the db
+func (repo *ProjectWebhookRepository) Insert(ctx context.Context, webhook *models.ProjectWebhook) (*models.ProjectWebhook, error) {
+	return nil, errors.New("cannot write database")
+}
+
+// Get finds a ProjectWebhook by project id and webhook id
+func (repo *ProjectWebhookRepository) Get(ctx context.Context, projectID uint, id uuid.UUID) (*models.ProjectWebhook, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// ListByProjectID returns all ProjectWebhooks in a project
+func (repo *ProjectWebhookRepository) ListByProjectID(ctx context.Context, projectID uint) ([]*models.ProjectWebhook, error) {
+	return nil, errors.New("cannot read database")
+}
+
+// Delete deletes a ProjectWebhook
+func (repo *ProjectWebhookRepository) Delete(ctx context.Context, webhook *models.ProjectWebhook) error {
+	return errors.New("cannot write database")
+}
@@ -54,6 +54,8 @@ type TestRepository struct {
 	deploymentTarget          repository.DeploymentTargetRepository
 	appTemplate               repository.AppTemplateRepository
 	githubWebhook             repository.GithubWebhookRepository
+	projectWebhook            repository.ProjectWebhookRepository
+	auditLog                  repository.AuditLogRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -255,6 +257,16 @@ func (t *TestRepository) GithubWebhook() repository.GithubWebhookRepository {
 	return t.githubWebhook
 }
 
+// ProjectWebhook returns a test ProjectWebhookRepository
+func (t *TestRepository) ProjectWebhook() repository.ProjectWebhookRepository {
+	return t.projectWebhook
+}
+
+// AuditLog returns a test AuditLogRepository
+func (t *TestRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -308,5 +320,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		deploymentTarget:          NewDeploymentTargetRepository(),
 		appTemplate:               NewAppTemplateRepository(),
 		githubWebhook:             NewGithubWebhookRepository(),
+		projectWebhook:            NewProjectWebhookRepository(),
+		auditLog:                  NewAuditLogRepository(canQuery),
 	}
 }
@@ -0,0 +1,48 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// counters caches counters by name, as instruments should only be created once per meter
+var counters sync.Map
+
+// IncrementCounter adds one to the counter with the given name. The name will be namespaced to avoid conflicts, e.g. `porter.notification_conversion_skipped`.
+// Attributes should have a small, fixed set of values, such as a reason, as each combination is exported as its own series.
+// Counters are exported only if metrics are enabled on the TracerConfig, and are otherwise a no-op
+func IncrementCounter(ctx context.Context, name string, attrs ...AttributeKV) {
+	counter, err := counterForName(name)
+	if err != nil {
+		return
+	}
+
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		if val, ok := attr.Value.(string); ok && attr.Key != "" {
+			kvs = append(kvs, attribute.String(string(attr.Key), val))
+		}
+	}
+
+	counter.Add(ctx, 1, metric.WithAttributes(kvs...))
+}
+
+func counterForName(name string) (metric.Int64Counter, error) {
+	if counter, ok := counters.Load(name); ok {
+		return counter.(metric.Int64Counter), nil
+	}
+
+	// the global meter provider delegates to the provider configured in InitTracer, even if the counter is created first
+	counter, err := otel.Meter("").Int64Counter(fmt.Sprintf("porter.%s", name))
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := counters.LoadOrStore(name, counter)
+	return actual.(metric.Int64Counter), nil
+}
@@ -0,0 +1,20 @@
+package telemetry
+
+import "context"
+
+type requestIDContextKey struct{}
+
+// WithRequestID returns a copy of ctx which carries the given request id. Spans created from the returned context
+// using NewSpan will be tagged with the request id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id stored in the context, if one exists
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	requestID, ok := ctx.Value(requestIDContextKey{}).(string)
+	if !ok || requestID == "" {
+		return "", false
+	}
+	return requestID, true
+}
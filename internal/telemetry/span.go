@@ -32,6 +32,10 @@ func NewSpan(ctx context.Context, name string) (context.Context, trace.Span) {
 
 // AddKnownContextVariablesToSpan adds known commonly read context variables to a span
 func AddKnownContextVariablesToSpan(ctx context.Context, span trace.Span) {
+	if requestID, ok := RequestIDFromContext(ctx); ok {
+		WithAttributes(span, AttributeKV{Key: "request-id", Value: requestID})
+	}
+
 	user, ok := ctx.Value(types.UserScope).(*models.User)
 	if ok {
 		WithAttributes(span, AttributeKV{Key: "user-id", Value: user.ID})
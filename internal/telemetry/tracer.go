@@ -12,6 +12,8 @@ type TracerConfig struct {
 	ServiceName string
 	// CollectorURL is the OLTP endpoint for receiving traces
 	CollectorURL string
+	// MetricsEnabled exports metrics recorded with IncrementCounter to the collector alongside traces
+	MetricsEnabled bool
 
 	Debug bool
 }
@@ -43,7 +45,7 @@ func InitTracer(ctx context.Context, conf TracerConfig) (Tracer, error) {
 		otelconfig.WithExporterEndpoint(conf.CollectorURL),
 		otelconfig.WithSpanProcessor(bsp),
 		otelconfig.WithLogLevel("DEBUG"),
-		otelconfig.WithMetricsEnabled(false),  // can turn this on later
+		otelconfig.WithMetricsEnabled(conf.MetricsEnabled),
 		otelconfig.WithExporterInsecure(true), // TODO: disable this before production usage
 		// otelconfig.WithHeaders() // TODO: add in information about runtime environment
 	)